@@ -43,7 +43,7 @@ func main() {
 	fmt.Println("✓ Connected to Redis")
 
 	// Initialize components
-	streamConsumer := consumer.NewStreamConsumer(redisClient, config.ConsumerID, config.GroupName)
+	streamConsumer := consumer.NewStreamConsumerWithRecovery(redisClient, config.ConsumerID, config.GroupName, config.Recovery)
 	alertFilter := filter.NewFilter(config.MinEdgePercent, config.MaxDataAgeSeconds)
 	deduplicator := dedup.NewDeduplicator(redisClient, config.DedupTTLMinutes)
 	rateLimiter := ratelimit.NewTokenBucket(redisClient, config.AlertRateLimit)
@@ -90,6 +90,14 @@ func main() {
 				}
 				fmt.Printf("📊 Metrics: sent=%d filtered=%d rate_limited=%d avg_latency=%.1fms\n",
 					alertsSent, alertsFiltered, alertsRateLimited, avgLatency)
+
+				pending, err := streamConsumer.PendingStats(alertCtx, "opportunities.detected")
+				if err != nil {
+					fmt.Printf("⚠️  Failed to read pending stats: %v\n", err)
+					continue
+				}
+				fmt.Printf("📊 Stream %s: pending=%d lag=%d claimed=%d dead_lettered=%d\n",
+					pending.Stream, pending.Pending, pending.Lag, pending.Claimed, pending.DeadLettered)
 			}
 		}
 	}()
@@ -218,6 +226,7 @@ type Config struct {
 	MaxDataAgeSeconds int
 	AlertRateLimit    int
 	DedupTTLMinutes   int
+	Recovery          consumer.RecoveryConfig
 }
 
 // loadConfig loads configuration from environment variables
@@ -232,6 +241,18 @@ func loadConfig() Config {
 		MaxDataAgeSeconds: getEnvInt("ALERT_MAX_DATA_AGE_SECONDS", 10),
 		AlertRateLimit:    getEnvInt("ALERT_RATE_LIMIT", 10),
 		DedupTTLMinutes:   getEnvInt("ALERT_DEDUP_TTL_MINUTES", 5),
+		Recovery:          loadRecoveryConfig(),
+	}
+}
+
+// loadRecoveryConfig loads pending-entry recovery settings from environment variables
+func loadRecoveryConfig() consumer.RecoveryConfig {
+	defaults := consumer.DefaultRecoveryConfig()
+	return consumer.RecoveryConfig{
+		ClaimInterval: getEnvDuration("STREAM_CLAIM_INTERVAL", defaults.ClaimInterval),
+		MinIdle:       getEnvDuration("STREAM_CLAIM_MIN_IDLE", defaults.MinIdle),
+		MaxDeliveries: int64(getEnvInt("STREAM_MAX_DELIVERIES", int(defaults.MaxDeliveries))),
+		BatchSize:     defaults.BatchSize,
 	}
 }
 
@@ -252,6 +273,15 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
 func getEnvFloat(key string, defaultValue float64) float64 {
 	if value := os.Getenv(key); value != "" {
 		var floatValue float64
@@ -2,12 +2,14 @@ module github.com/XavierBriggs/fortuna/services/alert-service
 
 go 1.23
 
-require github.com/redis/go-redis/v9 v9.7.0
+require (
+	github.com/XavierBriggs/fortuna/services/shared v0.0.0
+	github.com/redis/go-redis/v9 v9.7.0
+)
 
 require (
 	github.com/cespare/xxhash/v2 v2.2.0 // indirect
 	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
 )
 
-
-
+replace github.com/XavierBriggs/fortuna/services/shared => ../shared
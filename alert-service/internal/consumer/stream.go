@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/XavierBriggs/fortuna/services/alert-service/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/shared/streams"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -15,6 +16,7 @@ type StreamConsumer struct {
 	client     *redis.Client
 	consumerID string
 	groupName  string
+	recovery   *streams.PendingRecovery
 }
 
 // Message represents a stream message with an opportunity
@@ -24,12 +26,18 @@ type Message struct {
 	Opportunity models.Opportunity
 }
 
-// NewStreamConsumer creates a new stream consumer
+// NewStreamConsumer creates a new stream consumer with default pending recovery
 func NewStreamConsumer(client *redis.Client, consumerID, groupName string) *StreamConsumer {
+	return NewStreamConsumerWithRecovery(client, consumerID, groupName, DefaultRecoveryConfig())
+}
+
+// NewStreamConsumerWithRecovery creates a stream consumer with custom pending recovery
+func NewStreamConsumerWithRecovery(client *redis.Client, consumerID, groupName string, recovery RecoveryConfig) *StreamConsumer {
 	return &StreamConsumer{
 		client:     client,
 		consumerID: consumerID,
 		groupName:  groupName,
+		recovery:   streams.NewPendingRecovery(client, groupName, consumerID, recovery, nil),
 	}
 }
 
@@ -56,6 +64,15 @@ func (c *StreamConsumer) ConsumeStream(ctx context.Context, streamKey string) (<
 			case <-ctx.Done():
 				return
 			default:
+				// Retry entries left pending by a crashed consumer (or by us before a restart)
+				if c.recovery.Due(streamKey) {
+					claimed, err := c.recovery.Claim(ctx, streamKey)
+					if err != nil {
+						errorCh <- fmt.Errorf("error claiming pending entries: %w", err)
+					}
+					c.deliver(streamKey, claimed, messageCh, errorCh)
+				}
+
 				// Read from stream
 				streams, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{
 					Group:    c.groupName,
@@ -79,15 +96,7 @@ func (c *StreamConsumer) ConsumeStream(ctx context.Context, streamKey string) (<
 
 				// Process messages
 				for _, stream := range streams {
-					for _, message := range stream.Messages {
-						msg, err := c.parseMessage(streamKey, message)
-						if err != nil {
-							errorCh <- fmt.Errorf("error parsing message %s: %w", message.ID, err)
-							continue
-						}
-
-						messageCh <- msg
-					}
+					c.deliver(streamKey, stream.Messages, messageCh, errorCh)
 				}
 			}
 		}
@@ -96,6 +105,20 @@ func (c *StreamConsumer) ConsumeStream(ctx context.Context, streamKey string) (<
 	return messageCh, errorCh
 }
 
+// deliver parses messages onto messageCh
+// Unparseable messages stay pending until recovery dead-letters them
+func (c *StreamConsumer) deliver(streamKey string, messages []redis.XMessage, messageCh chan<- Message, errorCh chan<- error) {
+	for _, message := range messages {
+		msg, err := c.parseMessage(streamKey, message)
+		if err != nil {
+			errorCh <- fmt.Errorf("error parsing message %s: %w", message.ID, err)
+			continue
+		}
+
+		messageCh <- msg
+	}
+}
+
 // parseMessage parses a Redis stream message into a Message
 func (c *StreamConsumer) parseMessage(streamKey string, xmsg redis.XMessage) (Message, error) {
 	// Get the JSON payload
@@ -122,6 +145,7 @@ func (c *StreamConsumer) AckMessage(ctx context.Context, streamKey, messageID st
 	return c.client.XAck(ctx, streamKey, c.groupName, messageID).Err()
 }
 
-
-
-
+// PendingStats returns the backlog and recovery counts for a stream
+func (c *StreamConsumer) PendingStats(ctx context.Context, streamKey string) (PendingStats, error) {
+	return c.recovery.Stats(ctx, streamKey)
+}
@@ -88,8 +88,12 @@ func (s *SlackNotifier) formatMessage(opp models.Opportunity) string {
 
 	// Legs
 	for i, leg := range opp.Legs {
+		outcome := leg.OutcomeName
+		if leg.PlayerName != "" {
+			outcome = fmt.Sprintf("%s %s %s", leg.PlayerName, leg.Stat, leg.OutcomeName)
+		}
 		sb.WriteString(fmt.Sprintf("*Leg %d:* %s | %s @ %s",
-			i+1, leg.BookKey, leg.OutcomeName, s.formatOdds(leg.Price)))
+			i+1, leg.BookKey, outcome, s.formatOdds(leg.Price)))
 
 		if leg.Point != nil {
 			sb.WriteString(fmt.Sprintf(" (%.1f)", *leg.Point))
@@ -126,6 +130,10 @@ func (s *SlackNotifier) getEmojiForType(oppType string) string {
 		return "🎯"
 	case "scalp":
 		return "⚡"
+	case "stale_line":
+		return "⏱️"
+	case "player_prop":
+		return "🏀"
 	default:
 		return "📊"
 	}
@@ -20,6 +20,8 @@ type Opportunity struct {
 type OpportunityLeg struct {
 	BookKey        string   `json:"book_key"`
 	OutcomeName    string   `json:"outcome_name"`
+	PlayerName     string   `json:"player_name,omitempty"` // Props only
+	Stat           string   `json:"stat,omitempty"`        // Props only, e.g. points
 	Price          int      `json:"price"`
 	Point          *float64 `json:"point,omitempty"`
 	LegEdgePercent *float64 `json:"leg_edge_pct,omitempty"`
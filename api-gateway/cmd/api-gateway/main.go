@@ -93,7 +93,9 @@ func main() {
 	opportunityHandler := handlers.NewOpportunityHandler(holocronDB, alexandriaDB)
 	betHandler := handlers.NewBetHandler(holocronClient)
 	settingsHandler := handlers.NewSettingsHandler(holocronClient)
+	detectorConfigHandler := handlers.NewDetectorConfigHandler(holocronClient, redisClient)
 	gamesHandler := handlers.NewGamesHandler(redisClient)
+	holdHandler := handlers.NewHoldHandler(redisClient)
 	minervaHandler := handlers.NewMinervaHandler(config.MinervaURL)
 	botHandler := handlers.NewBotHandler(config.BotServiceURL, holocronDB, alexandriaDB, atlasDB)
 
@@ -133,6 +135,7 @@ func main() {
 
 		// Books
 		r.Get("/books", handler.GetBooks)
+		r.Get("/books/hold", holdHandler.GetBookHold)
 
 		// Opportunities
 		r.Get("/opportunities", opportunityHandler.GetOpportunities)
@@ -155,6 +158,12 @@ func main() {
 		r.Get("/settings", settingsHandler.GetSettings)
 		r.Put("/settings", settingsHandler.UpdateSettings)
 
+		// Detector config (edge detector thresholds, applied live)
+		r.Get("/detector-config", detectorConfigHandler.GetDetectorConfigs)
+		r.Get("/detector-config/{sport}", detectorConfigHandler.GetDetectorConfig)
+		r.Put("/detector-config/{sport}", detectorConfigHandler.UpdateDetectorConfig)
+		r.Get("/detector-config/{sport}/history", detectorConfigHandler.GetDetectorConfigHistory)
+
 		// Games (live scores and box scores from game-stats-service)
 		r.Get("/games/today", gamesHandler.HandleGetTodaysGames)
 		r.Get("/games/{game_id}", gamesHandler.HandleGetGame)
@@ -223,6 +232,10 @@ func main() {
 		fmt.Println("    GET  /api/v1/bets/summary")
 		fmt.Println("    GET  /api/v1/settings")
 		fmt.Println("    PUT  /api/v1/settings")
+		fmt.Println("    GET  /api/v1/detector-config")
+		fmt.Println("    GET  /api/v1/detector-config/{sport}")
+		fmt.Println("    PUT  /api/v1/detector-config/{sport}")
+		fmt.Println("    GET  /api/v1/detector-config/{sport}/history")
 		fmt.Println("    GET  /api/v1/games/today")
 		fmt.Println("    GET  /api/v1/games/{game_id}")
 		fmt.Println("    GET  /api/v1/games/{game_id}/boxscore")
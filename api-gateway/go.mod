@@ -3,6 +3,7 @@ module github.com/XavierBriggs/fortuna/services/api-gateway
 go 1.21
 
 require (
+	github.com/XavierBriggs/fortuna/services/shared v0.0.0
 	github.com/go-chi/chi/v5 v5.0.11
 	github.com/go-chi/cors v1.2.1
 	github.com/lib/pq v1.10.9
@@ -13,3 +14,5 @@ require (
 	github.com/cespare/xxhash/v2 v2.2.0 // indirect
 	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
 )
+
+replace github.com/XavierBriggs/fortuna/services/shared => ../shared
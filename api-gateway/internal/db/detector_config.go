@@ -0,0 +1,332 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/XavierBriggs/fortuna/services/api-gateway/pkg/models"
+	"github.com/lib/pq"
+)
+
+// ErrDetectorConfigConflict is returned when an update's version is no longer current
+var ErrDetectorConfigConflict = errors.New("detector config was changed by someone else")
+
+// DetectorConfigStore reads and updates Holocron detector_config and its audit history
+type DetectorConfigStore interface {
+	GetDetectorConfigs(ctx context.Context) ([]*models.DetectorConfig, error)
+	GetDetectorConfig(ctx context.Context, sportKey string) (*models.DetectorConfig, error)
+	UpdateDetectorConfig(ctx context.Context, sportKey string, update *models.DetectorConfigUpdate) (*models.DetectorConfig, error)
+	GetDetectorConfigHistory(ctx context.Context, sportKey string, limit int) ([]*models.DetectorConfigAudit, error)
+}
+
+// detectorConfigSelect is the column list scanned by scanDetectorConfig
+const detectorConfigSelect = `
+	SELECT sport_key, min_edge_pct, max_data_age_seconds, enabled_markets,
+	       stale_line_min_move_cents, stale_line_window_seconds,
+	       enable_middles, enable_scalps, enable_stale_lines, enable_player_props,
+	       version, updated_by, updated_at
+	FROM detector_config
+`
+
+// scanDetectorConfig scans a row selected with detectorConfigSelect
+func scanDetectorConfig(row interface{ Scan(...interface{}) error }) (*models.DetectorConfig, error) {
+	config := &models.DetectorConfig{}
+	var enabledMarkets pq.StringArray
+
+	err := row.Scan(
+		&config.SportKey,
+		&config.MinEdgePct,
+		&config.MaxDataAgeSeconds,
+		&enabledMarkets,
+		&config.StaleLineMinMoveCents,
+		&config.StaleLineWindowSeconds,
+		&config.EnableMiddles,
+		&config.EnableScalps,
+		&config.EnableStaleLines,
+		&config.EnablePlayerProps,
+		&config.Version,
+		&config.UpdatedBy,
+		&config.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if enabledMarkets != nil {
+		config.EnabledMarkets = []string(enabledMarkets)
+	}
+	return config, nil
+}
+
+// GetDetectorConfigs returns every sport's detector config
+func (h *HolocronPostgres) GetDetectorConfigs(ctx context.Context) ([]*models.DetectorConfig, error) {
+	rows, err := h.db.QueryContext(ctx, detectorConfigSelect+" ORDER BY sport_key")
+	if err != nil {
+		return nil, fmt.Errorf("query detector config: %w", err)
+	}
+	defer rows.Close()
+
+	configs := make([]*models.DetectorConfig, 0)
+	for rows.Next() {
+		config, err := scanDetectorConfig(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan detector config: %w", err)
+		}
+		configs = append(configs, config)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate detector config: %w", err)
+	}
+
+	return configs, nil
+}
+
+// GetDetectorConfig returns a sport's detector config (nil when the sport has no row)
+func (h *HolocronPostgres) GetDetectorConfig(ctx context.Context, sportKey string) (*models.DetectorConfig, error) {
+	config, err := scanDetectorConfig(h.db.QueryRowContext(ctx, detectorConfigSelect+" WHERE sport_key = $1", sportKey))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query detector config: %w", err)
+	}
+	return config, nil
+}
+
+// UpdateDetectorConfig replaces a sport's thresholds, bumps its version and records the
+// change in detector_config_audit, all in one transaction
+func (h *HolocronPostgres) UpdateDetectorConfig(ctx context.Context, sportKey string, update *models.DetectorConfigUpdate) (*models.DetectorConfig, error) {
+	tx, err := h.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the current row so concurrent updates get consecutive versions
+	previous, err := scanDetectorConfig(tx.QueryRowContext(ctx, detectorConfigSelect+" WHERE sport_key = $1 FOR UPDATE", sportKey))
+	if err == sql.ErrNoRows {
+		previous = nil
+	} else if err != nil {
+		return nil, fmt.Errorf("query detector config: %w", err)
+	}
+
+	var currentVersion int64
+	if previous != nil {
+		currentVersion = previous.Version
+	}
+	if update.Version != nil && *update.Version != currentVersion {
+		return nil, ErrDetectorConfigConflict
+	}
+
+	config := &models.DetectorConfig{
+		SportKey:           sportKey,
+		DetectorThresholds: update.DetectorThresholds,
+		Version:            currentVersion + 1,
+		UpdatedBy:          update.UpdatedBy,
+	}
+
+	query := `
+		INSERT INTO detector_config (
+			sport_key, min_edge_pct, max_data_age_seconds, enabled_markets,
+			stale_line_min_move_cents, stale_line_window_seconds,
+			enable_middles, enable_scalps, enable_stale_lines, enable_player_props,
+			version, updated_by, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NOW())
+		ON CONFLICT (sport_key) DO UPDATE SET
+			min_edge_pct = EXCLUDED.min_edge_pct,
+			max_data_age_seconds = EXCLUDED.max_data_age_seconds,
+			enabled_markets = EXCLUDED.enabled_markets,
+			stale_line_min_move_cents = EXCLUDED.stale_line_min_move_cents,
+			stale_line_window_seconds = EXCLUDED.stale_line_window_seconds,
+			enable_middles = EXCLUDED.enable_middles,
+			enable_scalps = EXCLUDED.enable_scalps,
+			enable_stale_lines = EXCLUDED.enable_stale_lines,
+			enable_player_props = EXCLUDED.enable_player_props,
+			version = EXCLUDED.version,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+		RETURNING updated_at
+	`
+
+	err = tx.QueryRowContext(
+		ctx, query,
+		sportKey,
+		config.MinEdgePct,
+		config.MaxDataAgeSeconds,
+		pq.Array(config.EnabledMarkets),
+		config.StaleLineMinMoveCents,
+		config.StaleLineWindowSeconds,
+		config.EnableMiddles,
+		config.EnableScalps,
+		config.EnableStaleLines,
+		config.EnablePlayerProps,
+		config.Version,
+		config.UpdatedBy,
+	).Scan(&config.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("update detector config: %w", err)
+	}
+
+	if err := insertDetectorConfigAudit(ctx, tx, previous, config, update.Reason); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return config, nil
+}
+
+// insertDetectorConfigAudit records a change with the rows before and after and the changed fields
+func insertDetectorConfigAudit(ctx context.Context, tx *sql.Tx, previous, config *models.DetectorConfig, reason string) error {
+	var previousJSON interface{} // NULL when the change created the row
+	var previousThresholds models.DetectorThresholds
+	if previous != nil {
+		data, err := json.Marshal(previous)
+		if err != nil {
+			return fmt.Errorf("marshal previous detector config: %w", err)
+		}
+		previousJSON = data
+		previousThresholds = previous.DetectorThresholds
+	}
+
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("marshal detector config: %w", err)
+	}
+
+	changes, err := diffThresholds(previousThresholds, config.DetectorThresholds)
+	if err != nil {
+		return err
+	}
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("marshal detector config changes: %w", err)
+	}
+
+	var reasonValue sql.NullString
+	if reason != "" {
+		reasonValue = sql.NullString{String: reason, Valid: true}
+	}
+
+	query := `
+		INSERT INTO detector_config_audit (sport_key, version, changed_by, reason, previous, config, changes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err = tx.ExecContext(ctx, query,
+		config.SportKey,
+		config.Version,
+		config.UpdatedBy,
+		reasonValue,
+		previousJSON,
+		configJSON,
+		changesJSON,
+	)
+	if err != nil {
+		return fmt.Errorf("insert detector config audit: %w", err)
+	}
+
+	return nil
+}
+
+// diffThresholds returns the fields whose JSON value changed, by JSON name
+func diffThresholds(previous, current models.DetectorThresholds) (map[string]models.DetectorConfigChange, error) {
+	before, err := thresholdFields(previous)
+	if err != nil {
+		return nil, err
+	}
+	after, err := thresholdFields(current)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]models.DetectorConfigChange)
+	for field, value := range after {
+		if !bytes.Equal(before[field], value) {
+			changes[field] = models.DetectorConfigChange{Old: before[field], New: value}
+		}
+	}
+	return changes, nil
+}
+
+// thresholdFields marshals thresholds to their JSON fields
+func thresholdFields(thresholds models.DetectorThresholds) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(thresholds)
+	if err != nil {
+		return nil, fmt.Errorf("marshal detector thresholds: %w", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("unmarshal detector thresholds: %w", err)
+	}
+	return fields, nil
+}
+
+// GetDetectorConfigHistory returns a sport's detector config changes, newest first
+func (h *HolocronPostgres) GetDetectorConfigHistory(ctx context.Context, sportKey string, limit int) ([]*models.DetectorConfigAudit, error) {
+	query := `
+		SELECT id, sport_key, version, changed_by, reason, previous, config, changes, changed_at
+		FROM detector_config_audit
+		WHERE sport_key = $1
+		ORDER BY changed_at DESC, id DESC
+		LIMIT $2
+	`
+
+	rows, err := h.db.QueryContext(ctx, query, sportKey, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query detector config history: %w", err)
+	}
+	defer rows.Close()
+
+	history := make([]*models.DetectorConfigAudit, 0)
+	for rows.Next() {
+		entry := &models.DetectorConfigAudit{}
+		var reason sql.NullString
+		var previousJSON, configJSON, changesJSON []byte
+
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.SportKey,
+			&entry.Version,
+			&entry.ChangedBy,
+			&reason,
+			&previousJSON,
+			&configJSON,
+			&changesJSON,
+			&entry.ChangedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan detector config history: %w", err)
+		}
+
+		if reason.Valid {
+			entry.Reason = &reason.String
+		}
+		if previousJSON != nil {
+			entry.Previous = &models.DetectorConfig{}
+			if err := json.Unmarshal(previousJSON, entry.Previous); err != nil {
+				return nil, fmt.Errorf("parse previous detector config JSON: %w", err)
+			}
+		}
+		if err := json.Unmarshal(configJSON, &entry.Config); err != nil {
+			return nil, fmt.Errorf("parse detector config JSON: %w", err)
+		}
+		if err := json.Unmarshal(changesJSON, &entry.Changes); err != nil {
+			return nil, fmt.Errorf("parse detector config changes JSON: %w", err)
+		}
+
+		history = append(history, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate detector config history: %w", err)
+	}
+
+	return history, nil
+}
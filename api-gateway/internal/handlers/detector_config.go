@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/api-gateway/internal/db"
+	"github.com/XavierBriggs/fortuna/services/api-gateway/pkg/models"
+	"github.com/go-chi/chi/v5"
+	"github.com/redis/go-redis/v9"
+)
+
+// detectorConfigChannel is the Redis pub/sub channel the edge detector reloads its config on
+const detectorConfigChannel = "detector.config.updated"
+
+var configKeyPattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// ConfigPublisher announces detector config changes (satisfied by *redis.Client)
+type ConfigPublisher interface {
+	Publish(ctx context.Context, channel string, message interface{}) *redis.IntCmd
+}
+
+// DetectorConfigHandler serves and updates the edge detector's live thresholds
+type DetectorConfigHandler struct {
+	store     db.DetectorConfigStore
+	publisher ConfigPublisher
+}
+
+// NewDetectorConfigHandler creates a new detector config handler
+func NewDetectorConfigHandler(store db.DetectorConfigStore, publisher ConfigPublisher) *DetectorConfigHandler {
+	return &DetectorConfigHandler{
+		store:     store,
+		publisher: publisher,
+	}
+}
+
+// GetDetectorConfigs returns every sport's detector config
+// GET /api/v1/detector-config
+func (h *DetectorConfigHandler) GetDetectorConfigs(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	configs, err := h.store.GetDetectorConfigs(ctx)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to retrieve detector config", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"configs": configs,
+		"count":   len(configs),
+	})
+}
+
+// GetDetectorConfig returns a sport's detector config
+// GET /api/v1/detector-config/{sport}
+func (h *DetectorConfigHandler) GetDetectorConfig(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	sportKey := chi.URLParam(r, "sport")
+	if !configKeyPattern.MatchString(sportKey) {
+		respondError(w, http.StatusBadRequest, "invalid sport key", nil)
+		return
+	}
+
+	config, err := h.store.GetDetectorConfig(ctx, sportKey)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to retrieve detector config", err)
+		return
+	}
+
+	if config == nil {
+		respondError(w, http.StatusNotFound, "no detector config for sport (environment defaults apply)", nil)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, config)
+}
+
+// UpdateDetectorConfig replaces a sport's thresholds and tells the edge detector to reload
+// Omitted fields go back to the edge detector's environment defaults.
+// PUT /api/v1/detector-config/{sport}
+func (h *DetectorConfigHandler) UpdateDetectorConfig(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	sportKey := chi.URLParam(r, "sport")
+	if !configKeyPattern.MatchString(sportKey) {
+		respondError(w, http.StatusBadRequest, "invalid sport key", nil)
+		return
+	}
+
+	var update models.DetectorConfigUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+
+	if err := validateDetectorThresholds(update.DetectorThresholds); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	// For now, changes are attributed to whoever the request names
+	if update.UpdatedBy == "" {
+		update.UpdatedBy = "api"
+	}
+	if len(update.UpdatedBy) > 100 {
+		respondError(w, http.StatusBadRequest, "updated_by must be at most 100 characters", nil)
+		return
+	}
+
+	config, err := h.store.UpdateDetectorConfig(ctx, sportKey, &update)
+	if errors.Is(err, db.ErrDetectorConfigConflict) {
+		respondError(w, http.StatusConflict, "detector config changed since version was read; reload and retry", nil)
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to update detector config", err)
+		return
+	}
+
+	// The edge detector also reloads on its own interval, so a failed notification only delays the change
+	notified := true
+	notice, _ := json.Marshal(models.DetectorConfigNotice{SportKey: sportKey, Version: config.Version})
+	if err := h.publisher.Publish(ctx, detectorConfigChannel, notice).Err(); err != nil {
+		fmt.Printf("⚠️  Failed to publish detector config change for %s: %v\n", sportKey, err)
+		notified = false
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"status":   "updated",
+		"config":   config,
+		"notified": notified,
+	})
+}
+
+// GetDetectorConfigHistory returns a sport's detector config changes, newest first
+// GET /api/v1/detector-config/{sport}/history?limit={n}
+func (h *DetectorConfigHandler) GetDetectorConfigHistory(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	sportKey := chi.URLParam(r, "sport")
+	if !configKeyPattern.MatchString(sportKey) {
+		respondError(w, http.StatusBadRequest, "invalid sport key", nil)
+		return
+	}
+
+	limit := 50
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed <= 0 || parsed > 500 {
+			respondError(w, http.StatusBadRequest, "limit must be between 1 and 500", nil)
+			return
+		}
+		limit = parsed
+	}
+
+	history, err := h.store.GetDetectorConfigHistory(ctx, sportKey, limit)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to retrieve detector config history", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"history": history,
+		"count":   len(history),
+	})
+}
+
+// validateDetectorThresholds applies the detector_config table's bounds
+func validateDetectorThresholds(thresholds models.DetectorThresholds) error {
+	if thresholds.MinEdgePct != nil && (*thresholds.MinEdgePct <= 0 || *thresholds.MinEdgePct > 0.5) {
+		return fmt.Errorf("min_edge_pct must be greater than 0 and at most 0.5 (0.01 = 1%%)")
+	}
+	if thresholds.MaxDataAgeSeconds != nil && (*thresholds.MaxDataAgeSeconds < 1 || *thresholds.MaxDataAgeSeconds > 3600) {
+		return fmt.Errorf("max_data_age_seconds must be between 1 and 3600")
+	}
+	if thresholds.EnabledMarkets != nil && len(thresholds.EnabledMarkets) == 0 {
+		return fmt.Errorf("enabled_markets must not be empty (omit it to use the default)")
+	}
+	seen := make(map[string]bool)
+	for _, market := range thresholds.EnabledMarkets {
+		if !configKeyPattern.MatchString(market) {
+			return fmt.Errorf("invalid market key %q in enabled_markets", market)
+		}
+		if seen[market] {
+			return fmt.Errorf("duplicate market key %q in enabled_markets", market)
+		}
+		seen[market] = true
+	}
+	if thresholds.StaleLineMinMoveCents != nil && (*thresholds.StaleLineMinMoveCents < 1 || *thresholds.StaleLineMinMoveCents > 500) {
+		return fmt.Errorf("stale_line_min_move_cents must be between 1 and 500")
+	}
+	if thresholds.StaleLineWindowSeconds != nil && (*thresholds.StaleLineWindowSeconds < 10 || *thresholds.StaleLineWindowSeconds > 86400) {
+		return fmt.Errorf("stale_line_window_seconds must be between 10 and 86400")
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/XavierBriggs/fortuna/services/api-gateway/internal/db"
 	"github.com/XavierBriggs/fortuna/services/api-gateway/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/shared/odds"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -107,11 +108,16 @@ func (h *Handler) GetEvent(w http.ResponseWriter, r *http.Request) {
 }
 
 // GetCurrentOdds retrieves the latest odds with filtering
-// Query params: event_id, sport, market, book, limit, offset
+// Query params: event_id, sport, market, book, odds_format, limit, offset
 func (h *Handler) GetCurrentOdds(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 
+	format, ok := parseOddsFormat(w, r)
+	if !ok {
+		return
+	}
+
 	// Parse query parameters
 	eventID := r.URL.Query().Get("event_id")
 	sportKey := r.URL.Query().Get("sport")
@@ -140,6 +146,12 @@ func (h *Handler) GetCurrentOdds(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if format != "" {
+		for i := range odds {
+			odds[i].PriceDisplay = displayPrice(odds[i].Price, format)
+		}
+	}
+
 	respondJSON(w, http.StatusOK, map[string]interface{}{
 		"odds":   odds,
 		"count":  len(odds),
@@ -149,11 +161,16 @@ func (h *Handler) GetCurrentOdds(w http.ResponseWriter, r *http.Request) {
 }
 
 // GetOddsHistory retrieves historical odds data
-// Query params: event_id, market, book, since, until, limit, offset
+// Query params: event_id, market, book, since, until, odds_format, limit, offset
 func (h *Handler) GetOddsHistory(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 	defer cancel()
 
+	format, ok := parseOddsFormat(w, r)
+	if !ok {
+		return
+	}
+
 	// Parse query parameters
 	eventID := r.URL.Query().Get("event_id")
 	marketKey := r.URL.Query().Get("market")
@@ -195,6 +212,12 @@ func (h *Handler) GetOddsHistory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if format != "" {
+		for i := range history {
+			history[i].PriceDisplay = displayPrice(history[i].Price, format)
+		}
+	}
+
 	respondJSON(w, http.StatusOK, map[string]interface{}{
 		"history": history,
 		"count":   len(history),
@@ -204,10 +227,16 @@ func (h *Handler) GetOddsHistory(w http.ResponseWriter, r *http.Request) {
 }
 
 // GetEventWithOdds retrieves an event with its current odds
+// Query params: odds_format
 func (h *Handler) GetEventWithOdds(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 
+	format, ok := parseOddsFormat(w, r)
+	if !ok {
+		return
+	}
+
 	eventID := chi.URLParam(r, "eventID")
 	if eventID == "" {
 		respondError(w, http.StatusBadRequest, "event_id is required", nil)
@@ -225,6 +254,12 @@ func (h *Handler) GetEventWithOdds(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if format != "" {
+		for i := range eventWithOdds.CurrentOdds {
+			eventWithOdds.CurrentOdds[i].PriceDisplay = displayPrice(eventWithOdds.CurrentOdds[i].Price, format)
+		}
+	}
+
 	respondJSON(w, http.StatusOK, eventWithOdds)
 }
 
@@ -247,6 +282,31 @@ func (h *Handler) GetBooks(w http.ResponseWriter, r *http.Request) {
 
 // Helper functions
 
+// parseOddsFormat reads the odds_format query param, responding 400 when it's unsupported
+// Returns an empty format when the param is absent (prices stay American only)
+func parseOddsFormat(w http.ResponseWriter, r *http.Request) (odds.Format, bool) {
+	value := r.URL.Query().Get("odds_format")
+	if value == "" {
+		return "", true
+	}
+
+	format, err := odds.ParseFormat(value)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "odds_format must be one of american, decimal, fractional, hongkong, malay, indonesian", nil)
+		return "", false
+	}
+	return format, true
+}
+
+// displayPrice renders an American price in the requested format (falls back to the raw price)
+func displayPrice(american int, format odds.Format) string {
+	display, err := odds.FormatAmerican(american, format)
+	if err != nil {
+		return strconv.Itoa(american)
+	}
+	return display
+}
+
 func parseIntParam(r *http.Request, param string, defaultValue int) int {
 	valueStr := r.URL.Query().Get(param)
 	if valueStr == "" {
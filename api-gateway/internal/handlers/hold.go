@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/api-gateway/pkg/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// holdKeyPrefix is the normalizer's per-sport hold hash (books.hold.{sport_key})
+const holdKeyPrefix = "books.hold."
+
+// HoldHandler serves per-book hold summaries
+type HoldHandler struct {
+	redisClient *redis.Client
+}
+
+// NewHoldHandler creates a new hold handler
+func NewHoldHandler(redisClient *redis.Client) *HoldHandler {
+	return &HoldHandler{
+		redisClient: redisClient,
+	}
+}
+
+// GetBookHold returns each book's rolling hold by sport and market type, lowest hold first
+// GET /api/v1/books/hold?sport={sport_key}&market_type={type}&book={book_key}&soft_only=true
+func (h *HoldHandler) GetBookHold(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	query := r.URL.Query()
+	marketType := query.Get("market_type")
+	bookKey := query.Get("book")
+	softOnly := query.Get("soft_only") == "true"
+
+	keys, err := h.holdKeys(ctx, query.Get("sport"))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "failed to retrieve book hold", err)
+		return
+	}
+
+	holds := make([]models.BookHold, 0)
+	for _, key := range keys {
+		values, err := h.redisClient.HGetAll(ctx, key).Result()
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "failed to retrieve book hold", err)
+			return
+		}
+
+		for field, value := range values {
+			var hold models.BookHold
+			if err := json.Unmarshal([]byte(value), &hold); err != nil {
+				fmt.Printf("⚠️  Skipping unparseable hold %s %s: %v\n", key, field, err)
+				continue
+			}
+			if (marketType != "" && hold.MarketType != marketType) ||
+				(bookKey != "" && hold.BookKey != bookKey) ||
+				(softOnly && hold.IsSharp) {
+				continue
+			}
+			holds = append(holds, hold)
+		}
+	}
+
+	sort.Slice(holds, func(i, j int) bool {
+		if holds[i].AvgHoldPct != holds[j].AvgHoldPct {
+			return holds[i].AvgHoldPct < holds[j].AvgHoldPct
+		}
+		if holds[i].SportKey != holds[j].SportKey {
+			return holds[i].SportKey < holds[j].SportKey
+		}
+		if holds[i].MarketType != holds[j].MarketType {
+			return holds[i].MarketType < holds[j].MarketType
+		}
+		return holds[i].BookKey < holds[j].BookKey
+	})
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"holds": holds,
+		"count": len(holds),
+	})
+}
+
+// holdKeys returns the hold hash for a sport, or every sport's hash when none is given
+func (h *HoldHandler) holdKeys(ctx context.Context, sportKey string) ([]string, error) {
+	if sportKey != "" {
+		return []string{holdKeyPrefix + sportKey}, nil
+	}
+
+	var keys []string
+	iter := h.redisClient.Scan(ctx, 0, holdKeyPrefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("scanning hold keys: %w", err)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
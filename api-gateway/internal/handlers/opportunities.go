@@ -26,8 +26,8 @@ func NewOpportunityHandler(holocronDB *sql.DB, alexandriaDB *sql.DB) *Opportunit
 	}
 }
 
-// GetOpportunities retrieves opportunities with filtering
-// Query params: type, sport, since, limit, offset
+// GetOpportunities retrieves opportunities seen in the last hour with filtering
+// Query params: type, sport, status (open, closed), since, limit, offset
 func (h *OpportunityHandler) GetOpportunities(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
@@ -35,6 +35,7 @@ func (h *OpportunityHandler) GetOpportunities(w http.ResponseWriter, r *http.Req
 	// Parse query parameters
 	oppType := r.URL.Query().Get("type")
 	sportKey := r.URL.Query().Get("sport")
+	status := r.URL.Query().Get("status")
 	sinceStr := r.URL.Query().Get("since")
 	limit := parseIntParam(r, "limit", 50)
 	offset := parseIntParam(r, "offset", 0)
@@ -43,10 +44,11 @@ func (h *OpportunityHandler) GetOpportunities(w http.ResponseWriter, r *http.Req
 	// Using dblink to query Alexandria from Holocron
 	query := `
 		SELECT o.id, o.opportunity_type, o.sport_key, o.event_id, o.market_key,
-		       o.edge_pct, o.fair_price, o.detected_at, o.data_age_seconds
+		       o.edge_pct, o.fair_price, o.detected_at, o.data_age_seconds,
+		       o.first_seen_at, o.last_seen_at, o.peak_edge_pct, o.closed_at, o.close_reason
 		FROM opportunities o
 		WHERE 1=1
-		  AND o.detected_at > NOW() - INTERVAL '1 hour'
+		  AND o.last_seen_at > NOW() - INTERVAL '1 hour'
 	`
 	args := []interface{}{}
 	argCount := 1
@@ -63,15 +65,26 @@ func (h *OpportunityHandler) GetOpportunities(w http.ResponseWriter, r *http.Req
 		argCount++
 	}
 
+	switch status {
+	case "open":
+		query += " AND o.closed_at IS NULL"
+	case "closed":
+		query += " AND o.closed_at IS NOT NULL"
+	case "":
+	default:
+		respondError(w, http.StatusBadRequest, "invalid status", nil)
+		return
+	}
+
 	if sinceStr != "" {
 		if since, err := time.Parse(time.RFC3339, sinceStr); err == nil {
-			query += fmt.Sprintf(" AND o.detected_at >= $%d", argCount)
+			query += fmt.Sprintf(" AND o.last_seen_at >= $%d", argCount)
 			args = append(args, since)
 			argCount++
 		}
 	}
 
-	query += " ORDER BY o.detected_at DESC"
+	query += " ORDER BY o.last_seen_at DESC"
 	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argCount, argCount+1)
 	args = append(args, limit, offset)
 
@@ -93,9 +106,12 @@ func (h *OpportunityHandler) GetOpportunities(w http.ResponseWriter, r *http.Req
 		var fairPrice sql.NullInt32
 		var detectedAt time.Time
 		var dataAge int
+		var lifecycle opportunityLifecycle
 
 		err := rows.Scan(&id, &oppType, &sportKey, &eventID, &marketKey,
-			&edgePct, &fairPrice, &detectedAt, &dataAge)
+			&edgePct, &fairPrice, &detectedAt, &dataAge,
+			&lifecycle.firstSeenAt, &lifecycle.lastSeenAt, &lifecycle.peakEdgePct,
+			&lifecycle.closedAt, &lifecycle.closeReason)
 		if err != nil {
 			continue
 		}
@@ -114,6 +130,7 @@ func (h *OpportunityHandler) GetOpportunities(w http.ResponseWriter, r *http.Req
 		if fairPrice.Valid {
 			opp["fair_price"] = fairPrice.Int32
 		}
+		lifecycle.addTo(opp)
 
 		// Get legs for this opportunity
 		legs, _ := h.getOpportunityLegs(ctx, id)
@@ -175,7 +192,8 @@ func (h *OpportunityHandler) GetOpportunity(w http.ResponseWriter, r *http.Reque
 
 	query := `
 		SELECT o.id, o.opportunity_type, o.sport_key, o.event_id, o.market_key,
-		       o.edge_pct, o.fair_price, o.detected_at, o.data_age_seconds
+		       o.edge_pct, o.fair_price, o.detected_at, o.data_age_seconds,
+		       o.first_seen_at, o.last_seen_at, o.peak_edge_pct, o.closed_at, o.close_reason
 		FROM opportunities o
 		WHERE o.id = $1
 	`
@@ -185,10 +203,13 @@ func (h *OpportunityHandler) GetOpportunity(w http.ResponseWriter, r *http.Reque
 	var fairPrice sql.NullInt32
 	var detectedAt time.Time
 	var dataAge int
+	var lifecycle opportunityLifecycle
 
 	err = h.holocronDB.QueryRowContext(ctx, query, id).Scan(
 		&id, &oppType, &sportKey, &eventID, &marketKey,
-		&edgePct, &fairPrice, &detectedAt, &dataAge)
+		&edgePct, &fairPrice, &detectedAt, &dataAge,
+		&lifecycle.firstSeenAt, &lifecycle.lastSeenAt, &lifecycle.peakEdgePct,
+		&lifecycle.closedAt, &lifecycle.closeReason)
 
 	if err == sql.ErrNoRows {
 		respondError(w, http.StatusNotFound, "opportunity not found", nil)
@@ -213,6 +234,7 @@ func (h *OpportunityHandler) GetOpportunity(w http.ResponseWriter, r *http.Reque
 	if fairPrice.Valid {
 		opp["fair_price"] = fairPrice.Int32
 	}
+	lifecycle.addTo(opp)
 
 	// Get legs
 	legs, _ := h.getOpportunityLegs(ctx, id)
@@ -221,6 +243,34 @@ func (h *OpportunityHandler) GetOpportunity(w http.ResponseWriter, r *http.Reque
 	respondJSON(w, http.StatusOK, opp)
 }
 
+// opportunityLifecycle holds an opportunity's lifecycle columns
+type opportunityLifecycle struct {
+	firstSeenAt time.Time
+	lastSeenAt  time.Time
+	peakEdgePct sql.NullFloat64
+	closedAt    sql.NullTime
+	closeReason sql.NullString
+}
+
+// addTo adds lifecycle fields, status (open/closed) and how long it has been open
+func (l opportunityLifecycle) addTo(opp map[string]interface{}) {
+	opp["first_seen_at"] = l.firstSeenAt
+	opp["last_seen_at"] = l.lastSeenAt
+	if l.peakEdgePct.Valid {
+		opp["peak_edge_pct"] = l.peakEdgePct.Float64
+	}
+
+	end := time.Now()
+	opp["status"] = "open"
+	if l.closedAt.Valid {
+		end = l.closedAt.Time
+		opp["status"] = "closed"
+		opp["closed_at"] = l.closedAt.Time
+		opp["close_reason"] = l.closeReason.String
+	}
+	opp["duration_seconds"] = int(end.Sub(l.firstSeenAt).Seconds())
+}
+
 // CreateOpportunityAction creates an action on an opportunity
 func (h *OpportunityHandler) CreateOpportunityAction(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
@@ -331,7 +381,7 @@ func (h *OpportunityHandler) getEventNames(ctx context.Context, eventIDs []strin
 // getOpportunityLegs retrieves legs for an opportunity
 func (h *OpportunityHandler) getOpportunityLegs(ctx context.Context, opportunityID int64) ([]map[string]interface{}, error) {
 	query := `
-		SELECT book_key, outcome_name, price, point, leg_edge_pct
+		SELECT book_key, outcome_name, price, point, leg_edge_pct, player_name, stat
 		FROM opportunity_legs
 		WHERE opportunity_id = $1
 		ORDER BY id
@@ -349,8 +399,9 @@ func (h *OpportunityHandler) getOpportunityLegs(ctx context.Context, opportunity
 		var price int
 		var point sql.NullFloat64
 		var legEdge sql.NullFloat64
+		var playerName, stat sql.NullString
 
-		if err := rows.Scan(&bookKey, &outcomeName, &price, &point, &legEdge); err != nil {
+		if err := rows.Scan(&bookKey, &outcomeName, &price, &point, &legEdge, &playerName, &stat); err != nil {
 			continue
 		}
 
@@ -366,6 +417,10 @@ func (h *OpportunityHandler) getOpportunityLegs(ctx context.Context, opportunity
 		if legEdge.Valid {
 			leg["leg_edge_pct"] = legEdge.Float64
 		}
+		if playerName.Valid {
+			leg["player_name"] = playerName.String
+			leg["stat"] = stat.String
+		}
 
 		legs = append(legs, leg)
 	}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// DetectorThresholds are the edge detector settings that can change live
+// Nil fields use the edge detector's environment default.
+type DetectorThresholds struct {
+	MinEdgePct             *float64 `json:"min_edge_pct"` // 0.01 = 1%, as MIN_EDGE_PCT
+	MaxDataAgeSeconds      *int     `json:"max_data_age_seconds"`
+	EnabledMarkets         []string `json:"enabled_markets"`
+	StaleLineMinMoveCents  *int     `json:"stale_line_min_move_cents"`
+	StaleLineWindowSeconds *int     `json:"stale_line_window_seconds"`
+	EnableMiddles          *bool    `json:"enable_middles"`
+	EnableScalps           *bool    `json:"enable_scalps"`
+	EnableStaleLines       *bool    `json:"enable_stale_lines"`
+	EnablePlayerProps      *bool    `json:"enable_player_props"`
+}
+
+// DetectorConfig is a sport's row in Holocron detector_config
+type DetectorConfig struct {
+	SportKey string `json:"sport_key"`
+	DetectorThresholds
+	Version   int64     `json:"version"`
+	UpdatedBy string    `json:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DetectorConfigUpdate replaces a sport's thresholds (omitted fields go back to the environment default)
+type DetectorConfigUpdate struct {
+	DetectorThresholds
+	UpdatedBy string `json:"updated_by"`
+	Reason    string `json:"reason"`
+	Version   *int64 `json:"version"` // Optional; the update fails if the row has moved past it
+}
+
+// DetectorConfigChange is one field's old and new value in an audit entry
+type DetectorConfigChange struct {
+	Old json.RawMessage `json:"old"`
+	New json.RawMessage `json:"new"`
+}
+
+// DetectorConfigAudit is one detector_config change
+type DetectorConfigAudit struct {
+	ID        int64                           `json:"id"`
+	SportKey  string                          `json:"sport_key"`
+	Version   int64                           `json:"version"`
+	ChangedBy string                          `json:"changed_by"`
+	Reason    *string                         `json:"reason,omitempty"`
+	Previous  *DetectorConfig                 `json:"previous"` // nil when the change created the row
+	Config    DetectorConfig                  `json:"config"`
+	Changes   map[string]DetectorConfigChange `json:"changes"`
+	ChangedAt time.Time                       `json:"changed_at"`
+}
+
+// DetectorConfigNotice is published on detector.config.updated after a change
+type DetectorConfigNotice struct {
+	SportKey string `json:"sport_key"`
+	Version  int64  `json:"version"`
+}
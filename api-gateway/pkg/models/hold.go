@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// BookHold is a book's rolling hold for one sport and market type (published by the normalizer)
+type BookHold struct {
+	SportKey        string    `json:"sport_key"`
+	MarketType      string    `json:"market_type"` // two_way, three_way, props, double_chance
+	BookKey         string    `json:"book_key"`
+	IsSharp         bool      `json:"is_sharp"`
+	Markets         int       `json:"markets"`
+	AvgHoldPct      float64   `json:"avg_hold_pct"`
+	MinHoldPct      float64   `json:"min_hold_pct"`
+	MaxHoldPct      float64   `json:"max_hold_pct"`
+	AvgOverroundPct float64   `json:"avg_overround_pct"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
@@ -22,6 +22,7 @@ type CurrentOdds struct {
 	BookKey          string     `json:"book_key"`
 	OutcomeName      string     `json:"outcome_name"`
 	Price            int        `json:"price"`              // American odds
+	PriceDisplay     string     `json:"price_display,omitempty"` // Price in the requested odds_format
 	Point            *float64   `json:"point,omitempty"`    // For spreads/totals
 	VendorLastUpdate time.Time  `json:"vendor_last_update"`
 	ReceivedAt       time.Time  `json:"received_at"`
@@ -37,6 +38,7 @@ type OddsHistory struct {
 	BookKey          string     `json:"book_key"`
 	OutcomeName      string     `json:"outcome_name"`
 	Price            int        `json:"price"`
+	PriceDisplay     string     `json:"price_display,omitempty"` // Price in the requested odds_format
 	Point            *float64   `json:"point,omitempty"`
 	VendorLastUpdate time.Time  `json:"vendor_last_update"`
 	ReceivedAt       time.Time  `json:"received_at"`
@@ -0,0 +1,243 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/api-gateway/internal/db"
+	"github.com/XavierBriggs/fortuna/services/api-gateway/internal/handlers"
+	"github.com/XavierBriggs/fortuna/services/api-gateway/pkg/models"
+	"github.com/go-chi/chi/v5"
+	"github.com/redis/go-redis/v9"
+)
+
+// MockDetectorConfigStore implements db.DetectorConfigStore for testing
+type MockDetectorConfigStore struct {
+	configs     map[string]*models.DetectorConfig
+	history     []*models.DetectorConfigAudit
+	updates     []*models.DetectorConfigUpdate
+	updateError error
+}
+
+func (m *MockDetectorConfigStore) GetDetectorConfigs(ctx context.Context) ([]*models.DetectorConfig, error) {
+	configs := make([]*models.DetectorConfig, 0, len(m.configs))
+	for _, config := range m.configs {
+		configs = append(configs, config)
+	}
+	return configs, nil
+}
+
+func (m *MockDetectorConfigStore) GetDetectorConfig(ctx context.Context, sportKey string) (*models.DetectorConfig, error) {
+	return m.configs[sportKey], nil
+}
+
+func (m *MockDetectorConfigStore) UpdateDetectorConfig(ctx context.Context, sportKey string, update *models.DetectorConfigUpdate) (*models.DetectorConfig, error) {
+	m.updates = append(m.updates, update)
+	if m.updateError != nil {
+		return nil, m.updateError
+	}
+
+	version := int64(1)
+	if current, ok := m.configs[sportKey]; ok {
+		version = current.Version + 1
+	}
+	config := &models.DetectorConfig{
+		SportKey:           sportKey,
+		DetectorThresholds: update.DetectorThresholds,
+		Version:            version,
+		UpdatedBy:          update.UpdatedBy,
+		UpdatedAt:          time.Now(),
+	}
+	if m.configs == nil {
+		m.configs = make(map[string]*models.DetectorConfig)
+	}
+	m.configs[sportKey] = config
+	return config, nil
+}
+
+func (m *MockDetectorConfigStore) GetDetectorConfigHistory(ctx context.Context, sportKey string, limit int) ([]*models.DetectorConfigAudit, error) {
+	return m.history, nil
+}
+
+// MockPublisher records published messages
+type MockPublisher struct {
+	channels []string
+	messages []string
+	err      error
+}
+
+func (m *MockPublisher) Publish(ctx context.Context, channel string, message interface{}) *redis.IntCmd {
+	m.channels = append(m.channels, channel)
+	if payload, ok := message.([]byte); ok {
+		m.messages = append(m.messages, string(payload))
+	}
+	return redis.NewIntResult(1, m.err)
+}
+
+func detectorConfigRouter(handler *handlers.DetectorConfigHandler) *chi.Mux {
+	r := chi.NewRouter()
+	r.Get("/detector-config/{sport}", handler.GetDetectorConfig)
+	r.Put("/detector-config/{sport}", handler.UpdateDetectorConfig)
+	r.Get("/detector-config/{sport}/history", handler.GetDetectorConfigHistory)
+	return r
+}
+
+func TestUpdateDetectorConfig_Success(t *testing.T) {
+	store := &MockDetectorConfigStore{}
+	publisher := &MockPublisher{}
+	r := detectorConfigRouter(handlers.NewDetectorConfigHandler(store, publisher))
+
+	body := `{"min_edge_pct": 0.02, "enabled_markets": ["h2h", "totals"], "reason": "tighten"}`
+	req := httptest.NewRequest("PUT", "/detector-config/basketball_nba", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if len(store.updates) != 1 {
+		t.Fatalf("expected 1 update, got %d", len(store.updates))
+	}
+	update := store.updates[0]
+	if update.MinEdgePct == nil || *update.MinEdgePct != 0.02 {
+		t.Errorf("expected min_edge_pct 0.02, got %v", update.MinEdgePct)
+	}
+	if update.MaxDataAgeSeconds != nil {
+		t.Errorf("expected omitted max_data_age_seconds to stay nil, got %v", *update.MaxDataAgeSeconds)
+	}
+	if update.UpdatedBy != "api" {
+		t.Errorf("expected default updated_by 'api', got %s", update.UpdatedBy)
+	}
+
+	if len(publisher.channels) != 1 || publisher.channels[0] != "detector.config.updated" {
+		t.Fatalf("expected one notice on detector.config.updated, got %v", publisher.channels)
+	}
+	var notice models.DetectorConfigNotice
+	if err := json.Unmarshal([]byte(publisher.messages[0]), &notice); err != nil {
+		t.Fatalf("failed to decode notice: %v", err)
+	}
+	if notice.SportKey != "basketball_nba" || notice.Version != 1 {
+		t.Errorf("expected notice basketball_nba v1, got %s v%d", notice.SportKey, notice.Version)
+	}
+
+	var response map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response["notified"] != true {
+		t.Errorf("expected notified true, got %v", response["notified"])
+	}
+}
+
+func TestUpdateDetectorConfig_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"min edge too high", `{"min_edge_pct": 2}`},
+		{"min edge zero", `{"min_edge_pct": 0}`},
+		{"max data age zero", `{"max_data_age_seconds": 0}`},
+		{"empty markets", `{"enabled_markets": []}`},
+		{"invalid market", `{"enabled_markets": ["h2h", "Spreads!"]}`},
+		{"duplicate market", `{"enabled_markets": ["h2h", "h2h"]}`},
+		{"stale move too large", `{"stale_line_min_move_cents": 1000}`},
+		{"stale window too short", `{"stale_line_window_seconds": 1}`},
+		{"malformed body", `{"min_edge_pct": "high"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &MockDetectorConfigStore{}
+			publisher := &MockPublisher{}
+			r := detectorConfigRouter(handlers.NewDetectorConfigHandler(store, publisher))
+
+			req := httptest.NewRequest("PUT", "/detector-config/basketball_nba", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", w.Code)
+			}
+			if len(store.updates) != 0 || len(publisher.channels) != 0 {
+				t.Errorf("expected no update or notice for invalid config")
+			}
+		})
+	}
+}
+
+func TestUpdateDetectorConfig_Conflict(t *testing.T) {
+	store := &MockDetectorConfigStore{updateError: db.ErrDetectorConfigConflict}
+	publisher := &MockPublisher{}
+	r := detectorConfigRouter(handlers.NewDetectorConfigHandler(store, publisher))
+
+	req := httptest.NewRequest("PUT", "/detector-config/basketball_nba", strings.NewReader(`{"min_edge_pct": 0.02, "version": 3}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected status 409, got %d", w.Code)
+	}
+	if len(publisher.channels) != 0 {
+		t.Errorf("expected no notice after a conflict")
+	}
+}
+
+func TestUpdateDetectorConfig_PublishFailure(t *testing.T) {
+	store := &MockDetectorConfigStore{}
+	publisher := &MockPublisher{err: errors.New("redis down")}
+	r := detectorConfigRouter(handlers.NewDetectorConfigHandler(store, publisher))
+
+	req := httptest.NewRequest("PUT", "/detector-config/basketball_nba", strings.NewReader(`{"max_data_age_seconds": 5}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	// The change is stored; the edge detector picks it up on its next refresh
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	var response map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response["notified"] != false {
+		t.Errorf("expected notified false, got %v", response["notified"])
+	}
+}
+
+func TestGetDetectorConfig_NotFound(t *testing.T) {
+	r := detectorConfigRouter(handlers.NewDetectorConfigHandler(&MockDetectorConfigStore{}, &MockPublisher{}))
+
+	req := httptest.NewRequest("GET", "/detector-config/basketball_nba", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", w.Code)
+	}
+}
+
+func TestGetDetectorConfigHistory_InvalidLimit(t *testing.T) {
+	r := detectorConfigRouter(handlers.NewDetectorConfigHandler(&MockDetectorConfigStore{}, &MockPublisher{}))
+
+	req := httptest.NewRequest("GET", "/detector-config/basketball_nba/history?limit=0", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
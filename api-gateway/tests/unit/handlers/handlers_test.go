@@ -70,6 +70,13 @@ func (m *MockDB) GetEventWithOdds(ctx context.Context, eventID string) (*models.
 	}, nil
 }
 
+func (m *MockDB) GetBooks(ctx context.Context) ([]models.Book, error) {
+	if m.shouldError {
+		return nil, context.DeadlineExceeded
+	}
+	return nil, nil
+}
+
 func (m *MockDB) Close() error {
 	return nil
 }
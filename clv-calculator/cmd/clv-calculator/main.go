@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -66,11 +67,12 @@ func main() {
 	calc := calculator.NewCLVCalculator(alexandriaDB, holocronDB)
 
 	// Initialize stream consumer
-	streamConsumer := consumer.NewConsumer(
+	streamConsumer := consumer.NewConsumerWithRecovery(
 		redisClient,
 		config.StreamName,
 		config.ConsumerGroup,
 		calc,
+		config.Recovery,
 	)
 
 	// Start consumer
@@ -86,6 +88,27 @@ func main() {
 		}
 	}()
 
+	// Start metrics reporter
+	go func() {
+		ticker := time.NewTicker(30 * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				pending, err := streamConsumer.PendingStats(ctx)
+				if err != nil {
+					fmt.Printf("⚠️  Failed to read pending stats: %v\n", err)
+					continue
+				}
+				fmt.Printf("📊 Stream %s: pending=%d lag=%d claimed=%d dead_lettered=%d\n",
+					pending.Stream, pending.Pending, pending.Lag, pending.Claimed, pending.DeadLettered)
+			}
+		}
+	}()
+
 	// Wait for interrupt
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
@@ -104,6 +127,7 @@ type Config struct {
 	RedisPassword  string
 	StreamName     string
 	ConsumerGroup  string
+	Recovery       consumer.RecoveryConfig
 }
 
 func loadConfig() Config {
@@ -114,6 +138,17 @@ func loadConfig() Config {
 		RedisPassword: os.Getenv("REDIS_PASSWORD"),
 		StreamName:    getEnv("CLV_STREAM", "closing_lines.captured"),
 		ConsumerGroup: getEnv("CLV_CONSUMER_GROUP", "clv-calculator-group"),
+		Recovery:      loadRecoveryConfig(),
+	}
+}
+
+func loadRecoveryConfig() consumer.RecoveryConfig {
+	defaults := consumer.DefaultRecoveryConfig()
+	return consumer.RecoveryConfig{
+		ClaimInterval: getEnvDuration("STREAM_CLAIM_INTERVAL", defaults.ClaimInterval),
+		MinIdle:       getEnvDuration("STREAM_CLAIM_MIN_IDLE", defaults.MinIdle),
+		MaxDeliveries: int64(getEnvInt("STREAM_MAX_DELIVERIES", int(defaults.MaxDeliveries))),
+		BatchSize:     defaults.BatchSize,
 	}
 }
 
@@ -124,6 +159,24 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+func getEnvInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
 
 
 
@@ -3,6 +3,7 @@ module github.com/XavierBriggs/fortuna/services/clv-calculator
 go 1.23
 
 require (
+	github.com/XavierBriggs/fortuna/services/shared v0.0.0
 	github.com/lib/pq v1.10.9
 	github.com/redis/go-redis/v9 v9.3.0
 )
@@ -12,5 +13,4 @@ require (
 	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
 )
 
-
-
+replace github.com/XavierBriggs/fortuna/services/shared => ../shared
@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"fmt"
 	"time"
+
+	"github.com/XavierBriggs/fortuna/services/shared/odds"
 )
 
 // CLVCalculator calculates CLV for bets
@@ -200,8 +202,8 @@ func findMatchingLine(lines []ClosingLine, bet Bet) *ClosingLine {
 // calculateCLV calculates CLV in cents per dollar
 // CLV = (1/close_decimal - 1/bet_decimal) * 100
 func calculateCLV(betPrice, closingPrice int) float64 {
-	betDecimal := americanToDecimal(betPrice)
-	closeDecimal := americanToDecimal(closingPrice)
+	betDecimal := odds.AmericanToDecimal(betPrice)
+	closeDecimal := odds.AmericanToDecimal(closingPrice)
 
 	betProb := 1.0 / betDecimal
 	closeProb := 1.0 / closeDecimal
@@ -209,14 +211,3 @@ func calculateCLV(betPrice, closingPrice int) float64 {
 	clvCents := (closeProb - betProb) * 100.0
 	return clvCents
 }
-
-func americanToDecimal(american int) float64 {
-	if american > 0 {
-		return (float64(american) / 100.0) + 1.0
-	}
-	return (100.0 / float64(-american)) + 1.0
-}
-
-
-
-
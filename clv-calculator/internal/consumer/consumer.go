@@ -5,9 +5,13 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/XavierBriggs/fortuna/services/shared/streams"
 	"github.com/redis/go-redis/v9"
 )
 
+// consumerName is this service's consumer within the group
+const consumerName = "clv-calculator-1"
+
 // EventProcessor processes closing line events
 type EventProcessor interface {
 	ProcessEvent(ctx context.Context, eventID string) error
@@ -19,15 +23,22 @@ type Consumer struct {
 	streamName    string
 	consumerGroup string
 	processor     EventProcessor
+	recovery      *streams.PendingRecovery
 }
 
-// NewConsumer creates a new stream consumer
+// NewConsumer creates a new stream consumer with default pending recovery
 func NewConsumer(redisClient *redis.Client, streamName, consumerGroup string, processor EventProcessor) *Consumer {
+	return NewConsumerWithRecovery(redisClient, streamName, consumerGroup, processor, DefaultRecoveryConfig())
+}
+
+// NewConsumerWithRecovery creates a stream consumer with custom pending recovery
+func NewConsumerWithRecovery(redisClient *redis.Client, streamName, consumerGroup string, processor EventProcessor, recovery RecoveryConfig) *Consumer {
 	return &Consumer{
 		redisClient:   redisClient,
 		streamName:    streamName,
 		consumerGroup: consumerGroup,
 		processor:     processor,
+		recovery:      streams.NewPendingRecovery(redisClient, consumerGroup, consumerName, recovery, nil),
 	}
 }
 
@@ -56,10 +67,19 @@ func (c *Consumer) Start(ctx context.Context) error {
 }
 
 func (c *Consumer) consumeBatch(ctx context.Context) error {
+	// Retry events left pending by a crash or a failed calculation
+	if c.recovery.Due(c.streamName) {
+		claimed, err := c.recovery.Claim(ctx, c.streamName)
+		if err != nil {
+			fmt.Printf("⚠️  Claim pending error: %v\n", err)
+		}
+		c.processMessages(ctx, claimed)
+	}
+
 	// Read from stream
 	streams, err := c.redisClient.XReadGroup(ctx, &redis.XReadGroupArgs{
 		Group:    c.consumerGroup,
-		Consumer: "clv-calculator-1",
+		Consumer: consumerName,
 		Streams:  []string{c.streamName, ">"},
 		Count:    10,
 		Block:    5 * time.Second,
@@ -76,18 +96,29 @@ func (c *Consumer) consumeBatch(ctx context.Context) error {
 
 	// Process messages
 	for _, stream := range streams {
-		for _, message := range stream.Messages {
-			if err := c.processMessage(ctx, message); err != nil {
-				fmt.Printf("⚠️  Process message error: %v\n", err)
-				continue
-			}
+		c.processMessages(ctx, stream.Messages)
+	}
+
+	return nil
+}
 
-			// ACK message
-			c.redisClient.XAck(ctx, c.streamName, c.consumerGroup, message.ID)
+// processMessages processes and acks messages
+// Failed messages stay pending and are retried until MaxDeliveries
+func (c *Consumer) processMessages(ctx context.Context, messages []redis.XMessage) {
+	for _, message := range messages {
+		if err := c.processMessage(ctx, message); err != nil {
+			fmt.Printf("⚠️  Process message error: %v\n", err)
+			continue
 		}
+
+		// ACK message
+		c.redisClient.XAck(ctx, c.streamName, c.consumerGroup, message.ID)
 	}
+}
 
-	return nil
+// PendingStats returns the backlog and recovery counts for the stream
+func (c *Consumer) PendingStats(ctx context.Context) (PendingStats, error) {
+	return c.recovery.Stats(ctx, c.streamName)
 }
 
 func (c *Consumer) processMessage(ctx context.Context, message redis.XMessage) error {
@@ -0,0 +1,14 @@
+package consumer
+
+import "github.com/XavierBriggs/fortuna/services/shared/streams"
+
+// RecoveryConfig controls how idle pending entries are reclaimed
+type RecoveryConfig = streams.RecoveryConfig
+
+// PendingStats describes a stream's backlog for the consumer group
+type PendingStats = streams.PendingStats
+
+// DefaultRecoveryConfig returns the default recovery settings
+func DefaultRecoveryConfig() RecoveryConfig {
+	return streams.DefaultRecoveryConfig()
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/backtest"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/books"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/detector"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/lifecycle"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/marketstate"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/contracts"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/sports/basketball_nba"
+	_ "github.com/lib/pq"
+)
+
+// runBacktest replays normalized odds history through the detectors on a replay clock
+// and grades what they found against final scores and closing lines
+//
+// Usage:
+//
+//	normalizer replay -sport basketball_nba -since ... -until ... -out nba.jsonl
+//	edge-detector backtest -in nba.jsonl -scores scores.json [-out results.jsonl]
+//	MAX_DATA_AGE_SECONDS=5 edge-detector backtest -in nba.jsonl -latency 2s -thresholds 0.01,0.02,0.04
+//
+// The detectors run with the environment's sport config, at the lowest threshold
+// (Holocron detector_config is not applied, so candidates are tried through the environment);
+// the report shows hit rate, average CLV and ROI per detector at each threshold.
+// Holocron and the live streams are never written.
+func runBacktest(args []string) int {
+	flags := flag.NewFlagSet("backtest", flag.ContinueOnError)
+	inPath := flags.String("in", "", "normalized odds JSON lines from normalizer replay -out (- for stdin)")
+	outPath := flags.String("out", "", "write lifecycle events as JSON lines to this file")
+	sportKey := flags.String("sport", "basketball_nba", "sport to backtest")
+	thresholdList := flags.String("thresholds", "0.01,0.02,0.03,0.05", "minimum edges to report, comma separated (MIN_EDGE_PCT scale)")
+	latency := flags.Duration("latency", 0, "delay from received_at to detection, counted in data age")
+	scoresPath := flags.String("scores", "", "saved Odds API scores response(s) used to settle opportunities")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	fmt.Println("=== Fortuna Edge Detector Backtest ===")
+
+	if *inPath == "" {
+		fmt.Println("❌ -in is required")
+		return 2
+	}
+	if *sportKey != "basketball_nba" {
+		fmt.Printf("❌ no detector config for sport: %s\n", *sportKey)
+		return 2
+	}
+	thresholds, err := parseThresholds(*thresholdList)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return 2
+	}
+
+	config := loadConfig()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	// Alexandria has the books behind sharp detection and the closing lines
+	alexandriaDB, err := sql.Open("postgres", config.AlexandriaDSN)
+	if err != nil {
+		fmt.Printf("❌ Failed to connect to Alexandria: %v\n", err)
+		return 1
+	}
+	defer alexandriaDB.Close()
+
+	if err := alexandriaDB.PingContext(ctx); err != nil {
+		fmt.Printf("❌ Failed to ping Alexandria: %v\n", err)
+		return 1
+	}
+	fmt.Println("✓ Connected to Alexandria")
+
+	// Book profiles and sharpness are read-only; without Holocron books are priced as
+	// posted and the configured sharp books are used everywhere
+	var profiles contracts.BookProfileProvider
+	var bookSharpness contracts.SharpnessProvider
+	holocronDB, err := sql.Open("postgres", config.HolocronDSN)
+	if err == nil {
+		defer holocronDB.Close()
+		profileStore := books.NewProfileStore(holocronDB, config.BookProfileRefresh)
+		if err = profileStore.Load(ctx); err == nil {
+			profiles = profileStore
+			fmt.Printf("✓ Book profiles loaded: %d books\n", profileStore.Count())
+
+			sharpnessStore := books.NewSharpnessStore(holocronDB, config.BookSharpnessRefresh)
+			if sharpnessErr := sharpnessStore.Load(ctx); sharpnessErr != nil {
+				fmt.Printf("⚠️  Failed to load book sharpness, using configured sharp books: %v\n", sharpnessErr)
+			} else {
+				bookSharpness = sharpnessStore
+				fmt.Printf("✓ Book sharpness loaded: %d scored markets\n", sharpnessStore.Count())
+			}
+		}
+	}
+	if err != nil {
+		fmt.Printf("⚠️  Failed to load book profiles, pricing books as posted: %v\n", err)
+	}
+
+	// Detect at the lowest threshold so every reported threshold can be graded
+	nbaConfig := basketball_nba.NewConfig()
+	nbaConfig.MinEdgePct = thresholds[0]
+	fmt.Printf("✓ NBA Config loaded: min_edge=%.1f%%, max_age=%ds, latency=%s\n",
+		nbaConfig.MinEdgePct*100, nbaConfig.MaxDataAgeSeconds, *latency)
+
+	var in io.Reader = os.Stdin
+	if *inPath != "-" {
+		file, err := os.Open(*inPath)
+		if err != nil {
+			fmt.Printf("❌ Failed to open %s: %v\n", *inPath, err)
+			return 1
+		}
+		defer file.Close()
+		in = file
+	}
+
+	var out io.Writer
+	if *outPath != "" {
+		file, err := os.Create(*outPath)
+		if err != nil {
+			fmt.Printf("❌ Failed to create %s: %v\n", *outPath, err)
+			return 1
+		}
+		defer file.Close()
+		out = file
+		fmt.Printf("✓ Writing to %s\n", *outPath)
+	}
+
+	// Fresh market state, detectors and lifecycle on the replay clock
+	clock := backtest.NewClock()
+	marketStore := marketstate.NewStoreWithClock(config.MarketState, clock.Now)
+	detectors := detector.NewDefaultRegistryWithClock(marketStore, profiles, clock.Now)
+	sharpBookProvider := basketball_nba.NewSharpBookProviderWithSharpness(alexandriaDB, nbaConfig.GetSharpBooks(), bookSharpness)
+	detectorTypes := detectors.AddSport(*sportKey, nbaConfig, sharpBookProvider)
+
+	journal := backtest.NewJournal(out)
+	tracker := lifecycle.NewTrackerWithClock(config.Lifecycle, backtest.NewMemoryStore(), journal, nil, clock.Now)
+	engine := detector.NewReplayEngine(tracker, detectors, marketStore)
+
+	backtester := backtest.NewBacktester(backtest.Config{
+		Latency:       *latency,
+		SweepInterval: config.Lifecycle.SweepInterval,
+	}, engine, tracker, marketStore, clock)
+
+	startTime := time.Now()
+	result, err := backtester.Run(ctx, in)
+
+	if flushErr := journal.Flush(); flushErr != nil {
+		fmt.Printf("❌ Failed to flush output: %v\n", flushErr)
+		return 1
+	}
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return 1
+	}
+
+	lifecycleStats := tracker.Stats()
+	fmt.Printf("📊 Replay complete: rows=%d events=%d detected=%d updated=%d closed=%d range=%s → %s elapsed=%s\n",
+		result.Rows, result.Changes, lifecycleStats.Detected, lifecycleStats.Updated,
+		lifecycleStats.Gone+lifecycleStats.Expired,
+		result.From.Format(time.RFC3339), result.To.Format(time.RFC3339),
+		time.Since(startTime).Round(time.Millisecond))
+
+	detectorStats := engine.GetDetectorStats()
+	for _, opportunityType := range detectorTypes {
+		stats := detectorStats[opportunityType]
+		fmt.Printf("📊 Detector %s: runs=%d opportunities=%d errors=%d timeouts=%d\n",
+			opportunityType, stats.Runs, stats.Opportunities, stats.Errors, stats.Timeouts)
+	}
+
+	// Grade against final scores and closing lines
+	entries := journal.Entries()
+	eventIDs := entryEventIDs(entries)
+
+	scores, err := loadBacktestScores(ctx, *scoresPath, *sportKey, eventIDs)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return 1
+	}
+	closing, err := backtest.LoadClosingLines(ctx, alexandriaDB, eventIDs)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return 1
+	}
+	fmt.Printf("✓ Outcomes loaded: events=%d scores=%d closing_lines=%d\n", len(eventIDs), len(scores), len(closing))
+
+	rows := backtest.NewGrader(scores, closing, profiles).Report(entries, thresholds)
+
+	fmt.Printf("\n%-12s %8s %6s %8s %6s %6s %6s %8s %9s %8s %8s\n",
+		"DETECTOR", "MIN_EDGE", "OPPS", "SETTLED", "WINS", "LOSS", "PUSH", "HIT", "AVG_CLV", "PROFIT", "ROI")
+	for _, row := range rows {
+		fmt.Printf("%-12s %7.1f%% %6d %8d %6d %6d %6d %7.1f%% %8.2f¢ %8.2f %7.1f%%\n",
+			row.OpportunityType, row.MinEdgePct*100, row.Opportunities, row.Settled,
+			row.Wins, row.Losses, row.Pushes, row.HitRate*100, row.AvgCLVCents, row.Profit, row.ROI*100)
+	}
+
+	return 0
+}
+
+// loadBacktestScores reads final scores from -scores and fetches the rest from
+// The Odds API when ODDS_API_KEY is set (the API only covers the last 3 days)
+func loadBacktestScores(ctx context.Context, path, sportKey string, eventIDs []string) (map[string]backtest.EventScore, error) {
+	scores := make(map[string]backtest.EventScore)
+	if path != "" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer file.Close()
+
+		if scores, err = backtest.ReadScores(file); err != nil {
+			return nil, err
+		}
+	}
+
+	apiKey := os.Getenv("ODDS_API_KEY")
+	if apiKey == "" {
+		return scores, nil
+	}
+
+	var missing []string
+	for _, eventID := range eventIDs {
+		if score, ok := scores[eventID]; !ok || !score.Completed {
+			missing = append(missing, eventID)
+		}
+	}
+	if len(missing) == 0 {
+		return scores, nil
+	}
+
+	fetched, err := backtest.FetchScores(ctx, &http.Client{Timeout: 30 * time.Second}, apiKey, sportKey, missing)
+	if err != nil {
+		fmt.Printf("⚠️  Failed to fetch scores from The Odds API: %v\n", err)
+		return scores, nil
+	}
+	for eventID, score := range fetched {
+		scores[eventID] = score
+	}
+	return scores, nil
+}
+
+// parseThresholds parses a comma-separated list of minimum edges, sorted ascending
+func parseThresholds(value string) ([]float64, error) {
+	var thresholds []float64
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		threshold, err := strconv.ParseFloat(part, 64)
+		if err != nil || threshold < 0 {
+			return nil, fmt.Errorf("invalid -thresholds value %q", part)
+		}
+		thresholds = append(thresholds, threshold)
+	}
+	if len(thresholds) == 0 {
+		return nil, fmt.Errorf("-thresholds needs at least one value")
+	}
+	sort.Float64s(thresholds)
+	return thresholds, nil
+}
+
+// entryEventIDs returns the events the backtest found opportunities in
+func entryEventIDs(entries []backtest.Entry) []string {
+	seen := make(map[string]bool)
+	var eventIDs []string
+	for _, entry := range entries {
+		if !seen[entry.Opportunity.EventID] {
+			seen[entry.Opportunity.EventID] = true
+			eventIDs = append(eventIDs, entry.Opportunity.EventID)
+		}
+	}
+	sort.Strings(eventIDs)
+	return eventIDs
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/sharpness"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/writer"
+	_ "github.com/lib/pq"
+)
+
+// runSharpness scores every book's pre-close prices against the closing consensus per
+// market and replaces the sport's Holocron book_sharpness rows
+//
+// Usage:
+//
+//	edge-detector sharpness -sport basketball_nba -days 30
+//	edge-detector sharpness -until 2026-10-01T00:00:00Z -days 60 -dry-run
+//
+// Meant to run once a day (cron); the detectors reload the scores every BOOK_SHARPNESS_REFRESH.
+func runSharpness(args []string) int {
+	flags := flag.NewFlagSet("sharpness", flag.ContinueOnError)
+	sportKey := flags.String("sport", "basketball_nba", "sport to score")
+	days := flags.Int("days", 30, "score events that started in this many days before -until")
+	untilValue := flags.String("until", "", "end of the scoring window, RFC3339 (default now)")
+	dryRun := flags.Bool("dry-run", false, "print scores without writing them to Holocron")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	fmt.Println("=== Fortuna Book Sharpness ===")
+
+	until := time.Now().UTC()
+	if *untilValue != "" {
+		parsed, err := time.Parse(time.RFC3339, *untilValue)
+		if err != nil {
+			fmt.Printf("❌ invalid -until value %q: %v\n", *untilValue, err)
+			return 2
+		}
+		until = parsed
+	}
+	if *days <= 0 {
+		fmt.Println("❌ -days must be positive")
+		return 2
+	}
+	since := until.AddDate(0, 0, -*days)
+
+	config := loadConfig()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	alexandriaDB, err := sql.Open("postgres", config.AlexandriaDSN)
+	if err != nil {
+		fmt.Printf("❌ Failed to connect to Alexandria: %v\n", err)
+		return 1
+	}
+	defer alexandriaDB.Close()
+
+	if err := alexandriaDB.PingContext(ctx); err != nil {
+		fmt.Printf("❌ Failed to ping Alexandria: %v\n", err)
+		return 1
+	}
+	fmt.Println("✓ Connected to Alexandria")
+
+	var holocronWriter *writer.HolocronWriter
+	if !*dryRun {
+		holocronDB, err := sql.Open("postgres", config.HolocronDSN)
+		if err != nil {
+			fmt.Printf("❌ Failed to connect to Holocron: %v\n", err)
+			return 1
+		}
+		defer holocronDB.Close()
+
+		if err := holocronDB.PingContext(ctx); err != nil {
+			fmt.Printf("❌ Failed to ping Holocron: %v\n", err)
+			return 1
+		}
+		fmt.Println("✓ Connected to Holocron")
+		holocronWriter = writer.NewHolocronWriter(holocronDB)
+	}
+
+	fmt.Printf("✓ Scoring %s events from %s to %s (min_samples=%d, min_consensus_books=%d, sharp_score=%.2f)\n",
+		*sportKey, since.Format(time.RFC3339), until.Format(time.RFC3339),
+		config.Sharpness.MinSamples, config.Sharpness.MinConsensusBooks, config.Sharpness.SharpScore)
+
+	startTime := time.Now()
+
+	closing, err := sharpness.LoadClosingLines(ctx, alexandriaDB, *sportKey, since, until)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return 1
+	}
+	scorer := sharpness.NewScorer(config.Sharpness, closing)
+	fmt.Printf("✓ Closing consensus built: closing_lines=%d markets=%d\n", len(closing), scorer.Markets())
+
+	rows, err := sharpness.StreamPrices(ctx, alexandriaDB, *sportKey, since, until, scorer.Add)
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return 1
+	}
+
+	scores := scorer.Scores(*sportKey, time.Now().UTC())
+	sharpCount := 0
+	for _, score := range scores {
+		if score.IsSharp {
+			sharpCount++
+		}
+	}
+	fmt.Printf("📊 Scoring complete: prices=%d scores=%d sharp=%d elapsed=%s\n",
+		rows, len(scores), sharpCount, time.Since(startTime).Round(time.Millisecond))
+
+	fmt.Printf("\n%-20s %-20s %7s %8s %9s %9s %8s %7s %6s\n",
+		"MARKET", "BOOK", "EVENTS", "SAMPLES", "OPEN_ERR", "INTRA_ERR", "SCORE", "WEIGHT", "SHARP")
+	for _, score := range scores {
+		openingError := "-"
+		if score.OpeningError != nil {
+			openingError = fmt.Sprintf("%.4f", *score.OpeningError)
+		}
+		sharp := ""
+		if score.IsSharp {
+			sharp = "✓"
+		}
+		fmt.Printf("%-20s %-20s %7d %8d %9s %9.4f %8.3f %7.2f %6s\n",
+			score.MarketKey, score.BookKey, score.Events, score.Samples,
+			openingError, *score.IntradayError, score.Score, score.Weight, sharp)
+	}
+
+	if holocronWriter == nil {
+		fmt.Println("\nℹ️  Dry run, book_sharpness not written")
+		return 0
+	}
+	if len(scores) == 0 {
+		fmt.Println("\n⚠️  No prices could be scored, keeping the existing book_sharpness rows")
+		return 0
+	}
+	if err := holocronWriter.WriteBookSharpness(ctx, *sportKey, scores); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		return 1
+	}
+	fmt.Printf("\n✓ Wrote %d book_sharpness rows for %s\n", len(scores), *sportKey)
+
+	return 0
+}
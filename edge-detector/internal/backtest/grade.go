@@ -0,0 +1,319 @@
+package backtest
+
+import (
+	"sort"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/contracts"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/shared/odds"
+)
+
+// Leg and opportunity results
+const (
+	ResultWin  = "win"
+	ResultLoss = "loss"
+	ResultPush = "push"
+)
+
+// Row is one detector's backtest results at one minimum edge
+// Every opportunity is a 1 unit bet split across its legs by stake fraction (evenly
+// when the detector doesn't size legs), taken at the first detection that reached
+// the threshold.
+type Row struct {
+	OpportunityType models.OpportunityType `json:"opportunity_type"`
+	MinEdgePct      float64                `json:"min_edge_pct"` // Same scale as MIN_EDGE_PCT (0.02 = 2%)
+	Opportunities   int                    `json:"opportunities"`
+	Settled         int                    `json:"settled"` // Every leg graded from a final score
+	Wins            int                    `json:"wins"`
+	Losses          int                    `json:"losses"`
+	Pushes          int                    `json:"pushes"`
+	HitRate         float64                `json:"hit_rate"`      // Wins / (wins + losses)
+	CLVCount        int                    `json:"clv_count"`     // Opportunities with a closing line on some leg
+	AvgCLVCents     float64                `json:"avg_clv_cents"` // Per dollar, as bet_performance.clv_cents
+	Staked          float64                `json:"staked"`        // Units on settled opportunities
+	Profit          float64                `json:"profit"`
+	ROI             float64                `json:"roi"` // Profit / staked
+}
+
+// Grader settles backtest opportunities against final scores and closing lines
+type Grader struct {
+	scores   map[string]EventScore         // event ID -> score
+	closing  map[string][]ClosingLine      // event ID -> closing lines
+	profiles contracts.BookProfileProvider // nil pays every book as posted
+}
+
+// graded is one opportunity snapshot's result
+type graded struct {
+	settled bool
+	profit  float64
+	hasCLV  bool
+	clv     float64
+}
+
+// NewGrader creates a grader
+func NewGrader(scores map[string]EventScore, closing map[string][]ClosingLine, profiles contracts.BookProfileProvider) *Grader {
+	return &Grader{
+		scores:   scores,
+		closing:  closing,
+		profiles: profiles,
+	}
+}
+
+// Report grades the journal's opportunities per detector at each minimum edge
+// An opportunity counts at a threshold if any of its detections or updates reached
+// it; the legs are priced as they were at the first one that did.
+func (g *Grader) Report(entries []Entry, thresholds []float64) []Row {
+	// Snapshots per opportunity row, in the order they were recorded
+	var ids []int64
+	snapshots := make(map[int64][]models.Opportunity)
+	types := make(map[models.OpportunityType]bool)
+	for _, entry := range entries {
+		if entry.Event == models.EventOpportunityClosed {
+			continue
+		}
+		id := entry.Opportunity.ID
+		if _, seen := snapshots[id]; !seen {
+			ids = append(ids, id)
+		}
+		snapshots[id] = append(snapshots[id], entry.Opportunity)
+		types[entry.Opportunity.OpportunityType] = true
+	}
+
+	sortedThresholds := append([]float64(nil), thresholds...)
+	sort.Float64s(sortedThresholds)
+
+	rowIndex := make(map[models.OpportunityType][]*Row)
+	var rows []*Row
+	for _, opportunityType := range sortedOpportunityTypes(types) {
+		for _, threshold := range sortedThresholds {
+			row := &Row{OpportunityType: opportunityType, MinEdgePct: threshold}
+			rows = append(rows, row)
+			rowIndex[opportunityType] = append(rowIndex[opportunityType], row)
+		}
+	}
+
+	for _, id := range ids {
+		history := snapshots[id]
+		for i, threshold := range sortedThresholds {
+			snapshot, ok := firstReaching(history, threshold)
+			if !ok {
+				break // Thresholds are ascending
+			}
+			g.add(rowIndex[snapshot.OpportunityType][i], g.grade(snapshot))
+		}
+	}
+
+	result := make([]Row, 0, len(rows))
+	for _, row := range rows {
+		if row.Wins+row.Losses > 0 {
+			row.HitRate = float64(row.Wins) / float64(row.Wins+row.Losses)
+		}
+		if row.CLVCount > 0 {
+			row.AvgCLVCents /= float64(row.CLVCount)
+		}
+		if row.Staked > 0 {
+			row.ROI = row.Profit / row.Staked
+		}
+		result = append(result, *row)
+	}
+	return result
+}
+
+// add accumulates one graded opportunity into a row (averages are finished by Report)
+func (g *Grader) add(row *Row, result graded) {
+	row.Opportunities++
+	if result.hasCLV {
+		row.CLVCount++
+		row.AvgCLVCents += result.clv
+	}
+	if !result.settled {
+		return
+	}
+
+	row.Settled++
+	row.Staked++
+	row.Profit += result.profit
+	switch {
+	case result.profit > 1e-9:
+		row.Wins++
+	case result.profit < -1e-9:
+		row.Losses++
+	default:
+		row.Pushes++
+	}
+}
+
+// grade settles an opportunity snapshot and measures its CLV
+func (g *Grader) grade(opportunity models.Opportunity) graded {
+	result := graded{settled: len(opportunity.Legs) > 0}
+	returned := 0.0
+	clvStake := 0.0
+
+	score, hasScore := g.scores[opportunity.EventID]
+	for _, leg := range opportunity.Legs {
+		marketKey := leg.MarketKey
+		if marketKey == "" {
+			marketKey = opportunity.MarketKey
+		}
+		stake := legStake(opportunity, leg)
+
+		// closing_lines has no player, so props have no CLV
+		if leg.PlayerName == "" {
+			if line := findClosingLine(g.closing[opportunity.EventID], marketKey, leg); line != nil {
+				result.hasCLV = true
+				result.clv += stake * calculateCLV(legDecimal(leg), line.ClosingPrice)
+				clvStake += stake
+			}
+		}
+
+		if !hasScore || !score.Completed {
+			result.settled = false
+			continue
+		}
+		switch settleLeg(score, marketKey, leg) {
+		case ResultWin:
+			returned += stake * g.netDecimal(leg)
+		case ResultPush:
+			returned += stake
+		case ResultLoss:
+		default:
+			result.settled = false
+		}
+	}
+
+	if clvStake > 0 {
+		result.clv /= clvStake
+	}
+	result.profit = returned - 1
+	return result
+}
+
+// netDecimal is what one unit on a winning leg pays back after the book's costs
+func (g *Grader) netDecimal(leg models.OpportunityLeg) float64 {
+	decimal := legDecimal(leg)
+	if g.profiles == nil {
+		return decimal
+	}
+	return g.profiles.GetBookProfile(leg.BookKey).NetDecimal(decimal)
+}
+
+// legDecimal returns a leg's decimal odds, exact from its quoted price when it has one
+func legDecimal(leg models.OpportunityLeg) float64 {
+	if price, err := odds.Quoted(leg.Price, leg.PriceValue, odds.Format(leg.PriceFormat)); err == nil {
+		return price.Decimal()
+	}
+	return odds.AmericanToDecimal(leg.Price)
+}
+
+// firstReaching returns the first snapshot whose edge reached the threshold
+func firstReaching(history []models.Opportunity, threshold float64) (models.Opportunity, bool) {
+	for _, snapshot := range history {
+		if snapshot.EdgePercent >= threshold*100-1e-9 {
+			return snapshot, true
+		}
+	}
+	return models.Opportunity{}, false
+}
+
+// legStake is a leg's share of the opportunity's 1 unit stake
+func legStake(opportunity models.Opportunity, leg models.OpportunityLeg) float64 {
+	if leg.StakeFraction != nil {
+		return *leg.StakeFraction
+	}
+	return 1.0 / float64(len(opportunity.Legs))
+}
+
+// settleLeg grades a leg from the final score the way the settlement service grades bets
+// Returns "" for markets (and props) a team score can't settle.
+func settleLeg(score EventScore, marketKey string, leg models.OpportunityLeg) string {
+	if leg.PlayerName != "" {
+		return ""
+	}
+	home, away := score.teamScores()
+
+	switch marketKey {
+	case "h2h", "draw_no_bet":
+		if leg.OutcomeName != score.HomeTeam && leg.OutcomeName != score.AwayTeam && leg.OutcomeName != "Draw" {
+			return ""
+		}
+		switch {
+		case home == away && leg.OutcomeName == "Draw":
+			return ResultWin
+		case home == away:
+			return ResultPush
+		case leg.OutcomeName == "Draw":
+			return ResultLoss
+		case (home > away) == (leg.OutcomeName == score.HomeTeam):
+			return ResultWin
+		default:
+			return ResultLoss
+		}
+
+	case "spreads":
+		if leg.Point == nil {
+			return ""
+		}
+		var margin float64
+		switch leg.OutcomeName {
+		case score.HomeTeam:
+			margin = float64(home-away) + *leg.Point
+		case score.AwayTeam:
+			margin = float64(away-home) + *leg.Point
+		default:
+			return ""
+		}
+		return overZero(margin)
+
+	case "totals":
+		if leg.Point == nil {
+			return ""
+		}
+		total := float64(home + away)
+		switch leg.OutcomeName {
+		case "Over":
+			return overZero(total - *leg.Point)
+		case "Under":
+			return overZero(*leg.Point - total)
+		}
+	}
+	return ""
+}
+
+// overZero grades a leg by its margin over the line
+func overZero(margin float64) string {
+	switch {
+	case margin > 0:
+		return ResultWin
+	case margin < 0:
+		return ResultLoss
+	default:
+		return ResultPush
+	}
+}
+
+// findClosingLine matches a leg to its book's closing line like the CLV calculator:
+// market, book and outcome, ignoring the point
+func findClosingLine(lines []ClosingLine, marketKey string, leg models.OpportunityLeg) *ClosingLine {
+	for i := range lines {
+		line := &lines[i]
+		if line.MarketKey == marketKey && line.BookKey == leg.BookKey && line.OutcomeName == leg.OutcomeName {
+			return line
+		}
+	}
+	return nil
+}
+
+// calculateCLV returns CLV in cents per dollar: (1/close_decimal - 1/bet_decimal) * 100
+func calculateCLV(betDecimal float64, closingPrice int) float64 {
+	return (1.0/odds.AmericanToDecimal(closingPrice) - 1.0/betDecimal) * 100.0
+}
+
+// sortedOpportunityTypes returns the types in a stable order
+func sortedOpportunityTypes(types map[models.OpportunityType]bool) []models.OpportunityType {
+	result := make([]models.OpportunityType, 0, len(types))
+	for opportunityType := range types {
+		result = append(result, opportunityType)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
@@ -0,0 +1,84 @@
+package backtest
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+)
+
+// Entry is one lifecycle event recorded by a backtest
+type Entry struct {
+	Event       models.LifecycleEvent `json:"event"`
+	Opportunity models.Opportunity    `json:"opportunity"`
+}
+
+// Journal is the backtest's lifecycle.Publisher
+// Every lifecycle event is kept in memory for grading and, when a results file is
+// given, written to it as a JSON line in the shape of opportunities.events entries.
+type Journal struct {
+	mu      sync.Mutex
+	out     *bufio.Writer // nil keeps entries in memory only
+	entries []Entry
+}
+
+// NewJournal creates a journal writing to w (nil = memory only)
+func NewJournal(w io.Writer) *Journal {
+	j := &Journal{}
+	if w != nil {
+		j.out = bufio.NewWriter(w)
+	}
+	return j
+}
+
+// Publish implements lifecycle.Publisher; opportunity.detected entries already cover it
+func (j *Journal) Publish(ctx context.Context, opportunity models.Opportunity) error {
+	return nil
+}
+
+// PublishEvent implements lifecycle.Publisher
+func (j *Journal) PublishEvent(ctx context.Context, event models.LifecycleEvent, opportunity models.Opportunity) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entry := Entry{Event: event, Opportunity: opportunity}
+	j.entries = append(j.entries, entry)
+
+	if j.out == nil {
+		return nil
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", event, err)
+	}
+	line = append(line, '\n')
+	if _, err := j.out.Write(line); err != nil {
+		return fmt.Errorf("failed to write %s: %w", event, err)
+	}
+	return nil
+}
+
+// Entries returns the recorded events in order
+func (j *Journal) Entries() []Entry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entries := make([]Entry, len(j.entries))
+	copy(entries, j.entries)
+	return entries
+}
+
+// Flush writes buffered entries to the results file
+func (j *Journal) Flush() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.out == nil {
+		return nil
+	}
+	return j.out.Flush()
+}
@@ -0,0 +1,144 @@
+package backtest
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// scoresBatchSize caps the event IDs sent in one Odds API scores request
+const scoresBatchSize = 40
+
+// EventScore is a final (or in-progress) score in The Odds API scores format
+// A saved /v4/sports/{sport}/scores response can be passed to the backtest as is.
+type EventScore struct {
+	ID        string `json:"id"`
+	SportKey  string `json:"sport_key"`
+	HomeTeam  string `json:"home_team"`
+	AwayTeam  string `json:"away_team"`
+	Completed bool   `json:"completed"`
+	Scores    []struct {
+		Name  string `json:"name"`
+		Score string `json:"score"`
+	} `json:"scores"`
+}
+
+// teamScores returns the home and away scores
+func (s EventScore) teamScores() (home, away int) {
+	for _, score := range s.Scores {
+		if score.Name == s.HomeTeam {
+			fmt.Sscanf(score.Score, "%d", &home)
+		} else if score.Name == s.AwayTeam {
+			fmt.Sscanf(score.Score, "%d", &away)
+		}
+	}
+	return home, away
+}
+
+// ClosingLine is a book's last price on an outcome before the event started
+type ClosingLine struct {
+	EventID      string
+	MarketKey    string
+	BookKey      string
+	OutcomeName  string
+	ClosingPrice int
+	Point        *float64
+}
+
+// ReadScores decodes one or more Odds API scores responses (JSON arrays) by event ID
+func ReadScores(r io.Reader) (map[string]EventScore, error) {
+	scores := make(map[string]EventScore)
+	decoder := json.NewDecoder(r)
+	for {
+		var batch []EventScore
+		if err := decoder.Decode(&batch); err != nil {
+			if errors.Is(err, io.EOF) {
+				return scores, nil
+			}
+			return nil, fmt.Errorf("failed to decode scores: %w", err)
+		}
+		for _, score := range batch {
+			scores[score.ID] = score
+		}
+	}
+}
+
+// FetchScores fetches scores from The Odds API, as the settlement service does
+// The API only returns events from the last 3 days.
+func FetchScores(ctx context.Context, client *http.Client, apiKey, sportKey string, eventIDs []string) (map[string]EventScore, error) {
+	scores := make(map[string]EventScore)
+	for start := 0; start < len(eventIDs); start += scoresBatchSize {
+		end := start + scoresBatchSize
+		if end > len(eventIDs) {
+			end = len(eventIDs)
+		}
+
+		url := fmt.Sprintf("https://api.the-odds-api.com/v4/sports/%s/scores/?apiKey=%s&daysFrom=3&eventIds=%s",
+			sportKey, apiKey, strings.Join(eventIDs[start:end], ","))
+
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch scores: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("scores API returned %d", resp.StatusCode)
+		}
+
+		batch, err := ReadScores(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		for id, score := range batch {
+			scores[id] = score
+		}
+	}
+	return scores, nil
+}
+
+// LoadClosingLines reads Alexandria closing_lines for the given events, by event ID
+func LoadClosingLines(ctx context.Context, db *sql.DB, eventIDs []string) (map[string][]ClosingLine, error) {
+	query := `
+		SELECT event_id, market_key, book_key, outcome_name, closing_price, point
+		FROM closing_lines
+		WHERE event_id = ANY($1)
+	`
+
+	queryCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	rows, err := db.QueryContext(queryCtx, query, pq.Array(eventIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query closing lines: %w", err)
+	}
+	defer rows.Close()
+
+	lines := make(map[string][]ClosingLine)
+	for rows.Next() {
+		var line ClosingLine
+		if err := rows.Scan(&line.EventID, &line.MarketKey, &line.BookKey, &line.OutcomeName, &line.ClosingPrice, &line.Point); err != nil {
+			return nil, fmt.Errorf("failed to scan closing line: %w", err)
+		}
+		lines[line.EventID] = append(lines[line.EventID], line)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating closing lines: %w", err)
+	}
+
+	return lines, nil
+}
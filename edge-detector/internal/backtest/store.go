@@ -0,0 +1,101 @@
+package backtest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/writer"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+)
+
+// MemoryStore is an in-memory lifecycle.Store with Holocron's upsert semantics
+// One open row per fingerprint; an upsert onto an open row keeps first_seen_at
+// and the peak edge and replaces everything else.
+type MemoryStore struct {
+	mu     sync.Mutex
+	nextID int64
+	rows   map[int64]*models.Opportunity
+	open   map[string]int64 // fingerprint -> open row ID
+}
+
+// NewMemoryStore creates an empty store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		rows: make(map[int64]*models.Opportunity),
+		open: make(map[string]int64),
+	}
+}
+
+// UpsertOpportunity implements lifecycle.Store
+func (s *MemoryStore) UpsertOpportunity(ctx context.Context, opportunity models.Opportunity) (writer.UpsertResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if opportunity.PeakEdgePercent < opportunity.EdgePercent {
+		opportunity.PeakEdgePercent = opportunity.EdgePercent
+	}
+
+	if id, isOpen := s.open[opportunity.Fingerprint]; isOpen {
+		existing := s.rows[id]
+		opportunity.ID = id
+		opportunity.FirstSeenAt = existing.FirstSeenAt
+		opportunity.DetectedAt = existing.DetectedAt
+		if existing.LastSeenAt.After(opportunity.LastSeenAt) {
+			opportunity.LastSeenAt = existing.LastSeenAt
+		}
+		if existing.PeakEdgePercent > opportunity.PeakEdgePercent {
+			opportunity.PeakEdgePercent = existing.PeakEdgePercent
+		}
+		s.rows[id] = &opportunity
+		return writer.UpsertResult{ID: id, FirstSeenAt: opportunity.FirstSeenAt, PeakEdgePercent: opportunity.PeakEdgePercent}, nil
+	}
+
+	s.nextID++
+	opportunity.ID = s.nextID
+	s.rows[opportunity.ID] = &opportunity
+	if opportunity.Fingerprint != "" {
+		s.open[opportunity.Fingerprint] = opportunity.ID
+	}
+	return writer.UpsertResult{ID: opportunity.ID, Inserted: true, FirstSeenAt: opportunity.FirstSeenAt, PeakEdgePercent: opportunity.PeakEdgePercent}, nil
+}
+
+// TouchOpportunity implements lifecycle.Store
+func (s *MemoryStore) TouchOpportunity(ctx context.Context, id int64, lastSeenAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if row, ok := s.rows[id]; ok && row.ClosedAt == nil && lastSeenAt.After(row.LastSeenAt) {
+		row.LastSeenAt = lastSeenAt
+	}
+	return nil
+}
+
+// CloseOpportunity implements lifecycle.Store
+func (s *MemoryStore) CloseOpportunity(ctx context.Context, id int64, closedAt time.Time, reason string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	row, ok := s.rows[id]
+	if !ok || row.ClosedAt != nil {
+		return nil
+	}
+	row.ClosedAt = &closedAt
+	row.CloseReason = reason
+	delete(s.open, row.Fingerprint)
+	return nil
+}
+
+// GetOpenOpportunities implements lifecycle.Store
+func (s *MemoryStore) GetOpenOpportunities(ctx context.Context, sportKey string) ([]models.Opportunity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var opportunities []models.Opportunity
+	for _, id := range s.open {
+		if row := s.rows[id]; row.SportKey == sportKey {
+			opportunities = append(opportunities, *row)
+		}
+	}
+	return opportunities, nil
+}
@@ -0,0 +1,127 @@
+package books
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+)
+
+// ProfileStore holds book profiles loaded from Holocron book_profiles
+// Books without a row are priced as posted with no limits.
+type ProfileStore struct {
+	db              *sql.DB
+	refreshInterval time.Duration
+
+	mu       sync.RWMutex
+	profiles map[string]models.BookProfile
+}
+
+// NewProfileStore creates a book profile store; call Load before detection starts
+func NewProfileStore(db *sql.DB, refreshInterval time.Duration) *ProfileStore {
+	return &ProfileStore{
+		db:              db,
+		refreshInterval: refreshInterval,
+		profiles:        make(map[string]models.BookProfile),
+	}
+}
+
+// GetBookProfile implements contracts.BookProfileProvider
+func (s *ProfileStore) GetBookProfile(bookKey string) models.BookProfile {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if profile, ok := s.profiles[bookKey]; ok {
+		return profile
+	}
+	return models.BookProfile{BookKey: bookKey}
+}
+
+// Count returns how many books have a profile
+func (s *ProfileStore) Count() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.profiles)
+}
+
+// Load replaces the cached profiles with the current book_profiles rows
+func (s *ProfileStore) Load(ctx context.Context) error {
+	query := `
+		SELECT book_key, commission_rate, tax_rate, tax_basis,
+		       min_stake, max_stake, market_max_stakes, stake_increment
+		FROM book_profiles
+	`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to query book profiles: %w", err)
+	}
+	defer rows.Close()
+
+	profiles := make(map[string]models.BookProfile)
+	for rows.Next() {
+		var profile models.BookProfile
+		var taxBasis string
+		var maxStake sql.NullFloat64
+		var marketMaxStakes []byte
+
+		if err := rows.Scan(
+			&profile.BookKey,
+			&profile.CommissionRate,
+			&profile.TaxRate,
+			&taxBasis,
+			&profile.MinStake,
+			&maxStake,
+			&marketMaxStakes,
+			&profile.StakeIncrement,
+		); err != nil {
+			return fmt.Errorf("failed to scan book profile: %w", err)
+		}
+
+		profile.TaxBasis = models.TaxBasis(taxBasis)
+		profile.MaxStake = maxStake.Float64
+		if len(marketMaxStakes) > 0 {
+			if err := json.Unmarshal(marketMaxStakes, &profile.MarketMaxStakes); err != nil {
+				return fmt.Errorf("invalid market_max_stakes for %s: %w", profile.BookKey, err)
+			}
+		}
+		profiles[profile.BookKey] = profile
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating book profiles: %w", err)
+	}
+
+	s.mu.Lock()
+	s.profiles = profiles
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Run reloads profiles every refresh interval until ctx is cancelled
+// A failed reload keeps the previous profiles.
+func (s *ProfileStore) Run(ctx context.Context) {
+	interval := s.refreshInterval
+	if interval <= 0 {
+		interval = 5 * time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Load(ctx); err != nil {
+				fmt.Printf("⚠️  Failed to reload book profiles: %v\n", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package books
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+)
+
+// SharpnessStore holds book sharpness scores loaded from Holocron book_sharpness
+// Markets with no sharp book scored are reported unscored so providers fall back.
+type SharpnessStore struct {
+	db              *sql.DB
+	refreshInterval time.Duration
+
+	mu      sync.RWMutex
+	markets map[string]map[string]models.BookSharpness // sport|market -> book -> score
+	sharp   map[string]bool                            // sport|market -> has a sharp book
+}
+
+// NewSharpnessStore creates a sharpness store; call Load before detection starts
+func NewSharpnessStore(db *sql.DB, refreshInterval time.Duration) *SharpnessStore {
+	return &SharpnessStore{
+		db:              db,
+		refreshInterval: refreshInterval,
+		markets:         make(map[string]map[string]models.BookSharpness),
+		sharp:           make(map[string]bool),
+	}
+}
+
+// GetBookSharpness implements contracts.SharpnessProvider
+func (s *SharpnessStore) GetBookSharpness(sportKey, marketKey, bookKey string) (models.BookSharpness, bool) {
+	key := sportKey + "|" + marketKey
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.sharp[key] {
+		return models.BookSharpness{}, false
+	}
+	if sharpness, ok := s.markets[key][bookKey]; ok {
+		return sharpness, true
+	}
+	return models.BookSharpness{SportKey: sportKey, MarketKey: marketKey, BookKey: bookKey}, true
+}
+
+// Count returns how many markets have a scored sharp set
+func (s *SharpnessStore) Count() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.sharp)
+}
+
+// Load replaces the cached scores with the current book_sharpness rows
+func (s *SharpnessStore) Load(ctx context.Context) error {
+	query := `
+		SELECT sport_key, market_key, book_key, events, samples,
+		       opening_error, intraday_error, score, weight, is_sharp,
+		       window_start, window_end, scored_at
+		FROM book_sharpness
+	`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to query book sharpness: %w", err)
+	}
+	defer rows.Close()
+
+	markets := make(map[string]map[string]models.BookSharpness)
+	sharp := make(map[string]bool)
+	for rows.Next() {
+		var sharpness models.BookSharpness
+		var openingError, intradayError sql.NullFloat64
+
+		if err := rows.Scan(
+			&sharpness.SportKey,
+			&sharpness.MarketKey,
+			&sharpness.BookKey,
+			&sharpness.Events,
+			&sharpness.Samples,
+			&openingError,
+			&intradayError,
+			&sharpness.Score,
+			&sharpness.Weight,
+			&sharpness.IsSharp,
+			&sharpness.WindowStart,
+			&sharpness.WindowEnd,
+			&sharpness.ScoredAt,
+		); err != nil {
+			return fmt.Errorf("failed to scan book sharpness: %w", err)
+		}
+
+		if openingError.Valid {
+			sharpness.OpeningError = &openingError.Float64
+		}
+		if intradayError.Valid {
+			sharpness.IntradayError = &intradayError.Float64
+		}
+
+		key := sharpness.SportKey + "|" + sharpness.MarketKey
+		if markets[key] == nil {
+			markets[key] = make(map[string]models.BookSharpness)
+		}
+		markets[key][sharpness.BookKey] = sharpness
+		if sharpness.IsSharp {
+			sharp[key] = true
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating book sharpness: %w", err)
+	}
+
+	s.mu.Lock()
+	s.markets = markets
+	s.sharp = sharp
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Run reloads scores every refresh interval until ctx is cancelled
+// A failed reload keeps the previous scores.
+func (s *SharpnessStore) Run(ctx context.Context) {
+	interval := s.refreshInterval
+	if interval <= 0 {
+		interval = 15 * time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Load(ctx); err != nil {
+				fmt.Printf("⚠️  Failed to reload book sharpness: %v\n", err)
+			}
+		}
+	}
+}
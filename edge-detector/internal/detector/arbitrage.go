@@ -0,0 +1,254 @@
+package detector
+
+import (
+	"math"
+	"sort"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+)
+
+// solverEpsilon treats smaller pivots and stakes as zero
+const solverEpsilon = 1e-12
+
+// SolveArbitrage finds the stakes that return the same amount in every state
+// returns[i][j] is what one unit on leg j pays back in state i: its decimal odds if
+// it wins, 1 if it pushes, 0 if it loses. Needs one leg per state. Returns the stake
+// fractions (summing to 1) and the return per unit staked; ok is false when the legs
+// can't be balanced with positive stakes. Arbitrage exists when the return is above 1.
+func SolveArbitrage(returns [][]float64) (stakes []float64, guaranteedReturn float64, ok bool) {
+	n := len(returns)
+	if n < 2 {
+		return nil, 0, false
+	}
+
+	// Augmented matrix [R | 1]: the stakes that return exactly 1 in every state
+	m := make([][]float64, n)
+	for i, row := range returns {
+		if len(row) != n {
+			return nil, 0, false
+		}
+		m[i] = make([]float64, n+1)
+		copy(m[i], row)
+		m[i][n] = 1
+	}
+
+	// Gaussian elimination with partial pivoting
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(m[row][col]) > math.Abs(m[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(m[pivot][col]) < solverEpsilon {
+			return nil, 0, false // Legs don't cover the states independently
+		}
+		m[col], m[pivot] = m[pivot], m[col]
+
+		for row := 0; row < n; row++ {
+			if row == col || m[row][col] == 0 {
+				continue
+			}
+			factor := m[row][col] / m[col][col]
+			for k := col; k <= n; k++ {
+				m[row][k] -= factor * m[col][k]
+			}
+		}
+	}
+
+	// Scale the unit-return stakes to fractions; the return scales inversely
+	stakes = make([]float64, n)
+	total := 0.0
+	for i := range stakes {
+		stakes[i] = m[i][n] / m[i][i]
+		if stakes[i] < solverEpsilon {
+			return nil, 0, false
+		}
+		total += stakes[i]
+	}
+	for i := range stakes {
+		stakes[i] /= total
+	}
+
+	return stakes, 1 / total, true
+}
+
+// arbLeg is a quote placed on the states of an event or market
+// One unit on it pays its net decimal odds in the win state, is refunded in the push
+// state and is lost everywhere else.
+type arbLeg struct {
+	odds    models.NormalizedOdds
+	profile models.BookProfile
+	decimal float64 // Posted decimal odds after the book's commission and tax
+	win     int
+	push    int // -1 when the leg never pushes
+}
+
+// newArbLeg prices a quote at what its book actually pays on a win
+func newArbLeg(odds models.NormalizedOdds, profile models.BookProfile, win, push int) arbLeg {
+	return arbLeg{
+		odds:    odds,
+		profile: profile,
+		decimal: profile.NetDecimal(odds.DecimalOdds),
+		win:     win,
+		push:    push,
+	}
+}
+
+// signature identifies legs that settle the same way, whatever their price
+func (l arbLeg) signature() [2]int {
+	return [2]int{l.win, l.push}
+}
+
+// returns is the leg's payback per unit staked in each state
+func (l arbLeg) returns(states int) []float64 {
+	column := make([]float64, states)
+	column[l.win] = l.decimal
+	if l.push >= 0 {
+		column[l.push] = 1
+	}
+	return column
+}
+
+// arbitrage is a balanced set of legs and what it returns whatever happens
+type arbitrage struct {
+	legs             []arbLeg
+	stakes           []float64 // Fraction of the total stake per leg
+	guaranteedReturn float64   // Payback per unit staked in every (non-push) state
+	maxStake         float64   // Largest total stake the legs' limits allow (0 = unlimited)
+}
+
+// bestArbitrage takes the best price for every way of settling and returns the
+// highest-returning set of legs that covers every state and includes the trigger.
+// Sets without the trigger were priced when their own quotes arrived.
+func bestArbitrage(trigger models.NormalizedOdds, legs []arbLeg, states int) *arbitrage {
+	if states < 2 {
+		return nil
+	}
+
+	// Best price per signature; the trigger wins ties so its arrival is reported
+	best := make(map[[2]int]arbLeg)
+	for _, leg := range legs {
+		current, exists := best[leg.signature()]
+		if !exists || leg.decimal > current.decimal ||
+			(leg.decimal == current.decimal && sameQuote(leg.odds, trigger)) {
+			best[leg.signature()] = leg
+		}
+	}
+
+	candidates := make([]arbLeg, 0, len(best))
+	for _, leg := range best {
+		candidates = append(candidates, leg)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i].signature(), candidates[j].signature()
+		return a[0] < b[0] || (a[0] == b[0] && a[1] < b[1])
+	})
+
+	var result *arbitrage
+	forEachCombination(len(candidates), states, func(indexes []int) {
+		set := make([]arbLeg, len(indexes))
+		covered := make([]bool, states)
+		hasTrigger := false
+		for i, index := range indexes {
+			set[i] = candidates[index]
+			covered[set[i].win] = true
+			hasTrigger = hasTrigger || sameQuote(set[i].odds, trigger)
+		}
+		if !hasTrigger {
+			return
+		}
+		for _, c := range covered {
+			if !c {
+				return // A state no leg wins can only lose money
+			}
+		}
+
+		returns := make([][]float64, states)
+		for i := range returns {
+			returns[i] = make([]float64, states)
+		}
+		for j, leg := range set {
+			for i, r := range leg.returns(states) {
+				returns[i][j] = r
+			}
+		}
+
+		stakes, guaranteedReturn, ok := SolveArbitrage(returns)
+		if !ok || (result != nil && guaranteedReturn <= result.guaranteedReturn) {
+			return
+		}
+		result = &arbitrage{legs: set, stakes: stakes, guaranteedReturn: guaranteedReturn}
+	})
+
+	return result
+}
+
+// sizeArbitrage caps the total stake at the tightest leg limit and re-prices the
+// arbitrage at stakes rounded down to each book's increment
+// Returns false when a rounded stake falls below its book's minimum. Without any
+// limit the exact fractions stand and there is no size to round.
+func sizeArbitrage(arb *arbitrage, states int) bool {
+	maxTotal := math.Inf(1)
+	for i, leg := range arb.legs {
+		if limit := leg.profile.MaxStakeFor(leg.odds.MarketKey); limit > 0 {
+			maxTotal = math.Min(maxTotal, limit/arb.stakes[i])
+		}
+	}
+	if math.IsInf(maxTotal, 1) {
+		return true
+	}
+
+	amounts := make([]float64, len(arb.legs))
+	total := 0.0
+	for i, leg := range arb.legs {
+		amounts[i] = leg.profile.RoundStake(arb.stakes[i] * maxTotal)
+		if amounts[i] <= 0 || amounts[i] < leg.profile.MinStake {
+			return false
+		}
+		total += amounts[i]
+	}
+
+	// Rounded stakes no longer return exactly the same everywhere; the worst state counts
+	worst := math.Inf(1)
+	for state := 0; state < states; state++ {
+		payback := 0.0
+		for i, leg := range arb.legs {
+			payback += amounts[i] * leg.returns(states)[state]
+		}
+		worst = math.Min(worst, payback/total)
+	}
+
+	for i := range arb.stakes {
+		arb.stakes[i] = amounts[i] / total
+	}
+	arb.guaranteedReturn = worst
+	arb.maxStake = total
+	return true
+}
+
+// forEachCombination calls fn with every k-subset of 0..n-1 in lexicographic order
+func forEachCombination(n, k int, fn func(indexes []int)) {
+	if k > n || k <= 0 {
+		return
+	}
+	indexes := make([]int, k)
+	for i := range indexes {
+		indexes[i] = i
+	}
+	for {
+		fn(indexes)
+
+		i := k - 1
+		for i >= 0 && indexes[i] == n-k+i {
+			i--
+		}
+		if i < 0 {
+			return
+		}
+		indexes[i]++
+		for j := i + 1; j < k; j++ {
+			indexes[j] = indexes[j-1] + 1
+		}
+	}
+}
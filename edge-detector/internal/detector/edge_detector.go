@@ -6,19 +6,30 @@ import (
 
 	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/contracts"
 	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+	sharedodds "github.com/XavierBriggs/fortuna/services/shared/odds"
 )
 
 // EdgeDetector detects simple +EV opportunities (single bets with positive edge)
+// Edge is measured on what a win nets after the book's commission and tax.
 type EdgeDetector struct {
 	config            contracts.DetectorConfig
 	sharpBookProvider contracts.SharpBookProvider
+	profiles          contracts.BookProfileProvider // nil prices every book as posted
+	now               func() time.Time
 }
 
 // NewEdgeDetector creates a new edge detector
-func NewEdgeDetector(config contracts.DetectorConfig, sharpBookProvider contracts.SharpBookProvider) *EdgeDetector {
+func NewEdgeDetector(config contracts.DetectorConfig, sharpBookProvider contracts.SharpBookProvider, profiles contracts.BookProfileProvider) *EdgeDetector {
+	return NewEdgeDetectorWithClock(config, sharpBookProvider, profiles, time.Now)
+}
+
+// NewEdgeDetectorWithClock creates an edge detector with an injected clock
+func NewEdgeDetectorWithClock(config contracts.DetectorConfig, sharpBookProvider contracts.SharpBookProvider, profiles contracts.BookProfileProvider, now func() time.Time) *EdgeDetector {
 	return &EdgeDetector{
 		config:            config,
 		sharpBookProvider: sharpBookProvider,
+		profiles:          profiles,
+		now:               now,
 	}
 }
 
@@ -30,26 +41,28 @@ func (d *EdgeDetector) Detect(ctx context.Context, odds models.NormalizedOdds, m
 	}
 
 	// Check data age
-	dataAge := time.Since(odds.ReceivedAt)
+	now := d.now()
+	dataAge := now.Sub(odds.ReceivedAt)
 	if int(dataAge.Seconds()) > d.config.GetMaxDataAgeSeconds() {
 		return nil, nil // Data too stale
 	}
 
-	// Get sharp consensus for this outcome
-	sharpConsensus, err := d.sharpBookProvider.GetSharpConsensus(ctx, marketOdds)
-	if err != nil {
-		// No sharp consensus available - skip this opportunity
+	// Get sharp consensus for this outcome at this line
+	fairProb, exists := lineConsensus(ctx, d.sharpBookProvider, odds, marketOdds)
+	if !exists {
+		// No sharp consensus for this outcome and line - skip this opportunity
 		return nil, nil
 	}
 
-	fairProb, exists := sharpConsensus[odds.OutcomeName]
-	if !exists {
-		// No sharp consensus for this specific outcome
-		return nil, nil
+	// Price the bet at what a win nets after commission and tax
+	profile := bookProfile(d.profiles, odds.BookKey)
+	impliedProb := odds.ImpliedProbability
+	if profile.HasCosts() {
+		impliedProb = 1.0 / profile.NetDecimal(odds.DecimalOdds)
 	}
 
 	// Calculate edge: (fairProb / impliedProb) - 1
-	edge := (fairProb / odds.ImpliedProbability) - 1.0
+	edge := (fairProb / impliedProb) - 1.0
 
 	// Check if edge meets threshold
 	if edge < d.config.GetMinEdgePercent() {
@@ -57,13 +70,13 @@ func (d *EdgeDetector) Detect(ctx context.Context, odds models.NormalizedOdds, m
 	}
 
 	// Check if this is a soft book (we only want edges at soft books)
-	if d.sharpBookProvider.IsSharpBook(odds.BookKey) {
+	if d.sharpBookProvider.IsSharpBookForMarket(odds.BookKey, odds.MarketKey) {
 		return nil, nil // Don't bet sharp books (they're efficient)
 	}
 
 	// Calculate fair price in American odds
 	fairDecimal := 1.0 / fairProb
-	fairPrice := decimalToAmerican(fairDecimal)
+	fairPrice := sharedodds.DecimalToAmerican(fairDecimal)
 
 	// Create opportunity
 	opportunity := models.Opportunity{
@@ -73,13 +86,16 @@ func (d *EdgeDetector) Detect(ctx context.Context, odds models.NormalizedOdds, m
 		MarketKey:       odds.MarketKey,
 		EdgePercent:     edge * 100, // Convert to percentage
 		FairPrice:       &fairPrice,
-		DetectedAt:      time.Now(),
+		DetectedAt:      now,
 		DataAgeSeconds:  int(dataAge.Seconds()),
+		MaxStake:        stakeLimit(profile.MaxStakeFor(odds.MarketKey)),
 		Legs: []models.OpportunityLeg{
 			{
 				BookKey:        odds.BookKey,
 				OutcomeName:    odds.OutcomeName,
 				Price:          odds.Price,
+				PriceFormat:    odds.PriceFormat,
+				PriceValue:     odds.PriceValue,
 				Point:          odds.Point,
 				LegEdgePercent: &[]float64{edge * 100}[0],
 			},
@@ -100,25 +116,51 @@ func (d *EdgeDetector) IsEnabled() bool {
 }
 
 // isMarketEnabled checks if a market is enabled in config
+// Player props are priced by the prop detector: sharp consensus here is keyed by
+// outcome alone, which would mix players and lines.
 func (d *EdgeDetector) isMarketEnabled(marketKey string) bool {
-	enabledMarkets := d.config.GetEnabledMarkets()
-	for _, m := range enabledMarkets {
-		if m == marketKey {
-			return true
+	return !isPropMarket(marketKey) && marketEnabled(d.config, marketKey)
+}
+
+// lineConsensus returns the sharp fair probability for odds' outcome at its point
+// False when no sharp book quotes the same outcome, player and point.
+func lineConsensus(ctx context.Context, sharpBookProvider contracts.SharpBookProvider, odds models.NormalizedOdds, marketOdds []models.NormalizedOdds) (float64, bool) {
+	sharpConsensus, err := sharpBookProvider.GetSharpConsensus(ctx, quotesAtLine(odds, marketOdds))
+	if err != nil {
+		return 0, false
+	}
+	fairProb, exists := sharpConsensus[odds.OutcomeName]
+	return fairProb, exists
+}
+
+// quotesAtLine returns the market's quotes for the same outcome, player and point as odds
+// Books post alternate lines side by side, so consensus must not mix them.
+func quotesAtLine(odds models.NormalizedOdds, marketOdds []models.NormalizedOdds) []models.NormalizedOdds {
+	var quotes []models.NormalizedOdds
+	for _, quote := range marketOdds {
+		if quote.OutcomeName == odds.OutcomeName &&
+			quote.Description == odds.Description &&
+			samePoint(quote.Point, odds.Point) {
+			quotes = append(quotes, quote)
 		}
 	}
-	return false
+	return quotes
 }
 
-// decimalToAmerican converts decimal odds to American odds
-func decimalToAmerican(decimal float64) int {
-	if decimal >= 2.0 {
-		// Positive American odds: (decimal - 1) * 100
-		return int((decimal - 1.0) * 100)
+// bookProfile returns a book's profile, or the posted-price profile without a provider
+func bookProfile(profiles contracts.BookProfileProvider, bookKey string) models.BookProfile {
+	if profiles == nil {
+		return models.BookProfile{BookKey: bookKey}
 	}
+	return profiles.GetBookProfile(bookKey)
+}
 
-	// Negative American odds: -100 / (decimal - 1)
-	return int(-100.0 / (decimal - 1.0))
+// stakeLimit returns a max stake for an opportunity, nil when unlimited
+func stakeLimit(limit float64) *float64 {
+	if limit <= 0 {
+		return nil
+	}
+	return &limit
 }
 
 // CalculateEdge computes edge percentage given fair and implied probabilities
@@ -7,64 +7,100 @@ import (
 	"time"
 
 	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/consumer"
-	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/publisher"
-	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/writer"
-	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/contracts"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/lifecycle"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/marketstate"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/metrics"
 	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
 )
 
 // Engine orchestrates opportunity detection
 type Engine struct {
-	consumer          *consumer.StreamConsumer
-	holocronWriter    *writer.HolocronWriter
-	streamPublisher   *publisher.StreamPublisher
-	sharpBookProvider contracts.SharpBookProvider
-	config            contracts.DetectorConfig
+	consumer *consumer.StreamConsumer
 
-	// Detectors
-	edgeDetector   contracts.OpportunityDetector
-	middleDetector contracts.OpportunityDetector
-	scalpDetector  contracts.OpportunityDetector
+	// Upserts opportunities by fingerprint and publishes their lifecycle events
+	tracker *lifecycle.Tracker
 
-	// Market cache for grouping odds
-	marketCache sync.Map // key: "eventID:marketKey" -> []models.NormalizedOdds
+	// Detectors by sport and opportunity type
+	detectors *Registry
+
+	// Market state for grouping odds by event+market
+	marketStore *marketstate.Store
+
+	// Prometheus counters and latency histograms (nil = disabled)
+	metrics *metrics.Recorder
+
+	// Run detectors one at a time without timeouts (replay)
+	synchronous bool
 
 	// Metrics
 	detectedCount      int64
 	errorCount         int64
 	totalLatencyMs     int64 // Cumulative latency in milliseconds
 	detectionLatencyMs int64 // Cumulative detection-only latency
+	detectorStats      map[models.OpportunityType]*DetectorStats
 	mu                 sync.Mutex
 }
 
+// DetectorStats summarizes one opportunity type's detector runs across sports
+type DetectorStats struct {
+	Runs          int64
+	Errors        int64
+	Timeouts      int64
+	Opportunities int64
+	AvgLatencyMs  float64
+
+	totalLatency time.Duration
+}
+
+// detectorResult is one detector's output for a message
+type detectorResult struct {
+	opportunityType models.OpportunityType
+	opportunities   []models.Opportunity
+	err             error
+	timedOut        bool
+	elapsed         time.Duration
+}
+
 // NewEngine creates a new detection engine
 func NewEngine(
 	consumer *consumer.StreamConsumer,
-	holocronWriter *writer.HolocronWriter,
-	streamPublisher *publisher.StreamPublisher,
-	sharpBookProvider contracts.SharpBookProvider,
-	config contracts.DetectorConfig,
+	tracker *lifecycle.Tracker,
+	detectors *Registry,
+	marketStore *marketstate.Store,
+	metrics *metrics.Recorder,
 ) *Engine {
-	e := &Engine{
-		consumer:          consumer,
-		holocronWriter:    holocronWriter,
-		streamPublisher:   streamPublisher,
-		sharpBookProvider: sharpBookProvider,
-		config:            config,
-		edgeDetector:      NewEdgeDetector(config, sharpBookProvider),
-		middleDetector:    NewMiddleDetector(config, sharpBookProvider),
-		scalpDetector:     NewScalpDetector(config),
+	return &Engine{
+		consumer:      consumer,
+		tracker:       tracker,
+		detectors:     detectors,
+		marketStore:   marketStore,
+		metrics:       metrics,
+		detectorStats: make(map[models.OpportunityType]*DetectorStats),
 	}
+}
 
-	return e
+// NewReplayEngine creates an engine for the backtest, driven with ProcessOdds
+// Detectors run one after another without timeouts, so results don't depend on
+// machine load.
+func NewReplayEngine(tracker *lifecycle.Tracker, detectors *Registry, marketStore *marketstate.Store) *Engine {
+	engine := NewEngine(nil, tracker, detectors, marketStore, nil)
+	engine.synchronous = true
+	return engine
 }
 
 // Start begins processing normalized odds for a sport
 func (e *Engine) Start(ctx context.Context, sportKey string) error {
 	streamKey := fmt.Sprintf("odds.normalized.%s", sportKey)
 
+	if len(e.detectors.ForSport(sportKey)) == 0 {
+		return fmt.Errorf("no detectors registered for sport: %s", sportKey)
+	}
+
 	fmt.Printf("✓ Starting detection engine for stream: %s\n", streamKey)
 
+	// Evict markets nobody has updated within the TTL
+	go e.marketStore.Run(ctx)
+
 	// Subscribe to normalized odds stream
 	messageCh, errorCh := e.consumer.ConsumeStream(ctx, streamKey)
 
@@ -87,6 +123,7 @@ func (e *Engine) Start(ctx context.Context, sportKey string) error {
 			if err := e.processMessage(ctx, msg); err != nil {
 				fmt.Printf("error processing message %s: %v\n", msg.ID, err)
 				e.incrementErrorCount()
+				e.metrics.Error(msg.StreamKey)
 			}
 
 			// Acknowledge the message
@@ -99,8 +136,15 @@ func (e *Engine) Start(ctx context.Context, sportKey string) error {
 
 // processMessage processes a single normalized odds message
 func (e *Engine) processMessage(ctx context.Context, msg consumer.Message) error {
+	e.ProcessOdds(ctx, msg.StreamKey, msg.NormalizedOdds)
+	return nil
+}
+
+// ProcessOdds runs one normalized quote through the market store, detectors and tracker
+// It needs no consumer, so the backtest drives it directly. Returns the lifecycle
+// changes the quote caused.
+func (e *Engine) ProcessOdds(ctx context.Context, streamKey string, odds models.NormalizedOdds) []lifecycle.Change {
 	startTime := time.Now()
-	odds := msg.NormalizedOdds
 
 	// Update market cache with this odds
 	e.updateMarketCache(odds)
@@ -108,43 +152,25 @@ func (e *Engine) processMessage(ctx context.Context, msg consumer.Message) error
 	// Get all odds for this market
 	marketOdds := e.getMarketOdds(odds)
 
-	// Run all enabled detectors
+	// Run the sport's detectors concurrently
 	detectionStart := time.Now()
-	allOpportunities := make([]models.Opportunity, 0)
-
-	// 1. Edge detector (always enabled)
-	if opportunities, err := e.edgeDetector.Detect(ctx, odds, marketOdds); err == nil {
-		allOpportunities = append(allOpportunities, opportunities...)
-	} else {
-		fmt.Printf("edge detector error: %v\n", err)
-	}
-
-	// 2. Middle detector (if enabled)
-	if e.middleDetector.IsEnabled() {
-		if opportunities, err := e.middleDetector.Detect(ctx, odds, marketOdds); err == nil {
-			allOpportunities = append(allOpportunities, opportunities...)
-		} else {
-			fmt.Printf("middle detector error: %v\n", err)
-		}
-	}
+	allOpportunities, completed := e.runDetectors(ctx, odds, marketOdds)
 
-	// 3. Scalp detector (if enabled)
-	if e.scalpDetector.IsEnabled() {
-		if opportunities, err := e.scalpDetector.Detect(ctx, odds, marketOdds); err == nil {
-			allOpportunities = append(allOpportunities, opportunities...)
-		} else {
-			fmt.Printf("scalp detector error: %v\n", err)
-		}
+	// Open, refresh or close opportunities; re-detections don't add rows
+	changes, err := e.tracker.Observe(ctx, odds, allOpportunities, completed)
+	if err != nil {
+		fmt.Printf("error tracking opportunities: %v\n", err)
 	}
 
-	// Process detected opportunities
-	for _, opportunity := range allOpportunities {
-		if err := e.processOpportunity(ctx, opportunity); err != nil {
-			fmt.Printf("error processing opportunity: %v\n", err)
+	// Report newly opened opportunities
+	for _, change := range changes {
+		if change.Event != models.EventOpportunityDetected {
 			continue
 		}
+		opportunity := change.Opportunity
 
 		e.incrementDetectedCount()
+		e.metrics.Detected(streamKey, odds, opportunity)
 		
 		// Calculate latencies
 		detectionLatency := time.Since(detectionStart).Milliseconds()
@@ -157,82 +183,132 @@ func (e *Engine) processMessage(ctx context.Context, msg consumer.Message) error
 			opportunity.EdgePercent, detectionLatency, totalLatency)
 	}
 
-	return nil
+	e.metrics.Processed(streamKey, time.Since(startTime))
+	return changes
 }
 
-// processOpportunity writes an opportunity to Holocron and publishes to stream
-func (e *Engine) processOpportunity(ctx context.Context, opportunity models.Opportunity) error {
-	// Write to Holocron
-	opportunityID, err := e.holocronWriter.WriteOpportunity(ctx, opportunity)
-	if err != nil {
-		return fmt.Errorf("failed to write to Holocron: %w", err)
-	}
-
-	// Update opportunity with database ID
-	opportunity.ID = opportunityID
+// runDetectors runs every enabled detector for the odds' sport concurrently
+// A failing or timed-out detector is logged and skipped; the others' opportunities
+// are returned in opportunity type order, along with the types that completed.
+func (e *Engine) runDetectors(ctx context.Context, odds models.NormalizedOdds, marketOdds []models.NormalizedOdds) ([]models.Opportunity, []models.OpportunityType) {
+	detectors := e.detectors.ForSport(odds.SportKey)
+	results := make([]detectorResult, len(detectors))
 
-	// Publish to stream
-	if err := e.streamPublisher.Publish(ctx, opportunity); err != nil {
-		return fmt.Errorf("failed to publish to stream: %w", err)
+	var wg sync.WaitGroup
+	for i, registered := range detectors {
+		if !registered.Enabled() {
+			continue
+		}
+		if e.synchronous {
+			results[i] = runDetectorInline(ctx, registered, odds, marketOdds)
+			continue
+		}
+		wg.Add(1)
+		go func(i int, registered Registered) {
+			defer wg.Done()
+			results[i] = runDetector(ctx, registered, odds, marketOdds)
+		}(i, registered)
 	}
+	wg.Wait()
 
-	return nil
-}
-
-// getMarketOdds retrieves all odds for the same event+market from cache
-func (e *Engine) getMarketOdds(odds models.NormalizedOdds) []models.NormalizedOdds {
-	cacheKey := e.buildMarketKey(odds)
+	allOpportunities := make([]models.Opportunity, 0)
+	completed := make([]models.OpportunityType, 0, len(results))
+	for _, result := range results {
+		if result.opportunityType == "" {
+			continue // Disabled
+		}
 
-	if value, ok := e.marketCache.Load(cacheKey); ok {
-		if cached, ok := value.([]models.NormalizedOdds); ok {
-			return cached
+		e.recordDetectorRun(odds.SportKey, result)
+		if result.err != nil {
+			fmt.Printf("%s detector error: %v\n", result.opportunityType, result.err)
+			continue
 		}
+		allOpportunities = append(allOpportunities, result.opportunities...)
+		completed = append(completed, result.opportunityType)
 	}
 
-	return []models.NormalizedOdds{}
+	return allOpportunities, completed
 }
 
-// updateMarketCache adds or updates odds in the market cache
-func (e *Engine) updateMarketCache(odds models.NormalizedOdds) {
-	cacheKey := e.buildMarketKey(odds)
-
-	// Load existing odds
-	var marketOdds []models.NormalizedOdds
-	if value, ok := e.marketCache.Load(cacheKey); ok {
-		if cached, ok := value.([]models.NormalizedOdds); ok {
-			marketOdds = cached
+// runDetector runs one detector under its timeout
+// Detectors aren't required to watch ctx, so a run that overruns is abandoned
+// and whatever it finds later is dropped.
+func runDetector(ctx context.Context, registered Registered, odds models.NormalizedOdds, marketOdds []models.NormalizedOdds) detectorResult {
+	opportunityType := registered.Detector.GetType()
+
+	detectCtx, cancel := context.WithTimeout(ctx, registered.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan detectorResult, 1)
+	go func() {
+		opportunities, err := registered.Detector.Detect(detectCtx, odds, marketOdds)
+		done <- detectorResult{opportunityType: opportunityType, opportunities: opportunities, err: err}
+	}()
+
+	select {
+	case result := <-done:
+		result.elapsed = time.Since(start)
+		return result
+	case <-detectCtx.Done():
+		return detectorResult{
+			opportunityType: opportunityType,
+			err:             fmt.Errorf("timed out after %s", registered.Timeout),
+			timedOut:        true,
+			elapsed:         time.Since(start),
 		}
 	}
+}
 
-	// Update or append this odds
-	found := false
-	for i, existingOdds := range marketOdds {
-		if existingOdds.BookKey == odds.BookKey && existingOdds.OutcomeName == odds.OutcomeName {
-			// Update existing
-			marketOdds[i] = odds
-			found = true
-			break
-		}
+// runDetectorInline runs one detector to completion, ignoring its timeout
+func runDetectorInline(ctx context.Context, registered Registered, odds models.NormalizedOdds, marketOdds []models.NormalizedOdds) detectorResult {
+	start := time.Now()
+	opportunities, err := registered.Detector.Detect(ctx, odds, marketOdds)
+	return detectorResult{
+		opportunityType: registered.Detector.GetType(),
+		opportunities:   opportunities,
+		err:             err,
+		elapsed:         time.Since(start),
 	}
+}
 
-	if !found {
-		// Append new
-		marketOdds = append(marketOdds, odds)
+// recordDetectorRun updates per-detector stats and Prometheus metrics
+func (e *Engine) recordDetectorRun(sportKey string, result detectorResult) {
+	e.mu.Lock()
+	stats, exists := e.detectorStats[result.opportunityType]
+	if !exists {
+		stats = &DetectorStats{}
+		e.detectorStats[result.opportunityType] = stats
+	}
+	stats.Runs++
+	stats.totalLatency += result.elapsed
+	switch {
+	case result.timedOut:
+		stats.Timeouts++
+	case result.err != nil:
+		stats.Errors++
+	default:
+		stats.Opportunities += int64(len(result.opportunities))
 	}
+	e.mu.Unlock()
 
-	// Store back in cache
-	e.marketCache.Store(cacheKey, marketOdds)
+	outcome := metrics.DetectorOK
+	if result.timedOut {
+		outcome = metrics.DetectorTimeout
+	} else if result.err != nil {
+		outcome = metrics.DetectorError
+	}
+	e.metrics.DetectorRun(sportKey, string(result.opportunityType), outcome, result.elapsed)
+}
 
-	// Schedule cache cleanup (remove stale entries after 5 minutes)
-	go func(key string) {
-		time.Sleep(5 * time.Minute)
-		e.marketCache.Delete(key)
-	}(cacheKey)
+// getMarketOdds retrieves all odds for the same event+market from the market store
+func (e *Engine) getMarketOdds(odds models.NormalizedOdds) []models.NormalizedOdds {
+	return e.marketStore.Get(odds)
 }
 
-// buildMarketKey creates a cache key for event+market
-func (e *Engine) buildMarketKey(odds models.NormalizedOdds) string {
-	return fmt.Sprintf("%s:%s", odds.EventID, odds.MarketKey)
+// updateMarketCache adds or updates odds in the market store
+func (e *Engine) updateMarketCache(odds models.NormalizedOdds) {
+	e.marketStore.Upsert(odds)
 }
 
 // incrementDetectedCount increments the detected opportunities counter
@@ -278,3 +354,28 @@ func (e *Engine) GetLatencyMetrics() (avgTotalMs, avgDetectionMs float64) {
 	return
 }
 
+// GetDetectorStats returns run, error, timeout and latency stats per opportunity type
+func (e *Engine) GetDetectorStats() map[models.OpportunityType]DetectorStats {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	result := make(map[models.OpportunityType]DetectorStats, len(e.detectorStats))
+	for opportunityType, stats := range e.detectorStats {
+		snapshot := *stats
+		if snapshot.Runs > 0 {
+			snapshot.AvgLatencyMs = float64(snapshot.totalLatency.Microseconds()) / 1000 / float64(snapshot.Runs)
+		}
+		result[opportunityType] = snapshot
+	}
+	return result
+}
+
+// GetMarketStoreStats returns market state store metrics
+func (e *Engine) GetMarketStoreStats() marketstate.Stats {
+	return e.marketStore.Stats()
+}
+
+// GetLifecycleStats returns open opportunity and lifecycle event counts
+func (e *Engine) GetLifecycleStats() lifecycle.Stats {
+	return e.tracker.Stats()
+}
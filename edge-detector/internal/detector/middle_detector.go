@@ -2,27 +2,40 @@ package detector
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/contracts"
 	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
 )
 
-// MiddleDetector detects middle opportunities (both sides of market have +EV)
+// MiddleDetector detects middles: opposite sides of a spread or total at different
+// lines, so both legs win if the final lands between them (Over 220.5 with Under
+// 223.5, or Team +4 with Opponent -2). EV comes from the sport's final margin or
+// total distribution centered on the sharp line.
 type MiddleDetector struct {
 	config            contracts.DetectorConfig
 	sharpBookProvider contracts.SharpBookProvider
+	now               func() time.Time
 }
 
 // NewMiddleDetector creates a new middle detector
 func NewMiddleDetector(config contracts.DetectorConfig, sharpBookProvider contracts.SharpBookProvider) *MiddleDetector {
+	return NewMiddleDetectorWithClock(config, sharpBookProvider, time.Now)
+}
+
+// NewMiddleDetectorWithClock creates a middle detector with an injected clock
+func NewMiddleDetectorWithClock(config contracts.DetectorConfig, sharpBookProvider contracts.SharpBookProvider, now func() time.Time) *MiddleDetector {
 	return &MiddleDetector{
 		config:            config,
 		sharpBookProvider: sharpBookProvider,
+		now:               now,
 	}
 }
 
 // Detect analyzes market odds and returns middle opportunities
+// A soft quote is paired with the opposite side at every other soft book; a sharp
+// quote moves the center, so every pair in the market is re-priced.
 func (d *MiddleDetector) Detect(ctx context.Context, odds models.NormalizedOdds, marketOdds []models.NormalizedOdds) ([]models.Opportunity, error) {
 	if !d.IsEnabled() {
 		return nil, nil
@@ -34,103 +47,57 @@ func (d *MiddleDetector) Detect(ctx context.Context, odds models.NormalizedOdds,
 	}
 
 	// Check data age
-	dataAge := time.Since(odds.ReceivedAt)
+	now := d.now()
+	dataAge := now.Sub(odds.ReceivedAt)
 	if int(dataAge.Seconds()) > d.config.GetMaxDataAgeSeconds() {
 		return nil, nil
 	}
 
-	// Get sharp consensus
-	sharpConsensus, err := d.sharpBookProvider.GetSharpConsensus(ctx, marketOdds)
-	if err != nil {
+	dist := d.config.GetScoreDistribution(odds.MarketKey)
+	if dist == nil {
 		return nil, nil
 	}
 
-	// Group market odds by outcome (e.g., "Over 223.5" and "Under 223.5")
-	// For a middle to exist, we need opposite sides of the same line to both have +EV
-	outcomeEdges := make(map[string][]middleCandidate)
-
+	// Soft quotes with a line are the candidate legs
+	var candidates []models.NormalizedOdds
 	for _, marketOdd := range marketOdds {
-		// Skip sharp books (we bet soft books)
-		if d.sharpBookProvider.IsSharpBook(marketOdd.BookKey) {
+		if marketOdd.Point == nil || marketOdd.DecimalOdds <= 1 || d.sharpBookProvider.IsSharpBookForMarket(marketOdd.BookKey, marketOdd.MarketKey) {
 			continue
 		}
+		candidates = append(candidates, marketOdd)
+	}
 
-		// Calculate edge for this odd
-		fairProb, exists := sharpConsensus[marketOdd.OutcomeName]
-		if !exists {
-			continue
-		}
+	// One outcome is the reference side; spread margins are measured from its perspective
+	reference := referenceOutcome(candidates)
+	triggerIsSharp := d.sharpBookProvider.IsSharpBookForMarket(odds.BookKey, odds.MarketKey)
 
-		edge := (fairProb / marketOdd.ImpliedProbability) - 1.0
+	var opportunities []models.Opportunity
+	for i := range candidates {
+		for j := i + 1; j < len(candidates); j++ {
+			a, b := candidates[i], candidates[j]
+			if a.OutcomeName == b.OutcomeName {
+				continue
+			}
+			if !triggerIsSharp && !sameQuote(a, odds) && !sameQuote(b, odds) {
+				continue // Pairs without the new quote were priced when their own quotes arrived
+			}
 
-		// Check if this has positive edge
-		if edge > 0 {
-			outcomeEdges[marketOdd.OutcomeName] = append(outcomeEdges[marketOdd.OutcomeName], middleCandidate{
-				odds: marketOdd,
-				edge: edge,
-			})
-		}
-	}
+			window, ok := newMiddleWindow(odds.MarketKey, reference, a, b)
+			if !ok {
+				continue // Same line is a hedge; crossed lines can lose both legs
+			}
 
-	// Look for middles: same point value with both sides having +EV
-	var opportunities []models.Opportunity
+			center, ok := sharpCenter(odds.MarketKey, reference, marketOdds, d.sharpBookProvider)
+			if !ok {
+				center = (window.low.line + window.high.line) / 2
+			}
 
-	// For spreads/totals, look for opposing outcomes
-	for outcome1, candidates1 := range outcomeEdges {
-		for _, cand1 := range candidates1 {
-			// Look for opposing side with same point
-			if cand1.odds.Point == nil {
+			eval := window.evaluate(dist, center)
+			if eval.ev < d.config.GetMinEdgePercent() {
 				continue
 			}
 
-			for outcome2, candidates2 := range outcomeEdges {
-				if outcome1 == outcome2 {
-					continue // Same outcome, not a middle
-				}
-
-				for _, cand2 := range candidates2 {
-					if cand2.odds.Point == nil {
-						continue
-					}
-
-					// Check if points match (or are close for a middle window)
-					if *cand1.odds.Point == *cand2.odds.Point {
-						// Both sides have +EV at the same line = MIDDLE
-						totalEdge := (cand1.edge + cand2.edge) / 2.0 * 100 // Average edge
-
-						if totalEdge >= d.config.GetMinEdgePercent()*100 {
-							opp := models.Opportunity{
-								OpportunityType: models.OpportunityTypeMiddle,
-								SportKey:        odds.SportKey,
-								EventID:         odds.EventID,
-								MarketKey:       odds.MarketKey,
-								EdgePercent:     totalEdge,
-								FairPrice:       nil, // No single fair price for middles
-								DetectedAt:      time.Now(),
-								DataAgeSeconds:  int(dataAge.Seconds()),
-								Legs: []models.OpportunityLeg{
-									{
-										BookKey:        cand1.odds.BookKey,
-										OutcomeName:    cand1.odds.OutcomeName,
-										Price:          cand1.odds.Price,
-										Point:          cand1.odds.Point,
-										LegEdgePercent: &[]float64{cand1.edge * 100}[0],
-									},
-									{
-										BookKey:        cand2.odds.BookKey,
-										OutcomeName:    cand2.odds.OutcomeName,
-										Price:          cand2.odds.Price,
-										Point:          cand2.odds.Point,
-										LegEdgePercent: &[]float64{cand2.edge * 100}[0],
-									},
-								},
-							}
-
-							opportunities = append(opportunities, opp)
-						}
-					}
-				}
-			}
+			opportunities = append(opportunities, middleOpportunity(odds, window, eval, now, dataAge))
 		}
 	}
 
@@ -147,9 +114,197 @@ func (d *MiddleDetector) IsEnabled() bool {
 	return d.config.IsMiddleDetectionEnabled()
 }
 
-// middleCandidate represents a potential leg of a middle opportunity
-type middleCandidate struct {
-	odds models.NormalizedOdds
-	edge float64
+// middleLeg is one side of a middle on the shared final-value axis
+// (the total, or the reference side's margin). The leg wins when the final is above
+// line (over) or below it (under), and pushes when it lands exactly on it.
+type middleLeg struct {
+	odds  models.NormalizedOdds
+	line  float64
+	over  bool
+	stake float64 // Fraction of the total stake; legs return the same when either wins
+}
+
+// result returns the leg's profit per unit staked and whether it pushed
+func (l middleLeg) result(final int) (float64, bool) {
+	value := float64(final)
+	switch {
+	case value == l.line:
+		return 0, true
+	case (value > l.line) == l.over:
+		return l.odds.DecimalOdds - 1, false
+	default:
+		return -1, false
+	}
+}
+
+// middleWindow is a pair of legs where both win when low.line < final < high.line
+type middleWindow struct {
+	low  middleLeg // Wins above its line
+	high middleLeg // Wins below its line
+}
+
+// middleEval is a middle's landing probabilities and EV per unit staked
+type middleEval struct {
+	bothWin float64
+	oneWin  float64
+	push    float64
+	ev      float64
+	legEV   [2]float64 // Each leg alone, per unit staked (low, high)
+}
+
+// newMiddleWindow orients two opposite-side quotes on the final-value axis
+// Totals use the total; spreads use the reference side's margin, so reference +p
+// wins above -p and the other side +q wins below q.
+func newMiddleWindow(marketKey, reference string, a, b models.NormalizedOdds) (middleWindow, bool) {
+	legA, okA := axisLeg(marketKey, reference, a)
+	legB, okB := axisLeg(marketKey, reference, b)
+	if !okA || !okB || legA.over == legB.over {
+		return middleWindow{}, false
+	}
+
+	window := middleWindow{low: legA, high: legB}
+	if !legA.over {
+		window = middleWindow{low: legB, high: legA}
+	}
+	if window.width() <= 0 {
+		return middleWindow{}, false
+	}
+
+	// Equal-return stakes: whichever leg wins alone pays back the same
+	dLow, dHigh := window.low.odds.DecimalOdds, window.high.odds.DecimalOdds
+	window.low.stake = dHigh / (dLow + dHigh)
+	window.high.stake = dLow / (dLow + dHigh)
+	return window, true
+}
+
+// axisLeg places a quote on the final-value axis
+func axisLeg(marketKey, reference string, odds models.NormalizedOdds) (middleLeg, bool) {
+	point := *odds.Point
+	if marketKey == "totals" {
+		switch odds.OutcomeName {
+		case "Over":
+			return middleLeg{odds: odds, line: point, over: true}, true
+		case "Under":
+			return middleLeg{odds: odds, line: point, over: false}, true
+		default:
+			return middleLeg{}, false
+		}
+	}
+
+	// Reference side covers when its margin + point > 0; the other side when margin < point
+	if odds.OutcomeName == reference {
+		return middleLeg{odds: odds, line: -point, over: true}, true
+	}
+	return middleLeg{odds: odds, line: point, over: false}, true
+}
+
+// width returns the points between the two lines
+func (w middleWindow) width() float64 {
+	return w.high.line - w.low.line
+}
+
+// evaluate sums both legs' results over every final value the distribution reaches
+func (w middleWindow) evaluate(dist contracts.ScoreDistribution, center float64) middleEval {
+	var eval middleEval
+
+	lo, hi := dist.Support(center)
+	for final := lo; final <= hi; final++ {
+		p := dist.Probability(final, center)
+		if p == 0 {
+			continue
+		}
+
+		lowProfit, lowPush := w.low.result(final)
+		highProfit, highPush := w.high.result(final)
+
+		switch {
+		case lowPush || highPush:
+			eval.push += p
+		case lowProfit > 0 && highProfit > 0:
+			eval.bothWin += p
+		case lowProfit > 0 || highProfit > 0:
+			eval.oneWin += p
+		}
+
+		eval.ev += p * (w.low.stake*lowProfit + w.high.stake*highProfit)
+		eval.legEV[0] += p * lowProfit
+		eval.legEV[1] += p * highProfit
+	}
+
+	return eval
 }
 
+// middleOpportunity builds the opportunity for a priced window
+func middleOpportunity(odds models.NormalizedOdds, window middleWindow, eval middleEval, detectedAt time.Time, dataAge time.Duration) models.Opportunity {
+	width := window.width()
+	legs := make([]models.OpportunityLeg, 0, 2)
+	for i, leg := range []middleLeg{window.low, window.high} {
+		legs = append(legs, models.OpportunityLeg{
+			BookKey:        leg.odds.BookKey,
+			OutcomeName:    leg.odds.OutcomeName,
+			Price:          leg.odds.Price,
+			PriceFormat:    leg.odds.PriceFormat,
+			PriceValue:     leg.odds.PriceValue,
+			Point:          leg.odds.Point,
+			LegEdgePercent: &[]float64{eval.legEV[i] * 100}[0],
+			MiddleWidth:    &width,
+		})
+	}
+
+	return models.Opportunity{
+		OpportunityType:   models.OpportunityTypeMiddle,
+		SportKey:          odds.SportKey,
+		EventID:           odds.EventID,
+		MarketKey:         odds.MarketKey,
+		EdgePercent:       eval.ev * 100,
+		FairPrice:         nil, // No single fair price for middles
+		DetectedAt:        detectedAt,
+		DataAgeSeconds:    int(dataAge.Seconds()),
+		MiddleBothWinProb: &eval.bothWin,
+		MiddleOneWinProb:  &eval.oneWin,
+		MiddlePushProb:    &eval.push,
+		Legs:              legs,
+	}
+}
+
+// sharpCenter returns the median final value implied by the sharp books' lines, weighted by sharpness
+func sharpCenter(marketKey, reference string, marketOdds []models.NormalizedOdds, sharpBookProvider contracts.SharpBookProvider) (float64, bool) {
+	var sum, weights float64
+	for _, marketOdd := range marketOdds {
+		if marketOdd.Point == nil {
+			continue
+		}
+		weight := sharpBookProvider.GetSharpWeight(marketOdd.BookKey, marketOdd.MarketKey)
+		if weight <= 0 {
+			continue
+		}
+		leg, ok := axisLeg(marketKey, reference, marketOdd)
+		if !ok {
+			continue
+		}
+		sum += weight * leg.line
+		weights += weight
+	}
+	if weights == 0 {
+		return 0, false
+	}
+	return sum / weights, true
+}
+
+// referenceOutcome picks the spread side margins are measured from (first by name)
+func referenceOutcome(candidates []models.NormalizedOdds) string {
+	names := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		names = append(names, candidate.OutcomeName)
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+	return names[0]
+}
+
+// sameQuote reports whether two quotes are the same book's line for the same outcome
+func sameQuote(a, b models.NormalizedOdds) bool {
+	return a.BookKey == b.BookKey && a.OutcomeName == b.OutcomeName && samePoint(a.Point, b.Point)
+}
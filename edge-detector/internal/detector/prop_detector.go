@@ -0,0 +1,180 @@
+package detector
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/contracts"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+	sharedodds "github.com/XavierBriggs/fortuna/services/shared/odds"
+)
+
+// propMarketPrefix marks player prop markets (player_points, player_threes, ...)
+const propMarketPrefix = "player_"
+
+// PropDetector detects +EV player props
+// Quotes are grouped by player (Description), stat (market) and point, so one player's
+// line is never priced off another's. The fair probability comes from sharp books
+// quoting both sides of the same player, stat and point, devigged per book and
+// averaged; a prop without a two-sided sharp price is skipped.
+type PropDetector struct {
+	config            contracts.DetectorConfig
+	sharpBookProvider contracts.SharpBookProvider
+	profiles          contracts.BookProfileProvider // nil prices every book as posted
+	now               func() time.Time
+}
+
+// NewPropDetector creates a new player prop detector
+func NewPropDetector(config contracts.DetectorConfig, sharpBookProvider contracts.SharpBookProvider, profiles contracts.BookProfileProvider) *PropDetector {
+	return NewPropDetectorWithClock(config, sharpBookProvider, profiles, time.Now)
+}
+
+// NewPropDetectorWithClock creates a player prop detector with an injected clock
+func NewPropDetectorWithClock(config contracts.DetectorConfig, sharpBookProvider contracts.SharpBookProvider, profiles contracts.BookProfileProvider, now func() time.Time) *PropDetector {
+	return &PropDetector{
+		config:            config,
+		sharpBookProvider: sharpBookProvider,
+		profiles:          profiles,
+		now:               now,
+	}
+}
+
+// Detect analyzes a soft prop quote and returns a player_prop opportunity if it beats the sharp price
+func (d *PropDetector) Detect(ctx context.Context, odds models.NormalizedOdds, marketOdds []models.NormalizedOdds) ([]models.Opportunity, error) {
+	if !d.IsEnabled() || !isPropMarket(odds.MarketKey) || odds.Description == "" {
+		return nil, nil
+	}
+
+	// Check data age
+	now := d.now()
+	dataAge := now.Sub(odds.ReceivedAt)
+	if int(dataAge.Seconds()) > d.config.GetMaxDataAgeSeconds() {
+		return nil, nil
+	}
+
+	// Only bet soft books; a sharp quote is the price, not the opportunity
+	if d.sharpBookProvider.IsSharpBookForMarket(odds.BookKey, odds.MarketKey) || odds.DecimalOdds <= 1 {
+		return nil, nil
+	}
+
+	fairProb, ok := d.sharpFairProbability(odds, marketOdds)
+	if !ok {
+		return nil, nil
+	}
+
+	// Price the bet at what a win nets after commission and tax
+	profile := bookProfile(d.profiles, odds.BookKey)
+	edge := CalculateEdge(fairProb, 1.0/profile.NetDecimal(odds.DecimalOdds))
+	if edge < d.config.GetMinEdgePercent() {
+		return nil, nil
+	}
+
+	fairPrice := sharedodds.DecimalToAmerican(1.0 / fairProb)
+
+	opportunity := models.Opportunity{
+		OpportunityType: models.OpportunityTypePlayerProp,
+		SportKey:        odds.SportKey,
+		EventID:         odds.EventID,
+		MarketKey:       odds.MarketKey,
+		EdgePercent:     edge * 100,
+		FairPrice:       &fairPrice,
+		DetectedAt:      now,
+		DataAgeSeconds:  int(dataAge.Seconds()),
+		MaxStake:        stakeLimit(profile.MaxStakeFor(odds.MarketKey)),
+		Legs: []models.OpportunityLeg{
+			withPlayer(models.OpportunityLeg{
+				BookKey:        odds.BookKey,
+				OutcomeName:    odds.OutcomeName,
+				Price:          odds.Price,
+				PriceFormat:    odds.PriceFormat,
+				PriceValue:     odds.PriceValue,
+				Point:          odds.Point,
+				LegEdgePercent: &[]float64{edge * 100}[0],
+			}, odds),
+		},
+	}
+
+	return []models.Opportunity{opportunity}, nil
+}
+
+// GetType returns the detector type
+func (d *PropDetector) GetType() models.OpportunityType {
+	return models.OpportunityTypePlayerProp
+}
+
+// IsEnabled returns whether player prop detection is enabled
+func (d *PropDetector) IsEnabled() bool {
+	return d.config.IsPlayerPropsEnabled()
+}
+
+// sharpFairProbability returns the no-vig probability of the quote's outcome
+// Each sharp book quoting exactly two outcomes for the player at the quote's point is
+// devigged multiplicatively; the books' probabilities are averaged by sharp weight.
+func (d *PropDetector) sharpFairProbability(odds models.NormalizedOdds, marketOdds []models.NormalizedOdds) (float64, bool) {
+	books := make(map[string]map[string]float64)
+	for _, quote := range marketOdds {
+		if quote.Description != odds.Description || !samePoint(quote.Point, odds.Point) ||
+			!d.sharpBookProvider.IsSharpBookForMarket(quote.BookKey, quote.MarketKey) || quote.DecimalOdds <= 1 {
+			continue
+		}
+		if books[quote.BookKey] == nil {
+			books[quote.BookKey] = make(map[string]float64)
+		}
+		books[quote.BookKey][quote.OutcomeName] = 1.0 / quote.DecimalOdds
+	}
+
+	sum, weights := 0.0, 0.0
+	for bookKey, outcomes := range books {
+		implied, exists := outcomes[odds.OutcomeName]
+		if !exists || len(outcomes) != 2 {
+			continue // One-sided (or malformed) quotes can't be devigged
+		}
+		total := 0.0
+		for _, p := range outcomes {
+			total += p
+		}
+		weight := d.sharpBookProvider.GetSharpWeight(bookKey, odds.MarketKey)
+		sum += weight * implied / total
+		weights += weight
+	}
+
+	if weights == 0 {
+		return 0, false
+	}
+	return sum / weights, true
+}
+
+// isPropMarket reports whether a market settles on one player's stat
+func isPropMarket(marketKey string) bool {
+	return strings.HasPrefix(marketKey, propMarketPrefix)
+}
+
+// propStat returns the stat a prop market settles on (player_points -> points)
+func propStat(marketKey string) string {
+	return strings.TrimPrefix(marketKey, propMarketPrefix)
+}
+
+// withPlayer sets a leg's player and stat when its quote is a player prop
+func withPlayer(leg models.OpportunityLeg, odds models.NormalizedOdds) models.OpportunityLeg {
+	if odds.Description == "" || !isPropMarket(odds.MarketKey) {
+		return leg
+	}
+	leg.PlayerName = odds.Description
+	leg.Stat = propStat(odds.MarketKey)
+	return leg
+}
+
+// marketEnabled reports whether detectors should look at a market: the configured
+// featured markets, plus player props when the sport enables them
+func marketEnabled(config contracts.DetectorConfig, marketKey string) bool {
+	if isPropMarket(marketKey) {
+		return config.IsPlayerPropsEnabled()
+	}
+	for _, m := range config.GetEnabledMarkets() {
+		if m == marketKey {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,169 @@
+package detector
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/contracts"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+)
+
+// DefaultDetectorTimeout bounds a detector's run on one message when the config doesn't
+const DefaultDetectorTimeout = 100 * time.Millisecond
+
+// Factory builds a detector for one sport's configuration
+type Factory func(config contracts.DetectorConfig, sharpBookProvider contracts.SharpBookProvider) contracts.OpportunityDetector
+
+// Registered is a detector built for a sport, with the timeout it runs under
+type Registered struct {
+	Detector contracts.OpportunityDetector
+	Timeout  time.Duration
+
+	config contracts.DetectorConfig // Sport config the detector was built from
+}
+
+// Enabled reports whether the detector runs for the next message
+// Checked per message, so detector_config can switch a detector on or off live.
+func (r Registered) Enabled() bool {
+	if r.config != nil && !r.config.IsDetectorEnabled(r.Detector.GetType()) {
+		return false
+	}
+	return r.Detector.IsEnabled()
+}
+
+// Registry holds detector factories by opportunity type and the detectors built
+// from them per sport. New detectors are added with Register; the Engine runs
+// whatever a sport has without knowing the types.
+type Registry struct {
+	factories map[models.OpportunityType]Factory
+	sports    map[string]map[models.OpportunityType]Registered
+	mu        sync.RWMutex
+}
+
+// NewRegistry creates an empty detector registry
+func NewRegistry() *Registry {
+	return &Registry{
+		factories: make(map[models.OpportunityType]Factory),
+		sports:    make(map[string]map[models.OpportunityType]Registered),
+	}
+}
+
+// NewDefaultRegistry creates a registry with the built-in edge, middle, scalp, stale line and player prop detectors
+// markets lets the scalp detector solve across an event's markets (nil disables that);
+// profiles prices edges, props and scalps net of each book's costs and limits (nil = as posted).
+func NewDefaultRegistry(markets contracts.MarketOddsProvider, profiles contracts.BookProfileProvider) *Registry {
+	return NewDefaultRegistryWithClock(markets, profiles, time.Now)
+}
+
+// NewDefaultRegistryWithClock creates the default registry with detectors on an injected clock
+// The backtest uses it so data age and detected_at follow replayed time.
+func NewDefaultRegistryWithClock(markets contracts.MarketOddsProvider, profiles contracts.BookProfileProvider, now func() time.Time) *Registry {
+	r := NewRegistry()
+	r.MustRegister(models.OpportunityTypeEdge, func(config contracts.DetectorConfig, sharp contracts.SharpBookProvider) contracts.OpportunityDetector {
+		return NewEdgeDetectorWithClock(config, sharp, profiles, now)
+	})
+	r.MustRegister(models.OpportunityTypeMiddle, func(config contracts.DetectorConfig, sharp contracts.SharpBookProvider) contracts.OpportunityDetector {
+		return NewMiddleDetectorWithClock(config, sharp, now)
+	})
+	r.MustRegister(models.OpportunityTypeScalp, func(config contracts.DetectorConfig, sharp contracts.SharpBookProvider) contracts.OpportunityDetector {
+		return NewScalpDetectorWithClock(config, markets, profiles, now)
+	})
+	r.MustRegister(models.OpportunityTypeStaleLine, func(config contracts.DetectorConfig, sharp contracts.SharpBookProvider) contracts.OpportunityDetector {
+		return NewStaleLineDetectorWithClock(config, sharp, now)
+	})
+	r.MustRegister(models.OpportunityTypePlayerProp, func(config contracts.DetectorConfig, sharp contracts.SharpBookProvider) contracts.OpportunityDetector {
+		return NewPropDetectorWithClock(config, sharp, profiles, now)
+	})
+	return r
+}
+
+// Register adds a detector factory for an opportunity type
+// Sports added afterwards get the detector; their config decides when it runs.
+func (r *Registry) Register(opportunityType models.OpportunityType, factory Factory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.factories[opportunityType]; exists {
+		return fmt.Errorf("detector already registered for opportunity type: %s", opportunityType)
+	}
+	r.factories[opportunityType] = factory
+	return nil
+}
+
+// MustRegister is Register for built-in detectors, panicking on duplicates
+func (r *Registry) MustRegister(opportunityType models.OpportunityType, factory Factory) {
+	if err := r.Register(opportunityType, factory); err != nil {
+		panic(err)
+	}
+}
+
+// AddSport builds every registered detector for a sport
+// Detectors the config has turned off are built too and skipped until it turns them
+// on (see Registered.Enabled). Returns the opportunity types built for the sport.
+func (r *Registry) AddSport(sportKey string, config contracts.DetectorConfig, sharpBookProvider contracts.SharpBookProvider) []models.OpportunityType {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	detectors := make(map[models.OpportunityType]Registered)
+	for opportunityType, factory := range r.factories {
+		timeout := time.Duration(config.GetDetectorTimeoutMs(opportunityType)) * time.Millisecond
+		if timeout <= 0 {
+			timeout = DefaultDetectorTimeout
+		}
+		detectors[opportunityType] = Registered{
+			Detector: factory(config, sharpBookProvider),
+			Timeout:  timeout,
+			config:   config,
+		}
+	}
+	r.sports[sportKey] = detectors
+
+	return sortedTypes(detectors)
+}
+
+// Get returns the detector for an opportunity type in a sport
+func (r *Registry) Get(sportKey string, opportunityType models.OpportunityType) (Registered, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	registered, exists := r.sports[sportKey][opportunityType]
+	return registered, exists
+}
+
+// ForSport returns a sport's detectors ordered by opportunity type
+func (r *Registry) ForSport(sportKey string) []Registered {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	detectors := r.sports[sportKey]
+	result := make([]Registered, 0, len(detectors))
+	for _, opportunityType := range sortedTypes(detectors) {
+		result = append(result, detectors[opportunityType])
+	}
+	return result
+}
+
+// Sports returns the sport keys with detectors, sorted
+func (r *Registry) Sports() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sports := make([]string, 0, len(r.sports))
+	for sportKey := range r.sports {
+		sports = append(sports, sportKey)
+	}
+	sort.Strings(sports)
+	return sports
+}
+
+// sortedTypes returns the opportunity types of a detector set in a stable order
+func sortedTypes(detectors map[models.OpportunityType]Registered) []models.OpportunityType {
+	types := make([]models.OpportunityType, 0, len(detectors))
+	for opportunityType := range detectors {
+		types = append(types, opportunityType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
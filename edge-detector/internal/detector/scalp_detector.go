@@ -3,190 +3,259 @@ package detector
 import (
 	"context"
 	"math"
+	"sort"
 	"time"
 
 	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/contracts"
 	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
 )
 
+// drawOutcome is the h2h outcome for a tied result
+const drawOutcome = "Draw"
+
+// resultMarkets settle on who wins the game: h2h, draw no bet, and a spread at 0
+// (which is draw no bet under another name). Scalps across them are found together.
+var resultMarkets = []string{"h2h", "draw_no_bet", "spreads"}
+
 // ScalpDetector detects scalp opportunities (guaranteed profit arbitrage)
+// Any N-way market is solved from the best price per outcome across books; result
+// markets are also solved against each other (spread 0 vs h2h, draw no bet vs h2h
+// with the draw).
+// Legs are priced at what each book nets after commission and tax, and the stakes
+// are sized to the books' limits and rounding.
 type ScalpDetector struct {
-	config contracts.DetectorConfig
+	config   contracts.DetectorConfig
+	markets  contracts.MarketOddsProvider  // Other markets of the event; nil disables cross-market scalps
+	profiles contracts.BookProfileProvider // nil prices every book as posted
+	now      func() time.Time
 }
 
 // NewScalpDetector creates a new scalp detector
-func NewScalpDetector(config contracts.DetectorConfig) *ScalpDetector {
+func NewScalpDetector(config contracts.DetectorConfig, markets contracts.MarketOddsProvider, profiles contracts.BookProfileProvider) *ScalpDetector {
+	return NewScalpDetectorWithClock(config, markets, profiles, time.Now)
+}
+
+// NewScalpDetectorWithClock creates a scalp detector with an injected clock
+func NewScalpDetectorWithClock(config contracts.DetectorConfig, markets contracts.MarketOddsProvider, profiles contracts.BookProfileProvider, now func() time.Time) *ScalpDetector {
 	return &ScalpDetector{
-		config: config,
+		config:   config,
+		markets:  markets,
+		profiles: profiles,
+		now:      now,
 	}
 }
 
 // Detect analyzes market odds and returns scalp opportunities
+// Returns at most one scalp: the best-returning set of legs that includes the new quote.
 func (d *ScalpDetector) Detect(ctx context.Context, odds models.NormalizedOdds, marketOdds []models.NormalizedOdds) ([]models.Opportunity, error) {
 	if !d.IsEnabled() {
 		return nil, nil
 	}
 
+	// Props only when the sport enables them
+	if isPropMarket(odds.MarketKey) && !d.config.IsPlayerPropsEnabled() {
+		return nil, nil
+	}
+
 	// Check data age
-	dataAge := time.Since(odds.ReceivedAt)
+	now := d.now()
+	dataAge := now.Sub(odds.ReceivedAt)
 	if int(dataAge.Seconds()) > d.config.GetMaxDataAgeSeconds() {
 		return nil, nil
 	}
 
-	// Group odds by outcome
-	outcomeOdds := make(map[string][]models.NormalizedOdds)
-	for _, marketOdd := range marketOdds {
-		outcomeOdds[marketOdd.OutcomeName] = append(outcomeOdds[marketOdd.OutcomeName], marketOdd)
+	var legs []arbLeg
+	var states int
+	if isResultQuote(odds) {
+		legs, states = d.resultLegs(odds, marketOdds)
+	} else {
+		legs, states = d.lineLegs(odds, marketOdds)
 	}
 
-	var opportunities []models.Opportunity
+	arb := bestArbitrage(odds, legs, states)
+	if arb == nil || !sizeArbitrage(arb, states) || arb.guaranteedReturn-1 < d.config.GetMinEdgePercent() {
+		return nil, nil
+	}
 
-	// For two-way markets, check all combinations
-	if len(outcomeOdds) == 2 {
-		var outcomes []string
-		var oddsLists [][]models.NormalizedOdds
+	return []models.Opportunity{scalpOpportunity(odds, arb, now, dataAge)}, nil
+}
 
-		for outcome, oddsList := range outcomeOdds {
-			outcomes = append(outcomes, outcome)
-			oddsLists = append(oddsLists, oddsList)
-		}
+// GetType returns the detector type
+func (d *ScalpDetector) GetType() models.OpportunityType {
+	return models.OpportunityTypeScalp
+}
 
-		// Check all combinations of odds from both sides
-		for _, odds1 := range oddsLists[0] {
-			for _, odds2 := range oddsLists[1] {
-				// Calculate inverse sum: 1/decimal1 + 1/decimal2
-				inverseSum := (1.0 / odds1.DecimalOdds) + (1.0 / odds2.DecimalOdds)
-
-				// Arbitrage exists if inverse sum < 1.0
-				if inverseSum < 1.0 {
-					// Calculate profit margin
-					profitMargin := (1.0 - inverseSum) * 100.0
-
-					// Check if profit meets minimum threshold
-					if profitMargin >= d.config.GetMinEdgePercent()*100 {
-						opp := models.Opportunity{
-							OpportunityType: models.OpportunityTypeScalp,
-							SportKey:        odds.SportKey,
-							EventID:         odds.EventID,
-							MarketKey:       odds.MarketKey,
-							EdgePercent:     profitMargin,
-							FairPrice:       nil, // No fair price for scalps (guaranteed profit)
-							DetectedAt:      time.Now(),
-							DataAgeSeconds:  int(dataAge.Seconds()),
-							Legs: []models.OpportunityLeg{
-								{
-									BookKey:        odds1.BookKey,
-									OutcomeName:    odds1.OutcomeName,
-									Price:          odds1.Price,
-									Point:          odds1.Point,
-									LegEdgePercent: &[]float64{profitMargin / 2.0}[0], // Split profit
-								},
-								{
-									BookKey:        odds2.BookKey,
-									OutcomeName:    odds2.OutcomeName,
-									Price:          odds2.Price,
-									Point:          odds2.Point,
-									LegEdgePercent: &[]float64{profitMargin / 2.0}[0], // Split profit
-								},
-							},
-						}
-
-						opportunities = append(opportunities, opp)
-					}
-				}
+// IsEnabled returns whether scalp detection is enabled
+func (d *ScalpDetector) IsEnabled() bool {
+	return d.config.IsScalpDetectionEnabled()
+}
+
+// resultLegs places every result-market quote for the event on home/away(/draw) states
+// Team states are ordered by name; the draw state exists only where games can tie.
+// Without draws, draw no bet and spread 0 settle exactly like h2h.
+func (d *ScalpDetector) resultLegs(odds models.NormalizedOdds, marketOdds []models.NormalizedOdds) ([]arbLeg, int) {
+	quotes := append([]models.NormalizedOdds{}, marketOdds...)
+	if d.markets != nil {
+		for _, marketKey := range resultMarkets {
+			if marketKey != odds.MarketKey {
+				quotes = append(quotes, d.markets.GetMarketOdds(odds.EventID, marketKey)...)
 			}
 		}
 	}
 
-	// For three-way markets (h2h with draw), check all combinations
-	if len(outcomeOdds) == 3 {
-		opportunities = append(opportunities, d.detectThreeWayScalps(odds, outcomeOdds, dataAge)...)
+	var teams []string
+	var usable []models.NormalizedOdds
+	for _, quote := range quotes {
+		if !isResultQuote(quote) || quote.DecimalOdds <= 1 {
+			continue
+		}
+		usable = append(usable, quote)
+		if quote.OutcomeName != drawOutcome && !containsString(teams, quote.OutcomeName) {
+			teams = append(teams, quote.OutcomeName)
+		}
+	}
+	if len(teams) != 2 {
+		return nil, 0
+	}
+	sort.Strings(teams)
+
+	states, draw := 2, -1
+	if d.config.IsDrawPossible() {
+		states, draw = 3, 2
 	}
 
-	return opportunities, nil
+	legs := make([]arbLeg, 0, len(usable))
+	for _, quote := range usable {
+		switch {
+		case quote.OutcomeName == drawOutcome:
+			if draw < 0 {
+				continue
+			}
+			legs = append(legs, d.leg(quote, draw, -1))
+		case quote.MarketKey == "h2h":
+			legs = append(legs, d.leg(quote, teamState(teams, quote.OutcomeName), -1))
+		default:
+			// Draw no bet / spread 0: refunded on a draw
+			legs = append(legs, d.leg(quote, teamState(teams, quote.OutcomeName), draw))
+		}
+	}
+	return legs, states
 }
 
-// detectThreeWayScalps finds arbitrage in three-way markets
-func (d *ScalpDetector) detectThreeWayScalps(odds models.NormalizedOdds, outcomeOdds map[string][]models.NormalizedOdds, dataAge time.Duration) []models.Opportunity {
-	var opportunities []models.Opportunity
+// lineLegs places the quotes at the trigger's line on one state per outcome
+// Spreads pair each side with the opposite point (Team -3.5 with Opponent +3.5);
+// over/under pairs share the point; props stay within the trigger's player.
+// Whole-number lines refund every leg on a push.
+func (d *ScalpDetector) lineLegs(odds models.NormalizedOdds, marketOdds []models.NormalizedOdds) ([]arbLeg, int) {
+	var sameLine []models.NormalizedOdds
+	for _, marketOdd := range marketOdds {
+		if marketOdd.Description == odds.Description && marketOdd.DecimalOdds > 1 {
+			sameLine = append(sameLine, marketOdd)
+		}
+	}
+
+	reference := referenceOutcome(sameLine)
+	line := lineKey(odds, reference)
 
-	// Get all outcomes
 	var outcomes []string
-	var oddsLists [][]models.NormalizedOdds
-
-	for outcome, oddsList := range outcomeOdds {
-		outcomes = append(outcomes, outcome)
-		oddsLists = append(oddsLists, oddsList)
-	}
-
-	if len(oddsLists) != 3 {
-		return opportunities
-	}
-
-	// Check all combinations of three odds
-	for _, odds1 := range oddsLists[0] {
-		for _, odds2 := range oddsLists[1] {
-			for _, odds3 := range oddsLists[2] {
-				// Calculate inverse sum for three-way
-				inverseSum := (1.0 / odds1.DecimalOdds) + (1.0 / odds2.DecimalOdds) + (1.0 / odds3.DecimalOdds)
-
-				// Arbitrage exists if inverse sum < 1.0
-				if inverseSum < 1.0 {
-					profitMargin := (1.0 - inverseSum) * 100.0
-
-					if profitMargin >= d.config.GetMinEdgePercent()*100 {
-						opp := models.Opportunity{
-							OpportunityType: models.OpportunityTypeScalp,
-							SportKey:        odds.SportKey,
-							EventID:         odds.EventID,
-							MarketKey:       odds.MarketKey,
-							EdgePercent:     profitMargin,
-							FairPrice:       nil,
-							DetectedAt:      time.Now(),
-							DataAgeSeconds:  int(dataAge.Seconds()),
-							Legs: []models.OpportunityLeg{
-								{
-									BookKey:        odds1.BookKey,
-									OutcomeName:    odds1.OutcomeName,
-									Price:          odds1.Price,
-									Point:          odds1.Point,
-									LegEdgePercent: &[]float64{profitMargin / 3.0}[0],
-								},
-								{
-									BookKey:        odds2.BookKey,
-									OutcomeName:    odds2.OutcomeName,
-									Price:          odds2.Price,
-									Point:          odds2.Point,
-									LegEdgePercent: &[]float64{profitMargin / 3.0}[0],
-								},
-								{
-									BookKey:        odds3.BookKey,
-									OutcomeName:    odds3.OutcomeName,
-									Price:          odds3.Price,
-									Point:          odds3.Point,
-									LegEdgePercent: &[]float64{profitMargin / 3.0}[0],
-								},
-							},
-						}
-
-						opportunities = append(opportunities, opp)
-					}
-				}
-			}
+	var legs []arbLeg
+	for _, marketOdd := range sameLine {
+		if (marketOdd.Point == nil) != (odds.Point == nil) || lineKey(marketOdd, reference) != line {
+			continue
 		}
+		if !containsString(outcomes, marketOdd.OutcomeName) {
+			outcomes = append(outcomes, marketOdd.OutcomeName)
+		}
+		legs = append(legs, d.leg(marketOdd, 0, -1))
 	}
+	sort.Strings(outcomes)
 
-	return opportunities
+	for i := range legs {
+		legs[i].win = teamState(outcomes, legs[i].odds.OutcomeName)
+	}
+	return legs, len(outcomes)
 }
 
-// GetType returns the detector type
-func (d *ScalpDetector) GetType() models.OpportunityType {
-	return models.OpportunityTypeScalp
+// leg places a quote on the states at its book's net price
+func (d *ScalpDetector) leg(odds models.NormalizedOdds, win, push int) arbLeg {
+	return newArbLeg(odds, bookProfile(d.profiles, odds.BookKey), win, push)
 }
 
-// IsEnabled returns whether scalp detection is enabled
-func (d *ScalpDetector) IsEnabled() bool {
-	return d.config.IsScalpDetectionEnabled()
+// lineKey returns the line a quote settles on, shared by both sides of the market
+func lineKey(odds models.NormalizedOdds, reference string) float64 {
+	if odds.Point == nil {
+		return 0
+	}
+	if odds.OutcomeName == "Over" || odds.OutcomeName == "Under" || odds.OutcomeName == reference {
+		return *odds.Point
+	}
+	return -*odds.Point
+}
+
+// isResultQuote reports whether a quote settles on the game result alone
+func isResultQuote(odds models.NormalizedOdds) bool {
+	if odds.Description != "" {
+		return false
+	}
+	switch odds.MarketKey {
+	case "h2h", "draw_no_bet":
+		return true
+	case "spreads":
+		return odds.Point != nil && *odds.Point == 0
+	default:
+		return false
+	}
+}
+
+// teamState returns the state index of an outcome in a sorted outcome list
+func teamState(outcomes []string, outcome string) int {
+	return sort.SearchStrings(outcomes, outcome)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// scalpOpportunity builds the opportunity for a solved arbitrage
+// Each leg's edge is its share of the guaranteed profit.
+func scalpOpportunity(odds models.NormalizedOdds, arb *arbitrage, detectedAt time.Time, dataAge time.Duration) models.Opportunity {
+	profit := arb.guaranteedReturn - 1
+	guaranteedReturn := arb.guaranteedReturn
+
+	legs := make([]models.OpportunityLeg, 0, len(arb.legs))
+	for i, leg := range arb.legs {
+		stake := arb.stakes[i]
+		legs = append(legs, withPlayer(models.OpportunityLeg{
+			BookKey:        leg.odds.BookKey,
+			MarketKey:      leg.odds.MarketKey,
+			OutcomeName:    leg.odds.OutcomeName,
+			Price:          leg.odds.Price,
+			PriceFormat:    leg.odds.PriceFormat,
+			PriceValue:     leg.odds.PriceValue,
+			Point:          leg.odds.Point,
+			LegEdgePercent: &[]float64{stake * profit * 100}[0],
+			StakeFraction:  &stake,
+		}, leg.odds))
+	}
+
+	return models.Opportunity{
+		OpportunityType:  models.OpportunityTypeScalp,
+		SportKey:         odds.SportKey,
+		EventID:          odds.EventID,
+		MarketKey:        odds.MarketKey,
+		EdgePercent:      profit * 100,
+		FairPrice:        nil, // No fair price for scalps (guaranteed profit)
+		DetectedAt:       detectedAt,
+		DataAgeSeconds:   int(dataAge.Seconds()),
+		GuaranteedReturn: &guaranteedReturn,
+		MaxStake:         stakeLimit(arb.maxStake),
+		Legs:             legs,
+	}
 }
 
 // CalculateArbitrage checks if odds create an arbitrage opportunity
@@ -236,7 +305,3 @@ func CalculateStakes(decimalOdds []float64, totalStake float64) []float64 {
 
 	return stakes
 }
-
-
-
-
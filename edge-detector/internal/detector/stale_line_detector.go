@@ -0,0 +1,321 @@
+package detector
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/contracts"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/shared/odds"
+)
+
+// StaleLineDetector detects soft quotes that haven't followed a significant sharp move
+// It keeps a short price history per sharp book and outcome (on vendor time). When a
+// sharp book moves at least the configured cents, soft books quoting the same outcome
+// and point whose last update predates the move are flagged, as long as they still
+// beat the sharp consensus.
+type StaleLineDetector struct {
+	config            contracts.DetectorConfig
+	sharpBookProvider contracts.SharpBookProvider
+	now               func() time.Time
+
+	mu        sync.Mutex
+	histories map[string]map[string]*sharpHistory // outcome key -> sharp book key -> history
+	lastSweep time.Time
+}
+
+// sharpPrice is one observed sharp price
+type sharpPrice struct {
+	price int
+	at    time.Time
+}
+
+// sharpHistory is a sharp book's recent prices for one outcome at one point
+type sharpHistory struct {
+	outcomeKey string
+	bookKey    string
+	point      *float64
+	prices     []sharpPrice // oldest first, consecutive duplicates dropped
+	lastSeen   time.Time    // Latest sharp quote, even when the price didn't change
+
+	// Soft books already flagged for the current move (book key -> move start)
+	flagged map[string]time.Time
+}
+
+// sharpMove is a sharp book's move within the window
+type sharpMove struct {
+	cents   int       // Signed, positive = shortened
+	startAt time.Time // First sharp quote at the new price
+	lastAt  time.Time // Latest sharp quote
+	history *sharpHistory
+}
+
+// NewStaleLineDetector creates a new stale line detector
+func NewStaleLineDetector(config contracts.DetectorConfig, sharpBookProvider contracts.SharpBookProvider) *StaleLineDetector {
+	return NewStaleLineDetectorWithClock(config, sharpBookProvider, time.Now)
+}
+
+// NewStaleLineDetectorWithClock creates a stale line detector with an injected clock
+// Only data age and detected_at use the clock; price history runs on vendor time.
+func NewStaleLineDetectorWithClock(config contracts.DetectorConfig, sharpBookProvider contracts.SharpBookProvider, now func() time.Time) *StaleLineDetector {
+	return &StaleLineDetector{
+		config:            config,
+		sharpBookProvider: sharpBookProvider,
+		now:               now,
+		histories:         make(map[string]map[string]*sharpHistory),
+	}
+}
+
+// Detect records sharp prices and returns stale soft quotes
+// A sharp quote checks every soft quote in the market; a soft quote is checked
+// against the largest recent sharp move on its outcome.
+func (d *StaleLineDetector) Detect(ctx context.Context, odds models.NormalizedOdds, marketOdds []models.NormalizedOdds) ([]models.Opportunity, error) {
+	if !d.IsEnabled() || !d.isMarketEnabled(odds.MarketKey) {
+		return nil, nil
+	}
+
+	// Check data age
+	now := d.now()
+	dataAge := now.Sub(odds.ReceivedAt)
+	if int(dataAge.Seconds()) > d.config.GetMaxDataAgeSeconds() {
+		return nil, nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.sweepIfDue(odds.VendorLastUpdate)
+
+	var moves []sharpMove
+	candidates := marketOdds
+	if d.sharpBookProvider.IsSharpBookForMarket(odds.BookKey, odds.MarketKey) {
+		if move, ok := d.move(d.record(odds)); ok {
+			moves = append(moves, move)
+		}
+	} else {
+		if move, ok := d.largestMove(odds); ok {
+			moves = append(moves, move)
+		}
+		candidates = []models.NormalizedOdds{odds}
+	}
+	if len(moves) == 0 {
+		return nil, nil
+	}
+
+	var opportunities []models.Opportunity
+	for _, move := range moves {
+		for _, soft := range candidates {
+			if opportunity, ok := d.staleOpportunity(ctx, move, soft, marketOdds, now, dataAge); ok {
+				opportunities = append(opportunities, opportunity)
+			}
+		}
+	}
+
+	return opportunities, nil
+}
+
+// staleOpportunity builds an opportunity if the soft quote missed the move (caller holds mu)
+func (d *StaleLineDetector) staleOpportunity(ctx context.Context, move sharpMove, soft models.NormalizedOdds, marketOdds []models.NormalizedOdds, detectedAt time.Time, dataAge time.Duration) (models.Opportunity, bool) {
+	if d.sharpBookProvider.IsSharpBookForMarket(soft.BookKey, soft.MarketKey) || staleOutcomeKey(soft) != move.history.outcomeKey ||
+		!samePoint(soft.Point, move.history.point) {
+		return models.Opportunity{}, false
+	}
+
+	// The soft book updated after the sharp move began, so it has had its chance to react
+	if !soft.VendorLastUpdate.Before(move.startAt) {
+		return models.Opportunity{}, false
+	}
+
+	// Already flagged for this move
+	if flaggedAt, ok := move.history.flagged[soft.BookKey]; ok && flaggedAt.Equal(move.startAt) {
+		return models.Opportunity{}, false
+	}
+
+	// Only worth betting while the stale price still beats the (moved) consensus at its point
+	fairProb, exists := lineConsensus(ctx, d.sharpBookProvider, soft, marketOdds)
+	if !exists || soft.ImpliedProbability <= 0 {
+		return models.Opportunity{}, false
+	}
+	edge := CalculateEdge(fairProb, soft.ImpliedProbability)
+	if edge <= 0 {
+		return models.Opportunity{}, false
+	}
+
+	move.history.flagged[soft.BookKey] = move.startAt
+
+	fairPrice := odds.DecimalToAmerican(1.0 / fairProb)
+	moveCents := abs(move.cents)
+	lagSeconds := int(move.lastAt.Sub(soft.VendorLastUpdate).Seconds())
+
+	return models.Opportunity{
+		OpportunityType: models.OpportunityTypeStaleLine,
+		SportKey:        soft.SportKey,
+		EventID:         soft.EventID,
+		MarketKey:       soft.MarketKey,
+		EdgePercent:     edge * 100,
+		FairPrice:       &fairPrice,
+		DetectedAt:      detectedAt,
+		DataAgeSeconds:  int(dataAge.Seconds()),
+		SharpMoveCents:  &moveCents,
+		LagSeconds:      &lagSeconds,
+		Legs: []models.OpportunityLeg{
+			{
+				BookKey:        soft.BookKey,
+				OutcomeName:    soft.OutcomeName,
+				Price:          soft.Price,
+				PriceFormat:    soft.PriceFormat,
+				PriceValue:     soft.PriceValue,
+				Point:          soft.Point,
+				LegEdgePercent: &[]float64{edge * 100}[0],
+			},
+		},
+	}, true
+}
+
+// record appends a sharp quote to its history (caller holds mu)
+func (d *StaleLineDetector) record(odds models.NormalizedOdds) *sharpHistory {
+	outcomeKey := staleOutcomeKey(odds)
+	if d.histories[outcomeKey] == nil {
+		d.histories[outcomeKey] = make(map[string]*sharpHistory)
+	}
+
+	h, ok := d.histories[outcomeKey][odds.BookKey]
+	if !ok || !samePoint(h.point, odds.Point) {
+		// A new point is a new line; cents are only comparable at the same point
+		h = &sharpHistory{
+			outcomeKey: outcomeKey,
+			bookKey:    odds.BookKey,
+			point:      copyPoint(odds.Point),
+			flagged:    make(map[string]time.Time),
+		}
+		d.histories[outcomeKey][odds.BookKey] = h
+	}
+
+	if n := len(h.prices); n == 0 || h.prices[n-1].price != odds.Price {
+		h.prices = append(h.prices, sharpPrice{price: odds.Price, at: odds.VendorLastUpdate})
+	}
+	if odds.VendorLastUpdate.After(h.lastSeen) {
+		h.lastSeen = odds.VendorLastUpdate
+	}
+
+	// Drop prices outside the window, always keeping the latest
+	cutoff := odds.VendorLastUpdate.Add(-d.window())
+	drop := 0
+	for drop < len(h.prices)-1 && h.prices[drop].at.Before(cutoff) {
+		drop++
+	}
+	h.prices = h.prices[drop:]
+
+	return h
+}
+
+// move returns the history's move if it meets the threshold (caller holds mu)
+func (d *StaleLineDetector) move(h *sharpHistory) (sharpMove, bool) {
+	if h == nil || len(h.prices) < 2 {
+		return sharpMove{}, false
+	}
+
+	cents := odds.PriceMoveCents(h.prices[0].price, h.prices[len(h.prices)-1].price)
+	if cents == 0 || abs(cents) < d.config.GetStaleLineMinMoveCents() {
+		return sharpMove{}, false
+	}
+
+	return sharpMove{
+		cents:   cents,
+		startAt: h.prices[1].at,
+		lastAt:  h.lastSeen,
+		history: h,
+	}, true
+}
+
+// largestMove returns the biggest recent sharp move on a soft quote's outcome and point (caller holds mu)
+func (d *StaleLineDetector) largestMove(odds models.NormalizedOdds) (sharpMove, bool) {
+	cutoff := odds.VendorLastUpdate.Add(-d.window())
+
+	var best sharpMove
+	found := false
+	for _, h := range d.histories[staleOutcomeKey(odds)] {
+		if !samePoint(h.point, odds.Point) {
+			continue
+		}
+		move, ok := d.move(h)
+		if !ok || move.lastAt.Before(cutoff) {
+			continue
+		}
+		if !found || abs(move.cents) > abs(best.cents) {
+			best, found = move, true
+		}
+	}
+	return best, found
+}
+
+// sweepIfDue drops histories idle longer than the window, at most once per window (caller holds mu)
+func (d *StaleLineDetector) sweepIfDue(now time.Time) {
+	window := d.window()
+	if now.Sub(d.lastSweep) < window {
+		return
+	}
+	d.lastSweep = now
+
+	cutoff := now.Add(-window)
+	for outcomeKey, books := range d.histories {
+		for bookKey, h := range books {
+			if h.lastSeen.Before(cutoff) {
+				delete(books, bookKey)
+			}
+		}
+		if len(books) == 0 {
+			delete(d.histories, outcomeKey)
+		}
+	}
+}
+
+// window returns the sharp price history window
+func (d *StaleLineDetector) window() time.Duration {
+	return time.Duration(d.config.GetStaleLineWindowSeconds()) * time.Second
+}
+
+// GetType returns the detector type
+func (d *StaleLineDetector) GetType() models.OpportunityType {
+	return models.OpportunityTypeStaleLine
+}
+
+// IsEnabled returns whether stale line detection is enabled
+func (d *StaleLineDetector) IsEnabled() bool {
+	return d.config.IsStaleLineDetectionEnabled()
+}
+
+// isMarketEnabled checks if a market is enabled in config
+// Player props are left to the prop detector (sharp consensus is keyed by outcome alone).
+func (d *StaleLineDetector) isMarketEnabled(marketKey string) bool {
+	return !isPropMarket(marketKey) && marketEnabled(d.config, marketKey)
+}
+
+// staleOutcomeKey identifies an outcome across books (event, market, player, outcome)
+func staleOutcomeKey(odds models.NormalizedOdds) string {
+	return fmt.Sprintf("%s:%s:%s:%s", odds.EventID, odds.MarketKey, odds.Description, odds.OutcomeName)
+}
+
+func samePoint(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func copyPoint(point *float64) *float64 {
+	if point == nil {
+		return nil
+	}
+	value := *point
+	return &value
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,436 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/metrics"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/writer"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+)
+
+// edgeTolerance is the smallest edge move published as an update (edge_pct is stored to 3 places)
+const edgeTolerance = 0.001
+
+// Config controls when open opportunities are closed
+type Config struct {
+	TTL           time.Duration // Opportunity expired if not re-detected within TTL
+	SweepInterval time.Duration // How often expired opportunities are swept
+}
+
+// DefaultConfig returns lifecycle defaults
+func DefaultConfig() Config {
+	return Config{
+		TTL:           5 * time.Minute,
+		SweepInterval: 30 * time.Second,
+	}
+}
+
+// Stats is a point-in-time snapshot of tracker metrics
+type Stats struct {
+	Open     int   `json:"open"`
+	Detected int64 `json:"detected"` // Opened
+	Updated  int64 `json:"updated"`  // Re-detected with a different edge or prices
+	Touched  int64 `json:"touched"`  // Re-detected unchanged
+	Gone     int64 `json:"gone"`     // Closed when a leg's quote updated
+	Expired  int64 `json:"expired"`  // Closed by TTL
+}
+
+// Store persists opportunity rows (writer.HolocronWriter in production)
+type Store interface {
+	UpsertOpportunity(ctx context.Context, opportunity models.Opportunity) (writer.UpsertResult, error)
+	TouchOpportunity(ctx context.Context, id int64, lastSeenAt time.Time) error
+	CloseOpportunity(ctx context.Context, id int64, closedAt time.Time, reason string) error
+	GetOpenOpportunities(ctx context.Context, sportKey string) ([]models.Opportunity, error)
+}
+
+// Publisher announces opportunities (publisher.StreamPublisher in production)
+type Publisher interface {
+	Publish(ctx context.Context, opportunity models.Opportunity) error
+	PublishEvent(ctx context.Context, event models.LifecycleEvent, opportunity models.Opportunity) error
+}
+
+// Change is a lifecycle event and the opportunity as stored after it
+type Change struct {
+	Event       models.LifecycleEvent
+	Opportunity models.Opportunity
+}
+
+// lockStripes is how many locks fingerprints are spread over
+const lockStripes = 64
+
+// Tracker keeps one stored row per open opportunity, keyed by fingerprint
+// Re-detections refresh the open row instead of inserting a new one; opportunities
+// that stop being detected are closed. Every change is published to opportunities.events,
+// and newly opened ones to opportunities.detected as before.
+type Tracker struct {
+	config    Config
+	store     Store
+	publisher Publisher
+	metrics   *metrics.Recorder
+	now       func() time.Time
+
+	// Held across a fingerprint's store write and publish so the sweep never closes
+	// an opportunity mid-update; fingerprints sharing a stripe wait on each other
+	locks [lockStripes]sync.Mutex
+
+	// Guards the open map and stats only, never held across I/O
+	mu    sync.Mutex
+	open  map[string]*models.Opportunity // fingerprint -> stored state
+	stats Stats
+}
+
+// NewTracker creates a lifecycle tracker; call Load before detection starts
+func NewTracker(config Config, store Store, publisher Publisher, recorder *metrics.Recorder) *Tracker {
+	return NewTrackerWithClock(config, store, publisher, recorder, time.Now)
+}
+
+// NewTrackerWithClock creates a lifecycle tracker with an injected clock
+// Expiry and gone closes are stamped with it; call Sweep directly when the clock isn't wall time.
+func NewTrackerWithClock(config Config, store Store, publisher Publisher, recorder *metrics.Recorder, now func() time.Time) *Tracker {
+	return &Tracker{
+		config:    config,
+		store:     store,
+		publisher: publisher,
+		metrics:   recorder,
+		now:       now,
+		open:      make(map[string]*models.Opportunity),
+	}
+}
+
+// Load picks up a sport's open opportunities from the store
+// Rows left open by a previous run are refreshed or closed like any other.
+func (t *Tracker) Load(ctx context.Context, sportKey string) (int, error) {
+	opportunities, err := t.store.GetOpenOpportunities(ctx, sportKey)
+	if err != nil {
+		return 0, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i := range opportunities {
+		t.open[opportunities[i].Fingerprint] = &opportunities[i]
+	}
+	return len(opportunities), nil
+}
+
+// Observe records one message's detection results
+// completed lists the opportunity types whose detectors ran to completion; an open
+// opportunity of one of those types with a leg on the trigger quote that wasn't
+// re-detected is closed as gone. Returns the changes that were stored and published.
+func (t *Tracker) Observe(ctx context.Context, trigger models.NormalizedOdds, opportunities []models.Opportunity, completed []models.OpportunityType) ([]Change, error) {
+	var changes []Change
+	var errs []error
+
+	seen := make(map[string]bool, len(opportunities))
+	for _, opportunity := range opportunities {
+		opportunity.Fingerprint = models.Fingerprint(opportunity)
+		if seen[opportunity.Fingerprint] {
+			continue // Two detectors can't share a type, but be safe
+		}
+		seen[opportunity.Fingerprint] = true
+
+		change, err := t.refresh(ctx, opportunity)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if change != nil {
+			changes = append(changes, *change)
+		}
+	}
+
+	isGone := func(opportunity models.Opportunity) bool {
+		return containsType(completed, opportunity.OpportunityType) && hasLeg(opportunity, trigger)
+	}
+	now := t.now()
+	for _, fingerprint := range t.openMatching(isGone) {
+		if seen[fingerprint] {
+			continue
+		}
+		change, err := t.close(ctx, fingerprint, now, models.CloseReasonGone, isGone)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if change != nil {
+			changes = append(changes, *change)
+		}
+	}
+
+	return changes, errors.Join(errs...)
+}
+
+// refresh stores a detected opportunity
+// Returns nil when it was already open with the same edge and prices.
+func (t *Tracker) refresh(ctx context.Context, opportunity models.Opportunity) (*Change, error) {
+	lock := t.lockFingerprint(opportunity.Fingerprint)
+	defer lock.Unlock()
+
+	existing, isOpen := t.lookup(opportunity.Fingerprint)
+	if isOpen && !changed(existing, opportunity) {
+		if err := t.store.TouchOpportunity(ctx, existing.ID, opportunity.DetectedAt); err != nil {
+			return nil, err
+		}
+
+		t.mu.Lock()
+		if stored, ok := t.open[opportunity.Fingerprint]; ok && opportunity.DetectedAt.After(stored.LastSeenAt) {
+			stored.LastSeenAt = opportunity.DetectedAt
+		}
+		t.stats.Touched++
+		t.mu.Unlock()
+		return nil, nil
+	}
+
+	opportunity.FirstSeenAt = opportunity.DetectedAt
+	opportunity.LastSeenAt = opportunity.DetectedAt
+	opportunity.PeakEdgePercent = opportunity.EdgePercent
+
+	result, err := t.store.UpsertOpportunity(ctx, opportunity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store opportunity: %w", err)
+	}
+	opportunity.ID = result.ID
+	opportunity.FirstSeenAt = result.FirstSeenAt
+	opportunity.DetectedAt = result.FirstSeenAt
+	opportunity.PeakEdgePercent = result.PeakEdgePercent
+
+	event := models.EventOpportunityUpdated
+	stored := opportunity
+	t.mu.Lock()
+	t.open[opportunity.Fingerprint] = &stored
+	if result.Inserted {
+		event = models.EventOpportunityDetected
+		t.stats.Detected++
+	} else {
+		t.stats.Updated++
+	}
+	t.mu.Unlock()
+
+	// Consumers of opportunities.detected see each opportunity once, when it opens
+	if result.Inserted {
+		if err := t.publisher.Publish(ctx, opportunity); err != nil {
+			return nil, fmt.Errorf("failed to publish to stream: %w", err)
+		}
+	}
+
+	if err := t.publisher.PublishEvent(ctx, event, opportunity); err != nil {
+		return nil, fmt.Errorf("failed to publish %s: %w", event, err)
+	}
+	t.metrics.Lifecycle(opportunity.SportKey, string(opportunity.OpportunityType), string(event))
+
+	return &Change{Event: event, Opportunity: opportunity}, nil
+}
+
+// close marks an open opportunity closed, forgets it and publishes opportunity.closed
+// shouldClose is checked again under the fingerprint's lock, so a concurrent
+// re-detection wins; nil is returned when it no longer applies. A failed write
+// leaves it open for the next sweep.
+func (t *Tracker) close(ctx context.Context, fingerprint string, closedAt time.Time, reason string, shouldClose func(models.Opportunity) bool) (*Change, error) {
+	lock := t.lockFingerprint(fingerprint)
+	defer lock.Unlock()
+
+	opportunity, isOpen := t.lookup(fingerprint)
+	if !isOpen || !shouldClose(opportunity) {
+		return nil, nil
+	}
+
+	if err := t.store.CloseOpportunity(ctx, opportunity.ID, closedAt, reason); err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	delete(t.open, fingerprint)
+	if reason == models.CloseReasonExpired {
+		t.stats.Expired++
+	} else {
+		t.stats.Gone++
+	}
+	t.mu.Unlock()
+
+	closed := opportunity
+	closed.ClosedAt = &closedAt
+	closed.CloseReason = reason
+
+	fmt.Printf("✗ Closed %s opportunity: event=%s market=%s peak_edge=%.2f%% open=%s reason=%s\n",
+		closed.OpportunityType, closed.EventID, closed.MarketKey, closed.PeakEdgePercent,
+		closedAt.Sub(closed.FirstSeenAt).Round(time.Second), reason)
+
+	if err := t.publisher.PublishEvent(ctx, models.EventOpportunityClosed, closed); err != nil {
+		return nil, fmt.Errorf("failed to publish %s: %w", models.EventOpportunityClosed, err)
+	}
+	t.metrics.Lifecycle(closed.SportKey, string(closed.OpportunityType), string(models.EventOpportunityClosed))
+
+	return &Change{Event: models.EventOpportunityClosed, Opportunity: closed}, nil
+}
+
+// Run expires opportunities not re-detected within the TTL until ctx is cancelled
+func (t *Tracker) Run(ctx context.Context) {
+	if t.config.TTL <= 0 || t.config.SweepInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(t.config.SweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.Sweep(ctx)
+		}
+	}
+}
+
+// Sweep closes every open opportunity last seen more than TTL ago
+func (t *Tracker) Sweep(ctx context.Context) int {
+	now := t.now()
+	cutoff := now.Add(-t.config.TTL)
+	isExpired := func(opportunity models.Opportunity) bool {
+		return opportunity.LastSeenAt.Before(cutoff)
+	}
+
+	expired := 0
+	for _, fingerprint := range t.openMatching(isExpired) {
+		change, err := t.close(ctx, fingerprint, now, models.CloseReasonExpired, isExpired)
+		if err != nil {
+			fmt.Printf("⚠️  Failed to expire opportunity %s: %v\n", fingerprint, err)
+			continue
+		}
+		if change != nil {
+			expired++
+		}
+	}
+	return expired
+}
+
+// lookup returns a copy of an open opportunity's stored state
+func (t *Tracker) lookup(fingerprint string) (models.Opportunity, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	opportunity, ok := t.open[fingerprint]
+	if !ok {
+		return models.Opportunity{}, false
+	}
+	return *opportunity, true
+}
+
+// openMatching returns the fingerprints of open opportunities that match
+func (t *Tracker) openMatching(match func(models.Opportunity) bool) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var fingerprints []string
+	for fingerprint, opportunity := range t.open {
+		if match(*opportunity) {
+			fingerprints = append(fingerprints, fingerprint)
+		}
+	}
+	return fingerprints
+}
+
+// lockFingerprint locks a fingerprint's stripe; the caller unlocks it
+func (t *Tracker) lockFingerprint(fingerprint string) *sync.Mutex {
+	hash := fnv.New32a()
+	hash.Write([]byte(fingerprint))
+	lock := &t.locks[hash.Sum32()%lockStripes]
+	lock.Lock()
+	return lock
+}
+
+// Stats returns a snapshot of tracker metrics
+func (t *Tracker) Stats() Stats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := t.stats
+	stats.Open = len(t.open)
+	return stats
+}
+
+// changed reports whether a re-detection differs from the stored opportunity
+// enough to publish an update
+func changed(stored, detected models.Opportunity) bool {
+	if math.Abs(stored.EdgePercent-detected.EdgePercent) >= edgeTolerance {
+		return true
+	}
+	if !sameFloat(stored.MaxStake, detected.MaxStake) || !sameFloat(stored.GuaranteedReturn, detected.GuaranteedReturn) {
+		return true
+	}
+
+	// A non-US quote can move without its rounded American price moving
+	prices := make(map[string]string, len(stored.Legs))
+	for _, leg := range stored.Legs {
+		prices[legKey(stored, leg)] = legPrice(leg)
+	}
+	for _, leg := range detected.Legs {
+		if price, ok := prices[legKey(detected, leg)]; !ok || price != legPrice(leg) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameFloat compares optional values to the precision Holocron stores
+func sameFloat(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return math.Abs(*a-*b) < 1e-4
+}
+
+// legPrice is a leg's price as quoted
+func legPrice(leg models.OpportunityLeg) string {
+	return fmt.Sprintf("%d|%s|%s", leg.Price, leg.PriceFormat, leg.PriceValue)
+}
+
+// legKey identifies a leg within its opportunity
+func legKey(opportunity models.Opportunity, leg models.OpportunityLeg) string {
+	point := "-"
+	if leg.Point != nil {
+		point = fmt.Sprintf("%g", *leg.Point)
+	}
+	return leg.BookKey + "|" + legMarket(opportunity, leg) + "|" + leg.PlayerName + "|" + leg.OutcomeName + "|" + point
+}
+
+// legMarket is the market a leg is quoted in; only cross-market scalps set it per leg
+func legMarket(opportunity models.Opportunity, leg models.OpportunityLeg) string {
+	if leg.MarketKey != "" {
+		return leg.MarketKey
+	}
+	return opportunity.MarketKey
+}
+
+// hasLeg reports whether the quote is one of the opportunity's legs, at the leg's point
+// Alternate lines share book, market and outcome, so a quote at another point isn't the leg.
+func hasLeg(opportunity models.Opportunity, quote models.NormalizedOdds) bool {
+	if opportunity.EventID != quote.EventID {
+		return false
+	}
+	for _, leg := range opportunity.Legs {
+		if leg.BookKey == quote.BookKey && leg.OutcomeName == quote.OutcomeName &&
+			legMarket(opportunity, leg) == quote.MarketKey && leg.PlayerName == quote.Description &&
+			sameFloat(leg.Point, quote.Point) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsType reports whether opportunityType is in types
+func containsType(types []models.OpportunityType, opportunityType models.OpportunityType) bool {
+	for _, t := range types {
+		if t == opportunityType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,236 @@
+package marketstate
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+)
+
+// Config controls market state retention
+type Config struct {
+	TTL                time.Duration // Market evicted if not updated within TTL
+	MaxMarkets         int           // Least recently updated markets evicted beyond this
+	MaxQuotesPerMarket int           // Upper bound on book×outcome quotes per market
+	SweepInterval      time.Duration // How often expired markets are swept
+}
+
+// DefaultConfig returns retention defaults sized for a full NBA slate with props
+func DefaultConfig() Config {
+	return Config{
+		TTL:                30 * time.Minute,
+		MaxMarkets:         50000,
+		MaxQuotesPerMarket: 2000,
+		SweepInterval:      time.Minute,
+	}
+}
+
+// Stats is a point-in-time snapshot of store metrics
+type Stats struct {
+	Markets        int   `json:"markets"`
+	Quotes         int   `json:"quotes"`
+	Hits           int64 `json:"hits"`
+	Misses         int64 `json:"misses"`
+	Upserts        int64 `json:"upserts"`
+	Expired        int64 `json:"expired"`         // Markets removed by TTL
+	Evicted        int64 `json:"evicted"`         // Markets removed by MaxMarkets
+	QuotesRejected int64 `json:"quotes_rejected"` // Quotes dropped by MaxQuotesPerMarket
+}
+
+// Store holds the latest quote per book+outcome for every event+market
+// Reads return copies so callers never share a slice with concurrent writers.
+type Store struct {
+	config Config
+	now    func() time.Time
+
+	mu      sync.Mutex
+	markets map[string]*list.Element // market key -> element in lru
+	lru     *list.List               // front = most recently updated
+
+	stats Stats
+}
+
+// marketEntry is the state of a single event+market
+type marketEntry struct {
+	key         string
+	quotes      []models.NormalizedOdds
+	index       map[string]int // quote key -> position in quotes
+	lastUpdated time.Time
+}
+
+// NewStore creates a market state store
+func NewStore(config Config) *Store {
+	return NewStoreWithClock(config, time.Now)
+}
+
+// NewStoreWithClock creates a market state store with an injected clock
+func NewStoreWithClock(config Config, now func() time.Time) *Store {
+	return &Store{
+		config:  config,
+		now:     now,
+		markets: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Get returns a copy of all quotes for the event+market of the given odds
+func (s *Store) Get(odds models.NormalizedOdds) []models.NormalizedOdds {
+	return s.GetMarketOdds(odds.EventID, odds.MarketKey)
+}
+
+// GetMarketOdds returns a copy of all quotes for an event's market
+func (s *Store) GetMarketOdds(eventID, marketKey string) []models.NormalizedOdds {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.markets[marketStoreKey(eventID, marketKey)]
+	if !ok || s.isExpired(elem.Value.(*marketEntry)) {
+		s.stats.Misses++
+		return []models.NormalizedOdds{}
+	}
+
+	s.stats.Hits++
+	entry := elem.Value.(*marketEntry)
+	quotes := make([]models.NormalizedOdds, len(entry.quotes))
+	copy(quotes, entry.quotes)
+	return quotes
+}
+
+// Upsert adds or replaces the quote for the odds' book+outcome
+func (s *Store) Upsert(odds models.NormalizedOdds) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := MarketKey(odds)
+	now := s.now()
+	s.stats.Upserts++
+
+	elem, ok := s.markets[key]
+	if !ok {
+		elem = s.lru.PushFront(&marketEntry{
+			key:   key,
+			index: make(map[string]int),
+		})
+		s.markets[key] = elem
+	} else {
+		s.lru.MoveToFront(elem)
+	}
+
+	entry := elem.Value.(*marketEntry)
+	entry.lastUpdated = now
+
+	quoteKey := QuoteKey(odds)
+	if i, exists := entry.index[quoteKey]; exists {
+		entry.quotes[i] = odds
+	} else if s.config.MaxQuotesPerMarket > 0 && len(entry.quotes) >= s.config.MaxQuotesPerMarket {
+		s.stats.QuotesRejected++
+	} else {
+		entry.index[quoteKey] = len(entry.quotes)
+		entry.quotes = append(entry.quotes, odds)
+	}
+
+	// Enforce market bound by evicting least recently updated
+	for s.config.MaxMarkets > 0 && s.lru.Len() > s.config.MaxMarkets {
+		s.remove(s.lru.Back())
+		s.stats.Evicted++
+	}
+}
+
+// Sweep removes every market whose TTL has elapsed
+// Returns the number of markets removed
+func (s *Store) Sweep() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := 0
+	// Oldest entries are at the back; stop at the first live one
+	for elem := s.lru.Back(); elem != nil; {
+		if !s.isExpired(elem.Value.(*marketEntry)) {
+			break
+		}
+		prev := elem.Prev()
+		s.remove(elem)
+		removed++
+		elem = prev
+	}
+
+	s.stats.Expired += int64(removed)
+	return removed
+}
+
+// Run sweeps expired markets on SweepInterval until ctx is cancelled
+func (s *Store) Run(ctx context.Context) {
+	interval := s.config.SweepInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Sweep()
+		}
+	}
+}
+
+// Stats returns a snapshot of store metrics
+func (s *Store) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := s.stats
+	stats.Markets = len(s.markets)
+	for _, elem := range s.markets {
+		stats.Quotes += len(elem.Value.(*marketEntry).quotes)
+	}
+	return stats
+}
+
+// isExpired checks an entry against the TTL (caller holds mu)
+func (s *Store) isExpired(entry *marketEntry) bool {
+	return s.config.TTL > 0 && s.now().Sub(entry.lastUpdated) > s.config.TTL
+}
+
+// remove deletes an element from the store (caller holds mu)
+func (s *Store) remove(elem *list.Element) {
+	entry := s.lru.Remove(elem).(*marketEntry)
+	delete(s.markets, entry.key)
+}
+
+// MarketKey creates the store key for event+market
+func MarketKey(odds models.NormalizedOdds) string {
+	return marketStoreKey(odds.EventID, odds.MarketKey)
+}
+
+func marketStoreKey(eventID, marketKey string) string {
+	return fmt.Sprintf("%s:%s", eventID, marketKey)
+}
+
+// QuoteKey identifies a quote within a market (latest price per book+outcome, per line
+// where a book posts several). Props include the player so "Over" for different players
+// doesn't collide. Main markets leave the point out so a moved line replaces the old one.
+func QuoteKey(odds models.NormalizedOdds) string {
+	key := fmt.Sprintf("%s:%s", odds.BookKey, odds.OutcomeName)
+	if odds.Description != "" {
+		key = fmt.Sprintf("%s:%s:%s", odds.BookKey, odds.Description, odds.OutcomeName)
+	}
+	if odds.Point != nil && hasLines(odds) {
+		key = fmt.Sprintf("%s:%g", key, *odds.Point)
+	}
+	return key
+}
+
+// hasLines reports whether a book quotes several points side by side in the market:
+// alternate lines and player props
+func hasLines(odds models.NormalizedOdds) bool {
+	return strings.Contains(odds.MarketKey, "alternate") || odds.Description != ""
+}
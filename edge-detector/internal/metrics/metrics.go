@@ -0,0 +1,278 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/consumer"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/marketstate"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "edge_detector"
+
+// scrapeTimeout bounds the Redis calls made while collecting stream gauges
+const scrapeTimeout = 2 * time.Second
+
+// latencyBuckets cover in-process detection up to multi-second pipeline backlogs
+var latencyBuckets = []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30}
+
+// Detector run outcomes, the result label on detector_runs_total
+const (
+	DetectorOK      = "ok"
+	DetectorError   = "error"
+	DetectorTimeout = "timeout"
+)
+
+// Recorder holds the edge detector's Prometheus collectors
+// A nil *Recorder is valid and records nothing.
+type Recorder struct {
+	registry *prometheus.Registry
+
+	processed         *prometheus.CounterVec
+	errors            *prometheus.CounterVec
+	opportunities     *prometheus.CounterVec
+	detectLatency     *prometheus.HistogramVec
+	endToEndLatency   *prometheus.HistogramVec
+	processingLatency *prometheus.HistogramVec
+	detectorRuns      *prometheus.CounterVec
+	detectorLatency   *prometheus.HistogramVec
+	lifecycleEvents   *prometheus.CounterVec
+}
+
+// MarketCacheFunc reports the market state store's current size
+type MarketCacheFunc func() marketstate.Stats
+
+// PendingFunc reports backlog and recovery counts for every consumed stream
+type PendingFunc func(ctx context.Context) ([]consumer.PendingStats, error)
+
+// NewRecorder creates a recorder with its own registry (plus Go and process collectors)
+func NewRecorder() *Recorder {
+	labels := []string{"sport", "stream"}
+	r := &Recorder{
+		registry: prometheus.NewRegistry(),
+		processed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "messages_processed_total",
+			Help:      "Normalized odds messages run through the detectors.",
+		}, labels),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "messages_errors_total",
+			Help:      "Normalized odds messages that failed processing.",
+		}, labels),
+		opportunities: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "opportunities_detected_total",
+			Help:      "Opportunities opened (first detected), by type.",
+		}, append(labels, "type")),
+		detectLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "detect_latency_seconds",
+			Help:      "Time from normalized_at to an opportunity's detected_at.",
+			Buckets:   latencyBuckets,
+		}, labels),
+		endToEndLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "end_to_end_latency_seconds",
+			Help:      "Time from received_at (ingestion) to an opportunity's detected_at.",
+			Buckets:   latencyBuckets,
+		}, labels),
+		processingLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "processing_latency_seconds",
+			Help:      "Time spent running the detectors on one message.",
+			Buckets:   latencyBuckets,
+		}, labels),
+		detectorRuns: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "detector_runs_total",
+			Help:      "Detector runs by opportunity type and result (ok, error, timeout).",
+		}, []string{"sport", "type", "result"}),
+		detectorLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "detector_latency_seconds",
+			Help:      "Time one detector spent on one message (capped by its timeout).",
+			Buckets:   latencyBuckets,
+		}, []string{"sport", "type"}),
+		lifecycleEvents: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "opportunity_events_total",
+			Help:      "Opportunity lifecycle events (opportunity.detected, .updated, .closed) by type.",
+		}, []string{"sport", "type", "event"}),
+	}
+
+	r.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		r.processed,
+		r.errors,
+		r.opportunities,
+		r.detectLatency,
+		r.endToEndLatency,
+		r.processingLatency,
+		r.detectorRuns,
+		r.detectorLatency,
+		r.lifecycleEvents,
+	)
+	return r
+}
+
+// Registry returns the registry served at /metrics
+func (r *Recorder) Registry() *prometheus.Registry {
+	return r.registry
+}
+
+// Processed counts a message and observes how long the detectors took on it
+func (r *Recorder) Processed(streamKey string, elapsed time.Duration) {
+	if r == nil {
+		return
+	}
+	sport := sportFromStream(streamKey)
+	r.processed.WithLabelValues(sport, streamKey).Inc()
+	r.processingLatency.WithLabelValues(sport, streamKey).Observe(elapsed.Seconds())
+}
+
+// Error counts a message that failed processing
+func (r *Recorder) Error(streamKey string) {
+	if r == nil {
+		return
+	}
+	r.errors.WithLabelValues(sportFromStream(streamKey), streamKey).Inc()
+}
+
+// DetectorRun counts one detector run and observes how long it took
+func (r *Recorder) DetectorRun(sportKey, opportunityType, result string, elapsed time.Duration) {
+	if r == nil {
+		return
+	}
+	r.detectorRuns.WithLabelValues(sportKey, opportunityType, result).Inc()
+	r.detectorLatency.WithLabelValues(sportKey, opportunityType).Observe(elapsed.Seconds())
+}
+
+// Detected counts an opportunity and observes the pipeline latency of the quote behind it
+func (r *Recorder) Detected(streamKey string, odds models.NormalizedOdds, opportunity models.Opportunity) {
+	if r == nil {
+		return
+	}
+	sport := sportFromStream(streamKey)
+	r.opportunities.WithLabelValues(sport, streamKey, string(opportunity.OpportunityType)).Inc()
+	observeSince(r.detectLatency.WithLabelValues(sport, streamKey), odds.NormalizedAt, opportunity.DetectedAt)
+	observeSince(r.endToEndLatency.WithLabelValues(sport, streamKey), odds.ReceivedAt, opportunity.DetectedAt)
+}
+
+// Lifecycle counts an opportunity lifecycle event
+func (r *Recorder) Lifecycle(sportKey, opportunityType, event string) {
+	if r == nil {
+		return
+	}
+	r.lifecycleEvents.WithLabelValues(sportKey, opportunityType, event).Inc()
+}
+
+// observeSince records end - start, skipping missing or out-of-order timestamps
+func observeSince(observer prometheus.Observer, start, end time.Time) {
+	if start.IsZero() || end.Before(start) {
+		return
+	}
+	observer.Observe(end.Sub(start).Seconds())
+}
+
+// WatchMarketCache exports the market state store's size, read on every scrape
+func (r *Recorder) WatchMarketCache(stats MarketCacheFunc) {
+	r.registry.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "market_cache_markets",
+			Help:      "Event+market groups held in the market state store.",
+		}, func() float64 { return float64(stats().Markets) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "market_cache_quotes",
+			Help:      "Book quotes held across all cached markets.",
+		}, func() float64 { return float64(stats().Quotes) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "market_cache_expired_total",
+			Help:      "Markets dropped after their TTL.",
+		}, func() float64 { return float64(stats().Expired) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "market_cache_evicted_total",
+			Help:      "Markets evicted to stay under the market cap.",
+		}, func() float64 { return float64(stats().Evicted) }),
+	)
+}
+
+// WatchOpenOpportunities exports how many opportunities are open, read on every scrape
+func (r *Recorder) WatchOpenOpportunities(open func() int) {
+	r.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "opportunities_open",
+		Help:      "Opportunities detected and not yet closed.",
+	}, func() float64 { return float64(open()) }))
+}
+
+// WatchStreams exports consumer group lag and pending counts, read on every scrape
+func (r *Recorder) WatchStreams(pending PendingFunc) {
+	r.registry.MustRegister(newStreamCollector(pending))
+}
+
+// streamCollector reads consumer group state from Redis when scraped
+type streamCollector struct {
+	pending PendingFunc
+
+	lag          *prometheus.Desc
+	pendingCount *prometheus.Desc
+	claimed      *prometheus.Desc
+	deadLettered *prometheus.Desc
+}
+
+func newStreamCollector(pending PendingFunc) *streamCollector {
+	labels := []string{"sport", "stream"}
+	return &streamCollector{
+		pending: pending,
+		lag: prometheus.NewDesc(prometheus.BuildFQName(namespace, "stream", "lag"),
+			"Entries not yet delivered to the consumer group (-1 if unknown).", labels, nil),
+		pendingCount: prometheus.NewDesc(prometheus.BuildFQName(namespace, "stream", "pending"),
+			"Entries delivered to the consumer group but not acknowledged.", labels, nil),
+		claimed: prometheus.NewDesc(prometheus.BuildFQName(namespace, "stream", "claimed_total"),
+			"Idle entries reclaimed by this consumer.", labels, nil),
+		deadLettered: prometheus.NewDesc(prometheus.BuildFQName(namespace, "stream", "dead_lettered_total"),
+			"Entries dead-lettered by recovery after MaxDeliveries.", labels, nil),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *streamCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.lag
+	ch <- c.pendingCount
+	ch <- c.claimed
+	ch <- c.deadLettered
+}
+
+// Collect implements prometheus.Collector
+// Streams read before a Redis error are still exported.
+func (c *streamCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), scrapeTimeout)
+	defer cancel()
+
+	stats, _ := c.pending(ctx)
+	for _, stream := range stats {
+		sport := sportFromStream(stream.Stream)
+		ch <- prometheus.MustNewConstMetric(c.lag, prometheus.GaugeValue, float64(stream.Lag), sport, stream.Stream)
+		ch <- prometheus.MustNewConstMetric(c.pendingCount, prometheus.GaugeValue, float64(stream.Pending), sport, stream.Stream)
+		ch <- prometheus.MustNewConstMetric(c.claimed, prometheus.CounterValue, float64(stream.Claimed), sport, stream.Stream)
+		ch <- prometheus.MustNewConstMetric(c.deadLettered, prometheus.CounterValue, float64(stream.DeadLettered), sport, stream.Stream)
+	}
+}
+
+// sportFromStream extracts the sport key from odds.normalized.{sport}
+func sportFromStream(streamKey string) string {
+	if i := strings.LastIndex(streamKey, "."); i >= 0 {
+		return streamKey[i+1:]
+	}
+	return streamKey
+}
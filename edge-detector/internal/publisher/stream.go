@@ -86,6 +86,33 @@ func (p *StreamPublisher) PublishToGlobalStream(ctx context.Context, opportunity
 	return nil
 }
 
+// PublishEvent publishes a lifecycle event to opportunities.events and opportunities.events.{sport}
+// Entries carry the event name and the opportunity as stored after the change.
+func (p *StreamPublisher) PublishEvent(ctx context.Context, event models.LifecycleEvent, opportunity models.Opportunity) error {
+	opportunityJSON, err := json.Marshal(opportunity)
+	if err != nil {
+		return fmt.Errorf("failed to marshal opportunity: %w", err)
+	}
+
+	values := map[string]interface{}{
+		"event":       string(event),
+		"opportunity": string(opportunityJSON),
+	}
+
+	for _, streamKey := range []string{"opportunities.events", fmt.Sprintf("opportunities.events.%s", opportunity.SportKey)} {
+		_, err = p.client.XAdd(ctx, &redis.XAddArgs{
+			Stream: streamKey,
+			Values: values,
+		}).Result()
+
+		if err != nil {
+			return fmt.Errorf("failed to publish to stream %s: %w", streamKey, err)
+		}
+	}
+
+	return nil
+}
+
 // Publish is the main publish method that publishes to both sport-specific and global streams
 func (p *StreamPublisher) Publish(ctx context.Context, opportunity models.Opportunity) error {
 	// Publish to sport-specific stream
@@ -0,0 +1,250 @@
+package settings
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/contracts"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+	"github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+)
+
+// Channel is the Redis pub/sub channel the API gateway notifies on after a detector_config change
+const Channel = "detector.config.updated"
+
+// Reloader keeps sport configs in line with Holocron detector_config
+// Changes are picked up when the API gateway publishes on Channel, and on every
+// refresh in case a notification was missed.
+type Reloader struct {
+	db              *sql.DB
+	redisClient     *redis.Client // nil reloads on the refresh interval only
+	refreshInterval time.Duration
+
+	mu       sync.Mutex
+	configs  map[string]contracts.ReloadableConfig // sport_key -> config
+	versions map[string]int64                      // sport_key -> applied version (missing = environment defaults)
+}
+
+// NewReloader creates a config reloader; Add each sport, then Load before detection starts
+func NewReloader(db *sql.DB, redisClient *redis.Client, refreshInterval time.Duration) *Reloader {
+	return &Reloader{
+		db:              db,
+		redisClient:     redisClient,
+		refreshInterval: refreshInterval,
+		configs:         make(map[string]contracts.ReloadableConfig),
+		versions:        make(map[string]int64),
+	}
+}
+
+// Add registers a sport's config to keep current
+func (r *Reloader) Add(sportKey string, config contracts.ReloadableConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.configs[sportKey] = config
+}
+
+// Load applies every registered sport's detector_config row
+// Sports without a row keep (or go back to) their environment defaults; a row that
+// fails validation is skipped and the sport keeps its current thresholds.
+func (r *Reloader) Load(ctx context.Context) error {
+	r.mu.Lock()
+	sportKeys := make([]string, 0, len(r.configs))
+	for sportKey := range r.configs {
+		sportKeys = append(sportKeys, sportKey)
+	}
+	r.mu.Unlock()
+
+	rows, err := r.query(ctx, sportKeys)
+	if err != nil {
+		return err
+	}
+
+	for _, sportKey := range sportKeys {
+		r.apply(sportKey, rows[sportKey])
+	}
+	return nil
+}
+
+// Reload applies one sport's detector_config row
+func (r *Reloader) Reload(ctx context.Context, sportKey string) error {
+	r.mu.Lock()
+	_, ok := r.configs[sportKey]
+	r.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	rows, err := r.query(ctx, []string{sportKey})
+	if err != nil {
+		return err
+	}
+	r.apply(sportKey, rows[sportKey])
+	return nil
+}
+
+// Run applies notified changes and reloads every refresh interval until ctx is cancelled
+// A failed reload keeps the current thresholds.
+func (r *Reloader) Run(ctx context.Context) {
+	interval := r.refreshInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var notices <-chan *redis.Message
+	if r.redisClient != nil {
+		pubsub := r.redisClient.Subscribe(ctx, Channel)
+		defer pubsub.Close()
+		notices = pubsub.Channel()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case message, ok := <-notices:
+			if !ok {
+				notices = nil
+				continue
+			}
+			var notice models.DetectorConfigNotice
+			if err := json.Unmarshal([]byte(message.Payload), &notice); err != nil {
+				fmt.Printf("⚠️  Invalid detector config notice: %v\n", err)
+				continue
+			}
+			if err := r.Reload(ctx, notice.SportKey); err != nil {
+				fmt.Printf("⚠️  Failed to reload detector config for %s: %v\n", notice.SportKey, err)
+			}
+		case <-ticker.C:
+			if err := r.Load(ctx); err != nil {
+				fmt.Printf("⚠️  Failed to reload detector config: %v\n", err)
+			}
+		}
+	}
+}
+
+// apply hands a sport its row (nil = no row) unless that version is already applied
+func (r *Reloader) apply(sportKey string, settings *models.DetectorSettings) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	config := r.configs[sportKey]
+	applied, hasApplied := r.versions[sportKey]
+
+	if settings == nil {
+		if hasApplied {
+			config.ApplySettings(nil)
+			delete(r.versions, sportKey)
+			fmt.Printf("✓ Detector config for %s removed, using environment defaults\n", sportKey)
+		}
+		return
+	}
+
+	if hasApplied && applied == settings.Version {
+		return
+	}
+	if err := settings.Validate(); err != nil {
+		fmt.Printf("⚠️  Ignoring detector config for %s v%d: %v\n", sportKey, settings.Version, err)
+		return
+	}
+
+	config.ApplySettings(settings)
+	r.versions[sportKey] = settings.Version
+	fmt.Printf("✓ Detector config applied: %s v%d by %s (min_edge=%.2f%%, max_age=%ds, markets=%v)\n",
+		sportKey, settings.Version, settings.UpdatedBy,
+		config.GetMinEdgePercent()*100, config.GetMaxDataAgeSeconds(), config.GetEnabledMarkets())
+}
+
+// query reads the detector_config rows for the given sports, by sport
+func (r *Reloader) query(ctx context.Context, sportKeys []string) (map[string]*models.DetectorSettings, error) {
+	query := `
+		SELECT sport_key, min_edge_pct, max_data_age_seconds, enabled_markets,
+		       stale_line_min_move_cents, stale_line_window_seconds,
+		       enable_middles, enable_scalps, enable_stale_lines, enable_player_props,
+		       version, updated_by, updated_at
+		FROM detector_config
+		WHERE sport_key = ANY($1)
+	`
+
+	queryCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(queryCtx, query, pq.Array(sportKeys))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query detector config: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[string]*models.DetectorSettings)
+	for rows.Next() {
+		var settings models.DetectorSettings
+		var minEdgePct sql.NullFloat64
+		var maxDataAge, minMoveCents, windowSeconds sql.NullInt64
+		var enableMiddles, enableScalps, enableStaleLines, enablePlayerProps sql.NullBool
+		var enabledMarkets pq.StringArray
+
+		if err := rows.Scan(
+			&settings.SportKey,
+			&minEdgePct,
+			&maxDataAge,
+			&enabledMarkets,
+			&minMoveCents,
+			&windowSeconds,
+			&enableMiddles,
+			&enableScalps,
+			&enableStaleLines,
+			&enablePlayerProps,
+			&settings.Version,
+			&settings.UpdatedBy,
+			&settings.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan detector config: %w", err)
+		}
+
+		if minEdgePct.Valid {
+			settings.MinEdgePct = &minEdgePct.Float64
+		}
+		settings.MaxDataAgeSeconds = nullInt(maxDataAge)
+		settings.StaleLineMinMoveCents = nullInt(minMoveCents)
+		settings.StaleLineWindowSeconds = nullInt(windowSeconds)
+		if enabledMarkets != nil {
+			settings.EnabledMarkets = []string(enabledMarkets)
+		}
+		settings.EnableMiddles = nullBool(enableMiddles)
+		settings.EnableScalps = nullBool(enableScalps)
+		settings.EnableStaleLines = nullBool(enableStaleLines)
+		settings.EnablePlayerProps = nullBool(enablePlayerProps)
+
+		result[settings.SportKey] = &settings
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating detector config: %w", err)
+	}
+
+	return result, nil
+}
+
+// nullInt converts a nullable column to an optional int
+func nullInt(value sql.NullInt64) *int {
+	if !value.Valid {
+		return nil
+	}
+	result := int(value.Int64)
+	return &result
+}
+
+// nullBool converts a nullable column to an optional bool
+func nullBool(value sql.NullBool) *bool {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Bool
+}
@@ -0,0 +1,97 @@
+package sharpness
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Player props are left out: closing_lines has no player to match a prop's close on.
+const marketFilter = `market_key NOT LIKE 'player\_%'`
+
+// LoadClosingLines reads closing lines for a sport's events starting in [since, until)
+func LoadClosingLines(ctx context.Context, db *sql.DB, sportKey string, since, until time.Time) ([]Line, error) {
+	query := `
+		SELECT c.event_id, c.market_key, c.book_key, c.outcome_name,
+		       c.closing_price, c.point, c.closed_at, e.commence_time
+		FROM closing_lines c
+		JOIN events e ON e.event_id = c.event_id
+		WHERE e.sport_key = $1
+		  AND e.commence_time >= $2
+		  AND e.commence_time < $3
+		  AND c.` + marketFilter
+
+	rows, err := db.QueryContext(ctx, query, sportKey, since, until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query closing lines: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []Line
+	for rows.Next() {
+		var line Line
+		var point sql.NullFloat64
+		if err := rows.Scan(
+			&line.EventID, &line.MarketKey, &line.BookKey, &line.OutcomeName,
+			&line.Price, &point, &line.At, &line.CommenceTime,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan closing line: %w", err)
+		}
+		if point.Valid {
+			line.Point = &point.Float64
+		}
+		lines = append(lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating closing lines: %w", err)
+	}
+
+	return lines, nil
+}
+
+// StreamPrices calls fn with every pre-close odds_raw price for a sport's events starting
+// in [since, until), ordered by event, market, book and vendor_last_update as Scorer.Add needs
+// Returns the number of rows read.
+func StreamPrices(ctx context.Context, db *sql.DB, sportKey string, since, until time.Time, fn func(Line)) (int, error) {
+	query := `
+		SELECT o.event_id, o.market_key, o.book_key, o.outcome_name,
+		       o.price, o.point, o.vendor_last_update, e.commence_time
+		FROM odds_raw o
+		JOIN events e ON e.event_id = o.event_id
+		WHERE e.sport_key = $1
+		  AND e.commence_time >= $2
+		  AND e.commence_time < $3
+		  AND o.vendor_last_update < e.commence_time
+		  AND o.` + marketFilter + `
+		ORDER BY o.event_id, o.market_key, o.book_key, o.vendor_last_update, o.id
+	`
+
+	rows, err := db.QueryContext(ctx, query, sportKey, since, until)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query odds_raw: %w", err)
+	}
+	defer rows.Close()
+
+	count := 0
+	for rows.Next() {
+		var line Line
+		var point sql.NullFloat64
+		if err := rows.Scan(
+			&line.EventID, &line.MarketKey, &line.BookKey, &line.OutcomeName,
+			&line.Price, &point, &line.At, &line.CommenceTime,
+		); err != nil {
+			return count, fmt.Errorf("failed to scan odds_raw: %w", err)
+		}
+		if point.Valid {
+			line.Point = &point.Float64
+		}
+		fn(line)
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, fmt.Errorf("error iterating odds_raw: %w", err)
+	}
+
+	return count, nil
+}
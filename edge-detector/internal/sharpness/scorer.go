@@ -0,0 +1,363 @@
+package sharpness
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/shared/odds"
+)
+
+// Config tunes how books are scored against the closing consensus
+type Config struct {
+	MinSamples        int     // Scored price snapshots a book needs before it can be sharp
+	MinConsensusBooks int     // Other books' closing lines needed to score a price
+	SharpScore        float64 // Minimum score (0 = average book) for the sharp set
+}
+
+// DefaultConfig returns the default scoring config
+func DefaultConfig() Config {
+	return Config{
+		MinSamples:        200,
+		MinConsensusBooks: 3,
+		SharpScore:        0.15,
+	}
+}
+
+// Line is one outcome price: a book's closing line or a pre-close price from odds_raw
+type Line struct {
+	EventID      string
+	MarketKey    string
+	BookKey      string
+	OutcomeName  string
+	Price        int // American odds
+	Point        *float64
+	At           time.Time // vendor_last_update (closed_at for closing lines)
+	CommenceTime time.Time
+}
+
+// Scorer measures each book's pre-close prices against the closing consensus
+// The consensus for an outcome is the average no-vig closing probability of every
+// other book, so a book is never graded against its own close. Prices at a point the
+// market didn't close on have nothing to compare with and are skipped.
+type Scorer struct {
+	config    Config
+	consensus map[string]*closingMarket // event|market -> closing consensus
+	tallies   map[string]*tally         // market|book -> errors
+
+	// The price series being read (one event, market and book)
+	seriesKey string
+	seriesAt  time.Time
+	latest    map[string]Line // outcome -> latest price
+	opened    bool            // The series' opening price was scored
+
+	windowStart time.Time
+	windowEnd   time.Time
+}
+
+// closingMarket is one event market's no-vig closing probabilities by book
+type closingMarket struct {
+	outcomes int                           // Outcomes a complete line quotes
+	points   map[string]map[string]float64 // outcome|point -> book -> no-vig probability
+}
+
+// tally accumulates one book's errors in one market
+type tally struct {
+	marketKey       string
+	bookKey         string
+	events          int
+	samples         int
+	errorSum        float64
+	openings        int
+	openingErrorSum float64
+}
+
+// NewScorer builds the closing consensus from every book's closing lines
+// Books whose closing line is incomplete (missing an outcome or at mismatched points)
+// are left out of the consensus.
+func NewScorer(config Config, closing []Line) *Scorer {
+	byBook := make(map[string][]Line)
+	outcomes := make(map[string]map[string]bool)
+	for _, line := range closing {
+		market := line.EventID + "|" + line.MarketKey
+		byBook[market+"|"+line.BookKey] = append(byBook[market+"|"+line.BookKey], line)
+		if outcomes[market] == nil {
+			outcomes[market] = make(map[string]bool)
+		}
+		outcomes[market][line.OutcomeName] = true
+	}
+
+	consensus := make(map[string]*closingMarket)
+	for _, lines := range byBook {
+		market := lines[0].EventID + "|" + lines[0].MarketKey
+		if !completeLine(lines, len(outcomes[market])) {
+			continue
+		}
+
+		closingMkt := consensus[market]
+		if closingMkt == nil {
+			closingMkt = &closingMarket{
+				outcomes: len(outcomes[market]),
+				points:   make(map[string]map[string]float64),
+			}
+			consensus[market] = closingMkt
+		}
+		for outcome, prob := range devig(lines) {
+			key := pointKey(outcome, findLine(lines, outcome).Point)
+			if closingMkt.points[key] == nil {
+				closingMkt.points[key] = make(map[string]float64)
+			}
+			closingMkt.points[key][lines[0].BookKey] = prob
+		}
+	}
+
+	return &Scorer{
+		config:    config,
+		consensus: consensus,
+		tallies:   make(map[string]*tally),
+	}
+}
+
+// Markets returns how many event markets have a closing consensus
+func (s *Scorer) Markets() int {
+	return len(s.consensus)
+}
+
+// Add scores a pre-close price
+// Prices must arrive ordered by event, market, book and vendor_last_update; prices
+// sharing a timestamp are one snapshot of the book's line.
+func (s *Scorer) Add(line Line) {
+	key := line.EventID + "|" + line.MarketKey + "|" + line.BookKey
+	switch {
+	case key != s.seriesKey:
+		s.scoreSnapshot()
+		s.seriesKey = key
+		s.seriesAt = line.At
+		s.latest = make(map[string]Line)
+		s.opened = false
+	case !line.At.Equal(s.seriesAt):
+		s.scoreSnapshot()
+		s.seriesAt = line.At
+	}
+	s.latest[line.OutcomeName] = line
+}
+
+// Scores finishes the run and returns every scored book per market
+// score = 1 - error / average book error, averaging the opening and intraday ratios.
+// Sharp books (score and samples over the thresholds) are weighted by the square of how
+// much more accurate than average their prices are, normalized to average 1 per market.
+func (s *Scorer) Scores(sportKey string, scoredAt time.Time) []models.BookSharpness {
+	s.scoreSnapshot()
+	s.seriesKey = ""
+	s.latest = nil
+
+	byMarket := make(map[string][]*tally)
+	for _, t := range s.tallies {
+		byMarket[t.marketKey] = append(byMarket[t.marketKey], t)
+	}
+
+	var scores []models.BookSharpness
+	for marketKey, tallies := range byMarket {
+		fieldIntraday, fieldOpening := fieldErrors(tallies)
+
+		marketScores := make([]models.BookSharpness, 0, len(tallies))
+		weightSum, sharpCount := 0.0, 0
+		for _, t := range tallies {
+			intraday := t.errorSum / float64(t.samples)
+			sharpness := models.BookSharpness{
+				SportKey:      sportKey,
+				MarketKey:     marketKey,
+				BookKey:       t.bookKey,
+				Events:        t.events,
+				Samples:       t.samples,
+				IntradayError: &intraday,
+				WindowStart:   s.windowStart,
+				WindowEnd:     s.windowEnd,
+				ScoredAt:      scoredAt,
+			}
+
+			relative := intraday / fieldIntraday
+			if t.openings > 0 && fieldOpening > 0 {
+				opening := t.openingErrorSum / float64(t.openings)
+				sharpness.OpeningError = &opening
+				relative = 0.5*relative + 0.5*opening/fieldOpening
+			}
+			sharpness.Score = 1 - relative
+			sharpness.IsSharp = t.samples >= s.config.MinSamples && sharpness.Score >= s.config.SharpScore
+
+			if sharpness.IsSharp {
+				sharpness.Weight = math.Pow(fieldIntraday/math.Max(intraday, 1e-6), 2)
+				weightSum += sharpness.Weight
+				sharpCount++
+			}
+			marketScores = append(marketScores, sharpness)
+		}
+
+		for i := range marketScores {
+			if marketScores[i].IsSharp {
+				marketScores[i].Weight /= weightSum / float64(sharpCount)
+			}
+		}
+		scores = append(scores, marketScores...)
+	}
+
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].MarketKey != scores[j].MarketKey {
+			return scores[i].MarketKey < scores[j].MarketKey
+		}
+		return scores[i].BookKey < scores[j].BookKey
+	})
+	return scores
+}
+
+// scoreSnapshot compares the current series' latest line to the closing consensus
+func (s *Scorer) scoreSnapshot() {
+	if s.seriesKey == "" || len(s.latest) == 0 {
+		return
+	}
+
+	var lines []Line
+	for _, line := range s.latest {
+		lines = append(lines, line)
+	}
+	first := lines[0]
+
+	closingMkt := s.consensus[first.EventID+"|"+first.MarketKey]
+	if closingMkt == nil || !completeLine(lines, closingMkt.outcomes) {
+		return
+	}
+
+	errorSum := 0.0
+	for outcome, prob := range devig(lines) {
+		consensus, ok := closingMkt.without(pointKey(outcome, findLine(lines, outcome).Point), first.BookKey, s.config.MinConsensusBooks)
+		if !ok {
+			return
+		}
+		errorSum += math.Abs(prob - consensus)
+	}
+	sampleError := errorSum / float64(len(lines))
+
+	key := first.MarketKey + "|" + first.BookKey
+	t := s.tallies[key]
+	if t == nil {
+		t = &tally{marketKey: first.MarketKey, bookKey: first.BookKey}
+		s.tallies[key] = t
+	}
+	t.samples++
+	t.errorSum += sampleError
+	if !s.opened {
+		s.opened = true
+		t.events++
+		t.openings++
+		t.openingErrorSum += sampleError
+	}
+
+	if s.windowStart.IsZero() || first.CommenceTime.Before(s.windowStart) {
+		s.windowStart = first.CommenceTime
+	}
+	if first.CommenceTime.After(s.windowEnd) {
+		s.windowEnd = first.CommenceTime
+	}
+}
+
+// without returns the average closing probability of every book but bookKey
+func (m *closingMarket) without(key, bookKey string, minBooks int) (float64, bool) {
+	sum, count := 0.0, 0
+	for book, prob := range m.points[key] {
+		if book == bookKey {
+			continue
+		}
+		sum += prob
+		count++
+	}
+	if count == 0 || count < minBooks {
+		return 0, false
+	}
+	return sum / float64(count), true
+}
+
+// fieldErrors returns the average book's intraday and opening errors in a market
+func fieldErrors(tallies []*tally) (intraday, opening float64) {
+	openingBooks := 0
+	for _, t := range tallies {
+		intraday += t.errorSum / float64(t.samples)
+		if t.openings > 0 {
+			opening += t.openingErrorSum / float64(t.openings)
+			openingBooks++
+		}
+	}
+	intraday /= float64(len(tallies))
+	if openingBooks > 0 {
+		opening /= float64(openingBooks)
+	}
+	return math.Max(intraday, 1e-6), opening
+}
+
+// completeLine reports whether a book's line quotes every outcome once at consistent points:
+// no points (h2h), one shared point (totals) or opposite points (spreads)
+func completeLine(lines []Line, outcomes int) bool {
+	if len(lines) != outcomes || outcomes < 2 {
+		return false
+	}
+
+	seen := make(map[string]bool)
+	pointSum := 0.0
+	for _, line := range lines {
+		if seen[line.OutcomeName] || line.Price == 0 {
+			return false
+		}
+		seen[line.OutcomeName] = true
+
+		if (line.Point == nil) != (lines[0].Point == nil) {
+			return false
+		}
+		if line.Point == nil {
+			continue
+		}
+		pointSum += *line.Point
+		if !strings.Contains(line.MarketKey, "spread") && *line.Point != *lines[0].Point {
+			return false
+		}
+	}
+
+	if lines[0].Point != nil && strings.Contains(lines[0].MarketKey, "spread") {
+		return len(lines) == 2 && math.Abs(pointSum) < 1e-9
+	}
+	return true
+}
+
+// devig returns each outcome's multiplicative no-vig probability
+func devig(lines []Line) map[string]float64 {
+	implied := make(map[string]float64, len(lines))
+	total := 0.0
+	for _, line := range lines {
+		p := 1.0 / odds.AmericanToDecimal(line.Price)
+		implied[line.OutcomeName] = p
+		total += p
+	}
+	for outcome, p := range implied {
+		implied[outcome] = p / total
+	}
+	return implied
+}
+
+// findLine returns the line for an outcome
+func findLine(lines []Line, outcome string) Line {
+	for _, line := range lines {
+		if line.OutcomeName == outcome {
+			return line
+		}
+	}
+	return Line{}
+}
+
+// pointKey identifies an outcome at a point
+func pointKey(outcome string, point *float64) string {
+	if point == nil {
+		return outcome
+	}
+	return fmt.Sprintf("%s|%g", outcome, *point)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
 )
@@ -20,7 +21,65 @@ func NewHolocronWriter(db *sql.DB) *HolocronWriter {
 	}
 }
 
-// WriteOpportunity writes an opportunity and its legs to Holocron
+// UpsertResult is the stored state of an upserted opportunity
+type UpsertResult struct {
+	ID              int64
+	Inserted        bool      // A new row; false when an open row with the fingerprint was updated
+	FirstSeenAt     time.Time // Kept from the open row on update
+	PeakEdgePercent float64   // Highest edge across the open row's lifetime
+}
+
+// opportunityInsert is the column list and values shared by WriteOpportunity and UpsertOpportunity
+const opportunityInsert = `
+	INSERT INTO opportunities (
+		opportunity_type, sport_key, event_id, market_key,
+		edge_pct, fair_price, detected_at, data_age_seconds,
+		sharp_move_cents, lag_seconds,
+		middle_both_win_prob, middle_one_win_prob, middle_push_prob,
+		guaranteed_return, max_stake,
+		fingerprint, first_seen_at, last_seen_at, peak_edge_pct
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+`
+
+// opportunityArgs returns the values for opportunityInsert
+func opportunityArgs(opportunity models.Opportunity) []interface{} {
+	firstSeen := opportunity.FirstSeenAt
+	if firstSeen.IsZero() {
+		firstSeen = opportunity.DetectedAt
+	}
+	lastSeen := opportunity.LastSeenAt
+	if lastSeen.IsZero() {
+		lastSeen = opportunity.DetectedAt
+	}
+	peak := opportunity.PeakEdgePercent
+	if peak < opportunity.EdgePercent {
+		peak = opportunity.EdgePercent
+	}
+
+	return []interface{}{
+		string(opportunity.OpportunityType),
+		opportunity.SportKey,
+		opportunity.EventID,
+		opportunity.MarketKey,
+		opportunity.EdgePercent,
+		opportunity.FairPrice,
+		opportunity.DetectedAt,
+		opportunity.DataAgeSeconds,
+		opportunity.SharpMoveCents,
+		opportunity.LagSeconds,
+		opportunity.MiddleBothWinProb,
+		opportunity.MiddleOneWinProb,
+		opportunity.MiddlePushProb,
+		opportunity.GuaranteedReturn,
+		opportunity.MaxStake,
+		sql.NullString{String: opportunity.Fingerprint, Valid: opportunity.Fingerprint != ""},
+		firstSeen,
+		lastSeen,
+		peak,
+	}
+}
+
+// WriteOpportunity writes an opportunity and its legs to Holocron as a new row
 // Returns the opportunity ID on success
 func (w *HolocronWriter) WriteOpportunity(ctx context.Context, opportunity models.Opportunity) (int64, error) {
 	// Start transaction
@@ -31,41 +90,115 @@ func (w *HolocronWriter) WriteOpportunity(ctx context.Context, opportunity model
 	defer tx.Rollback() // Rollback if commit doesn't happen
 
 	// Insert opportunity
-	opportunityQuery := `
-		INSERT INTO opportunities (
-			opportunity_type, sport_key, event_id, market_key,
-			edge_pct, fair_price, detected_at, data_age_seconds
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		RETURNING id
+	var opportunityID int64
+	err = tx.QueryRowContext(ctx, opportunityInsert+" RETURNING id", opportunityArgs(opportunity)...).Scan(&opportunityID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert opportunity: %w", err)
+	}
+
+	if err := insertLegs(ctx, tx, opportunityID, opportunity.Legs); err != nil {
+		return 0, err
+	}
+
+	// Commit transaction
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return opportunityID, nil
+}
+
+// UpsertOpportunity inserts an opportunity or refreshes the open row with its fingerprint
+// On update the edge, prices and type-specific fields are replaced, last_seen_at moves
+// forward, peak_edge_pct keeps the maximum and the legs are rewritten.
+func (w *HolocronWriter) UpsertOpportunity(ctx context.Context, opportunity models.Opportunity) (UpsertResult, error) {
+	tx, err := w.db.BeginTx(ctx, nil)
+	if err != nil {
+		return UpsertResult{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := opportunityInsert + `
+		ON CONFLICT (fingerprint) WHERE closed_at IS NULL DO UPDATE SET
+			edge_pct = EXCLUDED.edge_pct,
+			fair_price = EXCLUDED.fair_price,
+			data_age_seconds = EXCLUDED.data_age_seconds,
+			sharp_move_cents = EXCLUDED.sharp_move_cents,
+			lag_seconds = EXCLUDED.lag_seconds,
+			middle_both_win_prob = EXCLUDED.middle_both_win_prob,
+			middle_one_win_prob = EXCLUDED.middle_one_win_prob,
+			middle_push_prob = EXCLUDED.middle_push_prob,
+			guaranteed_return = EXCLUDED.guaranteed_return,
+			max_stake = EXCLUDED.max_stake,
+			last_seen_at = GREATEST(opportunities.last_seen_at, EXCLUDED.last_seen_at),
+			peak_edge_pct = GREATEST(opportunities.peak_edge_pct, EXCLUDED.peak_edge_pct)
+		RETURNING id, (xmax = 0) AS inserted, first_seen_at, peak_edge_pct
 	`
 
-	var opportunityID int64
-	err = tx.QueryRowContext(
-		ctx,
-		opportunityQuery,
-		string(opportunity.OpportunityType),
-		opportunity.SportKey,
-		opportunity.EventID,
-		opportunity.MarketKey,
-		opportunity.EdgePercent,
-		opportunity.FairPrice,
-		opportunity.DetectedAt,
-		opportunity.DataAgeSeconds,
-	).Scan(&opportunityID)
+	var result UpsertResult
+	err = tx.QueryRowContext(ctx, query, opportunityArgs(opportunity)...).Scan(
+		&result.ID,
+		&result.Inserted,
+		&result.FirstSeenAt,
+		&result.PeakEdgePercent,
+	)
+	if err != nil {
+		return UpsertResult{}, fmt.Errorf("failed to upsert opportunity: %w", err)
+	}
+
+	if !result.Inserted {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM opportunity_legs WHERE opportunity_id = $1`, result.ID); err != nil {
+			return UpsertResult{}, fmt.Errorf("failed to replace opportunity legs: %w", err)
+		}
+	}
+	if err := insertLegs(ctx, tx, result.ID, opportunity.Legs); err != nil {
+		return UpsertResult{}, err
+	}
 
+	if err = tx.Commit(); err != nil {
+		return UpsertResult{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return result, nil
+}
+
+// TouchOpportunity moves an open opportunity's last_seen_at forward
+func (w *HolocronWriter) TouchOpportunity(ctx context.Context, id int64, lastSeenAt time.Time) error {
+	_, err := w.db.ExecContext(ctx, `
+		UPDATE opportunities
+		SET last_seen_at = GREATEST(last_seen_at, $2)
+		WHERE id = $1 AND closed_at IS NULL
+	`, id, lastSeenAt)
 	if err != nil {
-		return 0, fmt.Errorf("failed to insert opportunity: %w", err)
+		return fmt.Errorf("failed to touch opportunity %d: %w", id, err)
 	}
+	return nil
+}
 
-	// Insert opportunity legs
+// CloseOpportunity marks an open opportunity closed
+func (w *HolocronWriter) CloseOpportunity(ctx context.Context, id int64, closedAt time.Time, reason string) error {
+	_, err := w.db.ExecContext(ctx, `
+		UPDATE opportunities
+		SET closed_at = $2, close_reason = $3
+		WHERE id = $1 AND closed_at IS NULL
+	`, id, closedAt, reason)
+	if err != nil {
+		return fmt.Errorf("failed to close opportunity %d: %w", id, err)
+	}
+	return nil
+}
+
+// insertLegs writes an opportunity's legs inside its transaction
+func insertLegs(ctx context.Context, tx *sql.Tx, opportunityID int64, legs []models.OpportunityLeg) error {
 	legQuery := `
 		INSERT INTO opportunity_legs (
-			opportunity_id, book_key, outcome_name, price, point, leg_edge_pct
-		) VALUES ($1, $2, $3, $4, $5, $6)
+			opportunity_id, book_key, outcome_name, price, point, leg_edge_pct, middle_width,
+			market_key, stake_fraction, player_name, stat, price_format, price_value
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 	`
 
-	for _, leg := range opportunity.Legs {
-		_, err = tx.ExecContext(
+	for _, leg := range legs {
+		_, err := tx.ExecContext(
 			ctx,
 			legQuery,
 			opportunityID,
@@ -74,19 +207,21 @@ func (w *HolocronWriter) WriteOpportunity(ctx context.Context, opportunity model
 			leg.Price,
 			leg.Point,
 			leg.LegEdgePercent,
+			leg.MiddleWidth,
+			sql.NullString{String: leg.MarketKey, Valid: leg.MarketKey != ""},
+			leg.StakeFraction,
+			sql.NullString{String: leg.PlayerName, Valid: leg.PlayerName != ""},
+			sql.NullString{String: leg.Stat, Valid: leg.Stat != ""},
+			sql.NullString{String: leg.PriceFormat, Valid: leg.PriceFormat != ""},
+			sql.NullString{String: leg.PriceValue, Valid: leg.PriceValue != ""},
 		)
 
 		if err != nil {
-			return 0, fmt.Errorf("failed to insert opportunity leg: %w", err)
+			return fmt.Errorf("failed to insert opportunity leg: %w", err)
 		}
 	}
 
-	// Commit transaction
-	if err = tx.Commit(); err != nil {
-		return 0, fmt.Errorf("failed to commit transaction: %w", err)
-	}
-
-	return opportunityID, nil
+	return nil
 }
 
 // WriteOpportunities writes multiple opportunities in a batch
@@ -108,18 +243,22 @@ func (w *HolocronWriter) WriteOpportunities(ctx context.Context, opportunities [
 	return ids, nil
 }
 
-// GetOpportunityByID retrieves an opportunity by ID (with legs)
-func (w *HolocronWriter) GetOpportunityByID(ctx context.Context, id int64) (*models.Opportunity, error) {
-	// Query opportunity
-	opportunityQuery := `
-		SELECT id, opportunity_type, sport_key, event_id, market_key,
-		       edge_pct, fair_price, detected_at, data_age_seconds
-		FROM opportunities
-		WHERE id = $1
-	`
+// opportunitySelect is the column list scanned by scanOpportunity
+const opportunitySelect = `
+	SELECT id, opportunity_type, sport_key, event_id, market_key,
+	       edge_pct, fair_price, detected_at, data_age_seconds,
+	       sharp_move_cents, lag_seconds,
+	       middle_both_win_prob, middle_one_win_prob, middle_push_prob,
+	       guaranteed_return, max_stake,
+	       COALESCE(fingerprint, ''), first_seen_at, last_seen_at,
+	       COALESCE(peak_edge_pct, edge_pct), closed_at, COALESCE(close_reason, '')
+	FROM opportunities
+`
 
+// scanOpportunity scans a row selected with opportunitySelect
+func scanOpportunity(row interface{ Scan(...interface{}) error }) (models.Opportunity, error) {
 	var opp models.Opportunity
-	err := w.db.QueryRowContext(ctx, opportunityQuery, id).Scan(
+	err := row.Scan(
 		&opp.ID,
 		&opp.OpportunityType,
 		&opp.SportKey,
@@ -129,8 +268,26 @@ func (w *HolocronWriter) GetOpportunityByID(ctx context.Context, id int64) (*mod
 		&opp.FairPrice,
 		&opp.DetectedAt,
 		&opp.DataAgeSeconds,
+		&opp.SharpMoveCents,
+		&opp.LagSeconds,
+		&opp.MiddleBothWinProb,
+		&opp.MiddleOneWinProb,
+		&opp.MiddlePushProb,
+		&opp.GuaranteedReturn,
+		&opp.MaxStake,
+		&opp.Fingerprint,
+		&opp.FirstSeenAt,
+		&opp.LastSeenAt,
+		&opp.PeakEdgePercent,
+		&opp.ClosedAt,
+		&opp.CloseReason,
 	)
+	return opp, err
+}
 
+// GetOpportunityByID retrieves an opportunity by ID (with legs)
+func (w *HolocronWriter) GetOpportunityByID(ctx context.Context, id int64) (*models.Opportunity, error) {
+	opp, err := scanOpportunity(w.db.QueryRowContext(ctx, opportunitySelect+" WHERE id = $1", id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("opportunity not found: %d", id)
@@ -138,15 +295,64 @@ func (w *HolocronWriter) GetOpportunityByID(ctx context.Context, id int64) (*mod
 		return nil, fmt.Errorf("failed to query opportunity: %w", err)
 	}
 
-	// Query legs
+	legs, err := w.getLegs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	opp.Legs = legs
+	return &opp, nil
+}
+
+// GetOpenOpportunities retrieves a sport's open opportunities (with legs)
+// Used at startup so lifecycle tracking picks up where the last run left off.
+func (w *HolocronWriter) GetOpenOpportunities(ctx context.Context, sportKey string) ([]models.Opportunity, error) {
+	rows, err := w.db.QueryContext(ctx, opportunitySelect+`
+		WHERE sport_key = $1 AND closed_at IS NULL AND fingerprint IS NOT NULL
+		ORDER BY id
+	`, sportKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query open opportunities: %w", err)
+	}
+
+	var opportunities []models.Opportunity
+	for rows.Next() {
+		opp, err := scanOpportunity(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan opportunity: %w", err)
+		}
+		opportunities = append(opportunities, opp)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("error iterating opportunities: %w", err)
+	}
+	rows.Close()
+
+	for i := range opportunities {
+		legs, err := w.getLegs(ctx, opportunities[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		opportunities[i].Legs = legs
+	}
+
+	return opportunities, nil
+}
+
+// getLegs retrieves an opportunity's legs in insert order
+func (w *HolocronWriter) getLegs(ctx context.Context, opportunityID int64) ([]models.OpportunityLeg, error) {
 	legsQuery := `
-		SELECT book_key, outcome_name, price, point, leg_edge_pct
+		SELECT book_key, outcome_name, price, point, leg_edge_pct, middle_width,
+		       COALESCE(market_key, ''), stake_fraction,
+		       COALESCE(player_name, ''), COALESCE(stat, ''),
+		       COALESCE(price_format, ''), COALESCE(price_value, '')
 		FROM opportunity_legs
 		WHERE opportunity_id = $1
 		ORDER BY id
 	`
 
-	rows, err := w.db.QueryContext(ctx, legsQuery, id)
+	rows, err := w.db.QueryContext(ctx, legsQuery, opportunityID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query legs: %w", err)
 	}
@@ -161,6 +367,13 @@ func (w *HolocronWriter) GetOpportunityByID(ctx context.Context, id int64) (*mod
 			&leg.Price,
 			&leg.Point,
 			&leg.LegEdgePercent,
+			&leg.MiddleWidth,
+			&leg.MarketKey,
+			&leg.StakeFraction,
+			&leg.PlayerName,
+			&leg.Stat,
+			&leg.PriceFormat,
+			&leg.PriceValue,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan leg: %w", err)
@@ -172,10 +385,5 @@ func (w *HolocronWriter) GetOpportunityByID(ctx context.Context, id int64) (*mod
 		return nil, fmt.Errorf("error iterating legs: %w", err)
 	}
 
-	opp.Legs = legs
-	return &opp, nil
+	return legs, nil
 }
-
-
-
-
@@ -0,0 +1,62 @@
+package writer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+)
+
+// WriteBookSharpness replaces a sport's book_sharpness rows with a new scoring run
+// Books and markets missing from the run are removed so stale scores can't keep a book sharp.
+func (w *HolocronWriter) WriteBookSharpness(ctx context.Context, sportKey string, scores []models.BookSharpness) error {
+	tx, err := w.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM book_sharpness WHERE sport_key = $1`, sportKey); err != nil {
+		return fmt.Errorf("failed to clear book sharpness: %w", err)
+	}
+
+	query := `
+		INSERT INTO book_sharpness (
+			sport_key, market_key, book_key, events, samples,
+			opening_error, intraday_error, score, weight, is_sharp,
+			window_start, window_end, scored_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to prepare book sharpness insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, score := range scores {
+		if _, err := stmt.ExecContext(ctx,
+			sportKey,
+			score.MarketKey,
+			score.BookKey,
+			score.Events,
+			score.Samples,
+			score.OpeningError,
+			score.IntradayError,
+			score.Score,
+			score.Weight,
+			score.IsSharp,
+			score.WindowStart,
+			score.WindowEnd,
+			score.ScoredAt,
+		); err != nil {
+			return fmt.Errorf("failed to insert book sharpness for %s/%s: %w", score.MarketKey, score.BookKey, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit book sharpness: %w", err)
+	}
+
+	return nil
+}
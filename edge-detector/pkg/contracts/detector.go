@@ -26,11 +26,37 @@ type SharpBookProvider interface {
 	// IsSharpBook returns whether a given book is considered sharp
 	IsSharpBook(bookKey string) bool
 
-	// GetSharpConsensus calculates the average fair probability from sharp books
+	// IsSharpBookForMarket returns whether a book is sharp in one market
+	// Markets without sharpness scores fall back to IsSharpBook.
+	IsSharpBookForMarket(bookKey, marketKey string) bool
+
+	// GetSharpWeight returns a book's weight in a market's sharp consensus (0 = not sharp there)
+	GetSharpWeight(bookKey, marketKey string) float64
+
+	// GetSharpConsensus calculates the weighted average fair probability from sharp books
 	// Returns the consensus probability for each outcome in a market
 	GetSharpConsensus(ctx context.Context, marketOdds []models.NormalizedOdds) (map[string]float64, error)
 }
 
+// SharpnessProvider gives measured book sharpness per sport and market
+type SharpnessProvider interface {
+	// GetBookSharpness returns a book's sharpness in a sport's market
+	// scored is false when no book is sharp in the market, so callers fall back to their own sharp set.
+	GetBookSharpness(sportKey, marketKey, bookKey string) (sharpness models.BookSharpness, scored bool)
+}
+
+// MarketOddsProvider gives detectors the latest quotes for other markets of an event
+type MarketOddsProvider interface {
+	// GetMarketOdds returns the latest quote per book+outcome for an event's market
+	GetMarketOdds(eventID, marketKey string) []models.NormalizedOdds
+}
+
+// BookProfileProvider gives detectors each book's commission, tax and stake limits
+type BookProfileProvider interface {
+	// GetBookProfile returns the book's profile (the zero profile prices it as posted)
+	GetBookProfile(bookKey string) models.BookProfile
+}
+
 // DetectorConfig defines configuration for opportunity detection
 type DetectorConfig interface {
 	// GetMinEdgePercent returns the minimum edge percentage threshold
@@ -45,11 +71,50 @@ type DetectorConfig interface {
 	// IsScalpDetectionEnabled returns whether scalp detection is enabled
 	IsScalpDetectionEnabled() bool
 
+	// IsStaleLineDetectionEnabled returns whether stale soft-book line detection is enabled
+	IsStaleLineDetectionEnabled() bool
+
+	// GetStaleLineMinMoveCents returns the sharp move (in cents) that makes lagging soft quotes stale
+	GetStaleLineMinMoveCents() int
+
+	// GetStaleLineWindowSeconds returns how far back sharp price history is kept
+	GetStaleLineWindowSeconds() int
+
 	// GetEnabledMarkets returns the list of markets to monitor
 	GetEnabledMarkets() []string
 
 	// IsPlayerPropsEnabled returns whether player props detection is enabled
 	IsPlayerPropsEnabled() bool
+
+	// IsDetectorEnabled returns whether the detector for an opportunity type runs for this sport
+	IsDetectorEnabled(opportunityType models.OpportunityType) bool
+
+	// GetDetectorTimeoutMs returns how long a detector may run on one message (0 = default)
+	GetDetectorTimeoutMs(opportunityType models.OpportunityType) int
+
+	// GetScoreDistribution returns the final margin (spreads) or total (totals) model for a market
+	// Returns nil when the sport has no model for it, which disables middles there.
+	GetScoreDistribution(marketKey string) ScoreDistribution
+
+	// IsDrawPossible returns whether games can end tied (a draw outcome settles h2h)
+	IsDrawPossible() bool
+}
+
+// ReloadableConfig is a DetectorConfig whose thresholds can be replaced while detectors run
+type ReloadableConfig interface {
+	DetectorConfig
+
+	// ApplySettings replaces the live thresholds; nil restores the environment defaults
+	ApplySettings(settings *models.DetectorSettings)
+}
+
+// ScoreDistribution models where a final margin or total lands around the market's expectation
+type ScoreDistribution interface {
+	// Probability returns P(final == value) when the market expects center
+	Probability(value int, center float64) float64
+
+	// Support returns the range of values worth summing over for center
+	Support(center float64) (min, max int)
 }
 
 
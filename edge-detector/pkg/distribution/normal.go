@@ -0,0 +1,45 @@
+package distribution
+
+import "math"
+
+// supportStdDevs is how far either side of the center Support reaches
+const supportStdDevs = 8
+
+// Normal is a normal distribution discretized to whole-point final margins or totals
+// Each integer gets the mass between value-0.5 and value+0.5.
+type Normal struct {
+	StdDev float64 // Spread of final outcomes around the market's expectation
+	NoTies bool    // The value can't be 0 (margins in sports that play overtime)
+}
+
+// Probability implements contracts.ScoreDistribution
+func (n Normal) Probability(value int, center float64) float64 {
+	if n.StdDev <= 0 {
+		return 0
+	}
+	if n.NoTies && value == 0 {
+		return 0
+	}
+
+	p := n.mass(float64(value), center)
+	if n.NoTies {
+		// Games tied after regulation go to overtime; share the tie mass out proportionally
+		p /= 1 - n.mass(0, center)
+	}
+	return p
+}
+
+// Support implements contracts.ScoreDistribution
+func (n Normal) Support(center float64) (int, int) {
+	reach := supportStdDevs * n.StdDev
+	return int(math.Floor(center - reach)), int(math.Ceil(center + reach))
+}
+
+// mass returns the probability the continuous value rounds to value
+func (n Normal) mass(value, center float64) float64 {
+	return n.cdf(value+0.5, center) - n.cdf(value-0.5, center)
+}
+
+func (n Normal) cdf(x, center float64) float64 {
+	return 0.5 * (1 + math.Erf((x-center)/(n.StdDev*math.Sqrt2)))
+}
@@ -0,0 +1,55 @@
+package models
+
+import "math"
+
+// TaxBasis is what a book's tax rate is applied to
+type TaxBasis string
+
+const (
+	TaxBasisNet   TaxBasis = "net"   // Winnings after commission
+	TaxBasisGross TaxBasis = "gross" // Whole payout, stake included
+)
+
+// BookProfile is a book's costs and limits, loaded from Holocron book_profiles
+// The zero value is a book paid at its posted price with no limits.
+type BookProfile struct {
+	BookKey         string             `json:"book_key"`
+	CommissionRate  float64            `json:"commission_rate"`   // Share of net winnings the book keeps
+	TaxRate         float64            `json:"tax_rate"`          // Withheld on a winning bet
+	TaxBasis        TaxBasis           `json:"tax_basis"`         // What TaxRate applies to
+	MinStake        float64            `json:"min_stake"`         // Smallest accepted bet
+	MaxStake        float64            `json:"max_stake"`         // Default largest bet (0 = no limit)
+	MarketMaxStakes map[string]float64 `json:"market_max_stakes"` // Per-market MaxStake overrides
+	StakeIncrement  float64            `json:"stake_increment"`   // Stakes round down to a multiple of this (0 = exact)
+}
+
+// NetDecimal returns what one unit on a winning bet actually pays back at decimal odds
+func (p BookProfile) NetDecimal(decimal float64) float64 {
+	winnings := (decimal - 1) * (1 - p.CommissionRate)
+	if p.TaxBasis == TaxBasisGross {
+		return (1 + winnings) * (1 - p.TaxRate)
+	}
+	return 1 + winnings*(1-p.TaxRate)
+}
+
+// HasCosts reports whether a win pays less than the posted price
+func (p BookProfile) HasCosts() bool {
+	return p.CommissionRate > 0 || p.TaxRate > 0
+}
+
+// MaxStakeFor returns the largest bet on a market (0 = no limit)
+func (p BookProfile) MaxStakeFor(marketKey string) float64 {
+	if limit, ok := p.MarketMaxStakes[marketKey]; ok && limit > 0 {
+		return limit
+	}
+	return p.MaxStake
+}
+
+// RoundStake rounds a stake down to the book's increment
+func (p BookProfile) RoundStake(stake float64) float64 {
+	if p.StakeIncrement <= 0 {
+		return stake
+	}
+	// Nudge so 10.00 / 0.01 doesn't floor to 999
+	return math.Floor(stake/p.StakeIncrement+1e-9) * p.StakeIncrement
+}
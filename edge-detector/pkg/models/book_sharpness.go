@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// BookSharpness is a book's accuracy against the closing consensus in one sport's market,
+// written to Holocron book_sharpness by the sharpness job
+type BookSharpness struct {
+	SportKey      string    `json:"sport_key"`
+	MarketKey     string    `json:"market_key"`
+	BookKey       string    `json:"book_key"`
+	Events        int       `json:"events"`         // Events with at least one scored price
+	Samples       int       `json:"samples"`        // Pre-close price snapshots scored
+	OpeningError  *float64  `json:"opening_error"`  // Mean |no-vig prob - close| of the first price per event
+	IntradayError *float64  `json:"intraday_error"` // Mean |no-vig prob - close| over every price
+	Score         float64   `json:"score"`          // 1 - error / average book error; 0 = average book
+	Weight        float64   `json:"weight"`         // Weight in the sharp consensus (0 unless IsSharp)
+	IsSharp       bool      `json:"is_sharp"`
+	WindowStart   time.Time `json:"window_start"`
+	WindowEnd     time.Time `json:"window_end"`
+	ScoredAt      time.Time `json:"scored_at"`
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// DetectorSettings is a sport's live detection thresholds from Holocron detector_config
+// Nil fields keep the sport config's environment default.
+type DetectorSettings struct {
+	SportKey               string    `json:"sport_key"`
+	MinEdgePct             *float64  `json:"min_edge_pct"` // 0.01 = 1%, as MIN_EDGE_PCT
+	MaxDataAgeSeconds      *int      `json:"max_data_age_seconds"`
+	EnabledMarkets         []string  `json:"enabled_markets"`
+	StaleLineMinMoveCents  *int      `json:"stale_line_min_move_cents"`
+	StaleLineWindowSeconds *int      `json:"stale_line_window_seconds"`
+	EnableMiddles          *bool     `json:"enable_middles"`
+	EnableScalps           *bool     `json:"enable_scalps"`
+	EnableStaleLines       *bool     `json:"enable_stale_lines"`
+	EnablePlayerProps      *bool     `json:"enable_player_props"`
+	Version                int64     `json:"version"`
+	UpdatedBy              string    `json:"updated_by"`
+	UpdatedAt              time.Time `json:"updated_at"`
+}
+
+// DetectorConfigNotice is published on detector.config.updated when a sport's settings change
+type DetectorConfigNotice struct {
+	SportKey string `json:"sport_key"`
+	Version  int64  `json:"version"`
+}
+
+var marketKeyPattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Validate checks the settings against the same bounds as the detector_config table
+// and the API gateway, so a bad row never reaches the detectors.
+func (s DetectorSettings) Validate() error {
+	if s.MinEdgePct != nil && (*s.MinEdgePct <= 0 || *s.MinEdgePct > 0.5) {
+		return fmt.Errorf("min_edge_pct must be in (0, 0.5], got %v", *s.MinEdgePct)
+	}
+	if s.MaxDataAgeSeconds != nil && (*s.MaxDataAgeSeconds < 1 || *s.MaxDataAgeSeconds > 3600) {
+		return fmt.Errorf("max_data_age_seconds must be in [1, 3600], got %d", *s.MaxDataAgeSeconds)
+	}
+	if s.EnabledMarkets != nil && len(s.EnabledMarkets) == 0 {
+		return fmt.Errorf("enabled_markets must not be empty")
+	}
+	for _, market := range s.EnabledMarkets {
+		if !marketKeyPattern.MatchString(market) {
+			return fmt.Errorf("invalid market key %q in enabled_markets", market)
+		}
+	}
+	if s.StaleLineMinMoveCents != nil && (*s.StaleLineMinMoveCents < 1 || *s.StaleLineMinMoveCents > 500) {
+		return fmt.Errorf("stale_line_min_move_cents must be in [1, 500], got %d", *s.StaleLineMinMoveCents)
+	}
+	if s.StaleLineWindowSeconds != nil && (*s.StaleLineWindowSeconds < 10 || *s.StaleLineWindowSeconds > 86400) {
+		return fmt.Errorf("stale_line_window_seconds must be in [10, 86400], got %d", *s.StaleLineWindowSeconds)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LifecycleEvent is published to opportunities.events as an opportunity opens, changes and closes
+type LifecycleEvent string
+
+const (
+	EventOpportunityDetected LifecycleEvent = "opportunity.detected" // First seen (also on opportunities.detected)
+	EventOpportunityUpdated  LifecycleEvent = "opportunity.updated"  // Re-detected with a different edge or prices
+	EventOpportunityClosed   LifecycleEvent = "opportunity.closed"   // Gone; ClosedAt and CloseReason set
+)
+
+// Close reasons
+const (
+	CloseReasonGone    = "gone"    // A leg's quote updated and the opportunity wasn't re-detected
+	CloseReasonExpired = "expired" // Not re-detected within the lifecycle TTL
+)
+
+// Fingerprint identifies an opportunity across ticks: event, market, type and each
+// leg's book, market, player, outcome and point. Prices and edge are left out so a re-priced
+// opportunity keeps its identity.
+func Fingerprint(opportunity Opportunity) string {
+	legs := make([]string, 0, len(opportunity.Legs))
+	for _, leg := range opportunity.Legs {
+		point := "-"
+		if leg.Point != nil {
+			point = fmt.Sprintf("%g", *leg.Point)
+		}
+		legs = append(legs, strings.Join([]string{leg.BookKey, leg.MarketKey, leg.PlayerName, leg.OutcomeName, point}, "|"))
+	}
+	sort.Strings(legs)
+
+	key := strings.Join([]string{
+		string(opportunity.OpportunityType),
+		opportunity.EventID,
+		opportunity.MarketKey,
+		strings.Join(legs, ";"),
+	}, "#")
+
+	sum := sha1.Sum([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -6,9 +6,11 @@ import "time"
 type OpportunityType string
 
 const (
-	OpportunityTypeEdge   OpportunityType = "edge"   // Single +EV bet
-	OpportunityTypeMiddle OpportunityType = "middle" // Both sides of market are +EV
-	OpportunityTypeScalp  OpportunityType = "scalp"  // Guaranteed profit (arbitrage)
+	OpportunityTypeEdge      OpportunityType = "edge"       // Single +EV bet
+	OpportunityTypeMiddle    OpportunityType = "middle"     // Both sides of market are +EV
+	OpportunityTypeScalp     OpportunityType = "scalp"      // Guaranteed profit (arbitrage)
+	OpportunityTypeStaleLine OpportunityType = "stale_line" // Soft book hasn't followed a sharp move
+	OpportunityTypePlayerProp OpportunityType = "player_prop" // Single +EV player prop vs a two-sided sharp price
 )
 
 // Opportunity represents a detected betting opportunity
@@ -25,6 +27,29 @@ type Opportunity struct {
 	DetectedAt     time.Time `json:"detected_at"`
 	DataAgeSeconds int       `json:"data_age_seconds"`
 
+	// Lifecycle: one row per fingerprint while open, refreshed each time it's re-detected
+	Fingerprint     string     `json:"fingerprint,omitempty"`
+	FirstSeenAt     time.Time  `json:"first_seen_at"`
+	LastSeenAt      time.Time  `json:"last_seen_at"`
+	PeakEdgePercent float64    `json:"peak_edge_pct"`
+	ClosedAt        *time.Time `json:"closed_at,omitempty"`
+	CloseReason     string     `json:"close_reason,omitempty"` // gone or expired
+
+	// Stale line context (stale_line only)
+	SharpMoveCents *int `json:"sharp_move_cents,omitempty"` // Size of the sharp move the soft book missed
+	LagSeconds     *int `json:"lag_seconds,omitempty"`      // Soft quote age relative to the latest sharp quote
+
+	// Middle landing probabilities (middle only), from the sport's score distribution
+	MiddleBothWinProb *float64 `json:"middle_both_win_prob,omitempty"` // Final lands strictly inside the window
+	MiddleOneWinProb  *float64 `json:"middle_one_win_prob,omitempty"`  // One leg wins, the other loses
+	MiddlePushProb    *float64 `json:"middle_push_prob,omitempty"`     // A leg pushes on a whole-number line
+
+	// Scalp payback per unit staked, whichever leg wins (scalp only)
+	GuaranteedReturn *float64 `json:"guaranteed_return,omitempty"`
+
+	// Largest total stake the books' limits allow (edge, scalp); nil when unlimited
+	MaxStake *float64 `json:"max_stake,omitempty"`
+
 	// Legs
 	Legs []OpportunityLeg `json:"legs"`
 
@@ -35,10 +60,17 @@ type Opportunity struct {
 // OpportunityLeg represents a single betting leg within an opportunity
 type OpportunityLeg struct {
 	BookKey      string   `json:"book_key"`
+	MarketKey    string   `json:"market_key,omitempty"` // Market the leg is quoted in (scalp only; cross-market scalps mix markets)
+	PlayerName   string   `json:"player_name,omitempty"` // Player the prop settles on (props only)
+	Stat         string   `json:"stat,omitempty"`        // Stat the prop settles on, e.g. points (props only)
 	OutcomeName  string   `json:"outcome_name"`
 	Price        int      `json:"price"`             // American odds
+	PriceFormat  string   `json:"price_format,omitempty"` // Format of PriceValue (empty = American Price only)
+	PriceValue   string   `json:"price_value,omitempty"`  // Price as the book quoted it, e.g. "2.10" (non-US books)
 	Point        *float64 `json:"point,omitempty"`   // For spreads/totals
 	LegEdgePercent *float64 `json:"leg_edge_pct,omitempty"` // Edge for this specific leg
+	MiddleWidth    *float64 `json:"middle_width,omitempty"` // Points between the middle's two lines (middle only)
+	StakeFraction  *float64 `json:"stake_fraction,omitempty"` // Share of the total stake on this leg (scalp only)
 }
 
 // NormalizedOdds matches the normalizer's output
@@ -50,7 +82,10 @@ type NormalizedOdds struct {
 	MarketKey        string    `json:"market_key"`
 	BookKey          string    `json:"book_key"`
 	OutcomeName      string    `json:"outcome_name"`
+	Description      string    `json:"description,omitempty"` // Player name for props
 	Price            int       `json:"price"`              // American odds
+	PriceFormat      string    `json:"price_format,omitempty"` // Format of PriceValue (empty = American Price only)
+	PriceValue       string    `json:"price_value,omitempty"`  // Price as quoted by a non-US feed ("2.10", "11/10", "-0.87")
 	Point            *float64  `json:"point,omitempty"`    // For spreads/totals
 	VendorLastUpdate time.Time `json:"vendor_last_update"`
 	ReceivedAt       time.Time `json:"received_at"`
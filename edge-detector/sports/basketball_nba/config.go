@@ -4,66 +4,246 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/contracts"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/distribution"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
 )
 
 // Config holds NBA-specific edge detection configuration
+// The thresholds in models.DetectorSettings can be replaced live with ApplySettings;
+// read them through the getters once detectors are running.
 type Config struct {
-	MinEdgePct          float64
-	MaxDataAgeSeconds   int
-	EnableMiddles       bool
-	EnableScalps        bool
-	EnabledMarkets      []string
-	EnablePlayerProps   bool
-	SharpBookMinimum    int
-	SharpBooks          []string // Configurable list of sharp book keys
+	MinEdgePct             float64
+	MaxDataAgeSeconds      int
+	EnableMiddles          bool
+	EnableScalps           bool
+	EnableStaleLines       bool
+	StaleLineMinMoveCents  int // Sharp move that makes lagging soft quotes stale
+	StaleLineWindowSeconds int // Sharp price history kept for stale line detection
+	EnabledMarkets         []string
+	EnablePlayerProps      bool
+	SharpBookMinimum       int
+	SharpBooks             []string       // Configurable list of sharp book keys
+	DisabledDetectors      []string       // Opportunity types that never run for NBA
+	DetectorTimeoutMs      int            // Per-message budget for each detector
+	DetectorTimeoutsMs     map[string]int // Per-type overrides of DetectorTimeoutMs
+	MarginStdDev           float64        // Final margin spread around the market line (middles)
+	TotalStdDev            float64        // Final total spread around the market total (middles)
+
+	mu       sync.RWMutex
+	defaults models.DetectorSettings // Environment values ApplySettings falls back to
 }
 
 // NewConfig creates a new NBA configuration with defaults and environment overrides
 func NewConfig() *Config {
-	return &Config{
+	config := &Config{
 		MinEdgePct:         getEnvFloat("MIN_EDGE_PCT", 0.01),                                      // 1%
 		MaxDataAgeSeconds:  getEnvInt("MAX_DATA_AGE_SECONDS", 10),                                  // 10 seconds
 		EnableMiddles:      getEnvBool("ENABLE_MIDDLES", true),                                     // Enabled
 		EnableScalps:       getEnvBool("ENABLE_SCALPS", true),                                      // Enabled
+		EnableStaleLines:   getEnvBool("ENABLE_STALE_LINES", true),                                 // Enabled
+		StaleLineMinMoveCents:  getEnvInt("STALE_LINE_MIN_MOVE_CENTS", 10),                         // 10 cents
+		StaleLineWindowSeconds: getEnvInt("STALE_LINE_WINDOW_SECONDS", 300),                        // 5 minutes
 		EnabledMarkets:     getEnvStringSlice("ENABLED_MARKETS", []string{"h2h", "spreads", "totals"}), // Featured markets
-		EnablePlayerProps:  getEnvBool("ENABLE_PLAYER_PROPS", false),                               // Not in v0
+		EnablePlayerProps:  getEnvBool("ENABLE_PLAYER_PROPS", false),                               // Off by default
 		SharpBookMinimum:   getEnvInt("SHARP_BOOK_MINIMUM", 1),                                     // At least 1 sharp book
 		SharpBooks:         getEnvStringSlice("SHARP_BOOKS", []string{"pinnacle"}),                 // Default: Pinnacle
+		DisabledDetectors:  getEnvStringSlice("DISABLED_DETECTORS", nil),                           // None
+		DetectorTimeoutMs:  getEnvInt("DETECTOR_TIMEOUT_MS", 100),                                  // 100ms
+		DetectorTimeoutsMs: getEnvIntMap("DETECTOR_TIMEOUTS_MS"),                                   // e.g. stale_line:200
+		MarginStdDev:       getEnvFloat("MIDDLE_MARGIN_STDDEV", 12.0),                              // NBA margins vs closing spread
+		TotalStdDev:        getEnvFloat("MIDDLE_TOTAL_STDDEV", 18.0),                               // NBA totals vs closing total
+	}
+	config.defaults = config.Settings()
+	return config
+}
+
+// Settings returns the live thresholds
+func (c *Config) Settings() models.DetectorSettings {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	minEdgePct := c.MinEdgePct
+	maxDataAgeSeconds := c.MaxDataAgeSeconds
+	staleLineMinMoveCents := c.StaleLineMinMoveCents
+	staleLineWindowSeconds := c.StaleLineWindowSeconds
+	enableMiddles := c.EnableMiddles
+	enableScalps := c.EnableScalps
+	enableStaleLines := c.EnableStaleLines
+	enablePlayerProps := c.EnablePlayerProps
+
+	return models.DetectorSettings{
+		SportKey:               "basketball_nba",
+		MinEdgePct:             &minEdgePct,
+		MaxDataAgeSeconds:      &maxDataAgeSeconds,
+		EnabledMarkets:         c.EnabledMarkets,
+		StaleLineMinMoveCents:  &staleLineMinMoveCents,
+		StaleLineWindowSeconds: &staleLineWindowSeconds,
+		EnableMiddles:          &enableMiddles,
+		EnableScalps:           &enableScalps,
+		EnableStaleLines:       &enableStaleLines,
+		EnablePlayerProps:      &enablePlayerProps,
 	}
 }
 
+// ApplySettings implements ReloadableConfig
+// Fields the settings leave nil go back to their environment values. Detectors are
+// switched on and off from the next message.
+func (c *Config) ApplySettings(settings *models.DetectorSettings) {
+	if settings == nil {
+		settings = &models.DetectorSettings{}
+	}
+	defaults := c.defaults
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.MinEdgePct = *pick(settings.MinEdgePct, defaults.MinEdgePct)
+	c.MaxDataAgeSeconds = *pick(settings.MaxDataAgeSeconds, defaults.MaxDataAgeSeconds)
+	c.StaleLineMinMoveCents = *pick(settings.StaleLineMinMoveCents, defaults.StaleLineMinMoveCents)
+	c.StaleLineWindowSeconds = *pick(settings.StaleLineWindowSeconds, defaults.StaleLineWindowSeconds)
+	c.EnableMiddles = *pick(settings.EnableMiddles, defaults.EnableMiddles)
+	c.EnableScalps = *pick(settings.EnableScalps, defaults.EnableScalps)
+	c.EnableStaleLines = *pick(settings.EnableStaleLines, defaults.EnableStaleLines)
+	c.EnablePlayerProps = *pick(settings.EnablePlayerProps, defaults.EnablePlayerProps)
+
+	c.EnabledMarkets = defaults.EnabledMarkets
+	if settings.EnabledMarkets != nil {
+		c.EnabledMarkets = settings.EnabledMarkets
+	}
+}
+
+// pick returns the setting, or the default when it isn't set
+func pick[T any](value, defaultValue *T) *T {
+	if value != nil {
+		return value
+	}
+	return defaultValue
+}
+
 // GetMinEdgePercent implements DetectorConfig
 func (c *Config) GetMinEdgePercent() float64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.MinEdgePct
 }
 
 // GetMaxDataAgeSeconds implements DetectorConfig
 func (c *Config) GetMaxDataAgeSeconds() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.MaxDataAgeSeconds
 }
 
 // IsMiddleDetectionEnabled implements DetectorConfig
 func (c *Config) IsMiddleDetectionEnabled() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.EnableMiddles
 }
 
 // IsScalpDetectionEnabled implements DetectorConfig
 func (c *Config) IsScalpDetectionEnabled() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.EnableScalps
 }
 
+// IsStaleLineDetectionEnabled implements DetectorConfig
+func (c *Config) IsStaleLineDetectionEnabled() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.EnableStaleLines
+}
+
+// GetStaleLineMinMoveCents implements DetectorConfig
+func (c *Config) GetStaleLineMinMoveCents() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.StaleLineMinMoveCents
+}
+
+// GetStaleLineWindowSeconds implements DetectorConfig
+func (c *Config) GetStaleLineWindowSeconds() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.StaleLineWindowSeconds
+}
+
 // GetEnabledMarkets implements DetectorConfig
 func (c *Config) GetEnabledMarkets() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.EnabledMarkets
 }
 
 // IsPlayerPropsEnabled implements DetectorConfig
 func (c *Config) IsPlayerPropsEnabled() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.EnablePlayerProps
 }
 
+// IsDetectorEnabled implements DetectorConfig
+// Detectors without their own flag run unless listed in DisabledDetectors.
+func (c *Config) IsDetectorEnabled(opportunityType models.OpportunityType) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, disabled := range c.DisabledDetectors {
+		if strings.TrimSpace(disabled) == string(opportunityType) {
+			return false
+		}
+	}
+
+	switch opportunityType {
+	case models.OpportunityTypeMiddle:
+		return c.EnableMiddles
+	case models.OpportunityTypeScalp:
+		return c.EnableScalps
+	case models.OpportunityTypeStaleLine:
+		return c.EnableStaleLines
+	case models.OpportunityTypePlayerProp:
+		return c.EnablePlayerProps
+	default:
+		return true
+	}
+}
+
+// GetDetectorTimeoutMs implements DetectorConfig
+func (c *Config) GetDetectorTimeoutMs(opportunityType models.OpportunityType) int {
+	if timeout, ok := c.DetectorTimeoutsMs[string(opportunityType)]; ok {
+		return timeout
+	}
+	return c.DetectorTimeoutMs
+}
+
+// GetScoreDistribution implements DetectorConfig
+// NBA games can't end tied, so the margin model has no mass at 0.
+func (c *Config) GetScoreDistribution(marketKey string) contracts.ScoreDistribution {
+	switch marketKey {
+	case "spreads":
+		return distribution.Normal{StdDev: c.MarginStdDev, NoTies: true}
+	case "totals":
+		return distribution.Normal{StdDev: c.TotalStdDev}
+	default:
+		return nil
+	}
+}
+
+// IsDrawPossible implements DetectorConfig
+// NBA games go to overtime, so h2h has no draw and spread 0 settles like h2h.
+func (c *Config) IsDrawPossible() bool {
+	return false
+}
+
 // IsMarketEnabled checks if a given market is enabled
 func (c *Config) IsMarketEnabled(marketKey string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	for _, m := range c.EnabledMarkets {
 		if m == marketKey {
 			return true
@@ -113,3 +293,17 @@ func getEnvStringSlice(key string, defaultValue []string) []string {
 	return defaultValue
 }
 
+// getEnvIntMap parses "key:value,key:value" into a map, skipping malformed pairs
+func getEnvIntMap(key string) map[string]int {
+	result := make(map[string]int)
+	for _, pair := range strings.Split(os.Getenv(key), ",") {
+		name, value, found := strings.Cut(pair, ":")
+		if !found {
+			continue
+		}
+		if parsed, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+			result[strings.TrimSpace(name)] = parsed
+		}
+	}
+	return result
+}
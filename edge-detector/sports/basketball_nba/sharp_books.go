@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/contracts"
 	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
 )
 
@@ -18,15 +19,23 @@ type SharpBookProvider struct {
 	mu             sync.RWMutex
 	sharpBooks     map[string]bool // book_key -> is_sharp
 	lastRefresh    int64           // Unix timestamp
+	sharpness      contracts.SharpnessProvider // Scored sharp sets per market (nil = configured set everywhere)
 }
 
 // NewSharpBookProvider creates a new sharp book provider
 func NewSharpBookProvider(db *sql.DB, configuredSharpBooks []string) *SharpBookProvider {
+	return NewSharpBookProviderWithSharpness(db, configuredSharpBooks, nil)
+}
+
+// NewSharpBookProviderWithSharpness creates a sharp book provider whose scored markets
+// use the books measured sharp there, weighted by accuracy
+func NewSharpBookProviderWithSharpness(db *sql.DB, configuredSharpBooks []string, sharpness contracts.SharpnessProvider) *SharpBookProvider {
 	return &SharpBookProvider{
 		db:              db,
 		sportKey:        "basketball_nba",
 		configuredBooks: configuredSharpBooks,
 		sharpBooks:      make(map[string]bool),
+		sharpness:       sharpness,
 	}
 }
 
@@ -62,7 +71,34 @@ func (s *SharpBookProvider) IsSharpBook(bookKey string) bool {
 	return s.sharpBooks[bookKey]
 }
 
-// GetSharpConsensus calculates the average fair probability from sharp books
+// IsSharpBookForMarket returns whether a book is sharp in a market
+// Markets with sharpness scores use the scored sharp set; the rest (and all player
+// props, which aren't scored) use the configured or database sharp books.
+func (s *SharpBookProvider) IsSharpBookForMarket(bookKey, marketKey string) bool {
+	return s.GetSharpWeight(bookKey, marketKey) > 0
+}
+
+// GetSharpWeight returns a book's weight in a market's sharp consensus (0 = not sharp there)
+func (s *SharpBookProvider) GetSharpWeight(bookKey, marketKey string) float64 {
+	if s.sharpness != nil {
+		if sharpness, scored := s.sharpness.GetBookSharpness(s.sportKey, marketKey, bookKey); scored {
+			if !sharpness.IsSharp {
+				return 0
+			}
+			if sharpness.Weight <= 0 {
+				return 1
+			}
+			return sharpness.Weight
+		}
+	}
+
+	if s.IsSharpBook(bookKey) {
+		return 1
+	}
+	return 0
+}
+
+// GetSharpConsensus calculates the weighted average fair probability from sharp books
 func (s *SharpBookProvider) GetSharpConsensus(ctx context.Context, marketOdds []models.NormalizedOdds) (map[string]float64, error) {
 	if len(marketOdds) == 0 {
 		return nil, fmt.Errorf("no market odds provided")
@@ -73,11 +109,13 @@ func (s *SharpBookProvider) GetSharpConsensus(ctx context.Context, marketOdds []
 		return nil, err
 	}
 
-	// Group odds by outcome, filtering for sharp books only
-	outcomeProbs := make(map[string][]float64)
+	// Weight each sharp book's probability by its sharpness in the market
+	weightedSums := make(map[string]float64)
+	weights := make(map[string]float64)
 
 	for _, odds := range marketOdds {
-		if !s.IsSharpBook(odds.BookKey) {
+		weight := s.GetSharpWeight(odds.BookKey, odds.MarketKey)
+		if weight <= 0 {
 			continue
 		}
 
@@ -87,21 +125,14 @@ func (s *SharpBookProvider) GetSharpConsensus(ctx context.Context, marketOdds []
 			prob = *odds.NoVigProbability
 		}
 
-		outcomeProbs[odds.OutcomeName] = append(outcomeProbs[odds.OutcomeName], prob)
+		weightedSums[odds.OutcomeName] += weight * prob
+		weights[odds.OutcomeName] += weight
 	}
 
-	// Calculate average (consensus) for each outcome
+	// Calculate weighted average (consensus) for each outcome
 	consensus := make(map[string]float64)
-	for outcome, probs := range outcomeProbs {
-		if len(probs) == 0 {
-			continue
-		}
-
-		sum := 0.0
-		for _, prob := range probs {
-			sum += prob
-		}
-		consensus[outcome] = sum / float64(len(probs))
+	for outcome, sum := range weightedSums {
+		consensus[outcome] = sum / weights[outcome]
 	}
 
 	if len(consensus) == 0 {
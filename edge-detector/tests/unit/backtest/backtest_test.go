@@ -0,0 +1,206 @@
+package backtest_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/backtest"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/detector"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/lifecycle"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/marketstate"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/sports/basketball_nba"
+)
+
+// noSharpBooks leaves every book soft, so only scalps are found
+type noSharpBooks struct{}
+
+func (noSharpBooks) GetSharpBooks(ctx context.Context, sportKey string) ([]string, error) {
+	return nil, nil
+}
+
+func (noSharpBooks) IsSharpBook(bookKey string) bool {
+	return false
+}
+
+func (noSharpBooks) IsSharpBookForMarket(bookKey, marketKey string) bool {
+	return false
+}
+
+func (noSharpBooks) GetSharpWeight(bookKey, marketKey string) float64 {
+	return 0
+}
+
+func (noSharpBooks) GetSharpConsensus(ctx context.Context, marketOdds []models.NormalizedOdds) (map[string]float64, error) {
+	return nil, fmt.Errorf("no sharp books in market")
+}
+
+var tipOff = time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+// replayed is a moneyline quote as the normalizer replay writes it, received secondsIn after 23:00
+func replayed(eventID, bookKey, outcomeName string, price, secondsIn int) models.NormalizedOdds {
+	receivedAt := tipOff.Add(-time.Hour + time.Duration(secondsIn)*time.Second)
+	decimal := 1 + float64(price)/100
+	return models.NormalizedOdds{
+		EventID:            eventID,
+		SportKey:           "basketball_nba",
+		MarketKey:          "h2h",
+		BookKey:            bookKey,
+		OutcomeName:        outcomeName,
+		Price:              price,
+		DecimalOdds:        decimal,
+		ImpliedProbability: 1 / decimal,
+		VendorLastUpdate:   receivedAt,
+		ReceivedAt:         receivedAt,
+	}
+}
+
+func TestBacktester_Run(t *testing.T) {
+	// A two-book scalp, then ten replayed minutes of another game's quotes
+	var in bytes.Buffer
+	encoder := json.NewEncoder(&in)
+	for _, odds := range []models.NormalizedOdds{
+		replayed("event-1", "fanduel", "Los Angeles Lakers", 110, 0),
+		replayed("event-1", "draftkings", "Boston Celtics", 110, 5),
+		replayed("event-2", "fanduel", "Denver Nuggets", 120, 300),
+		replayed("event-2", "fanduel", "Denver Nuggets", 125, 600),
+	} {
+		if err := encoder.Encode(odds); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	clock := backtest.NewClock()
+	marketStore := marketstate.NewStoreWithClock(marketstate.DefaultConfig(), clock.Now)
+	detectors := detector.NewDefaultRegistryWithClock(marketStore, nil, clock.Now)
+	detectors.AddSport("basketball_nba", basketball_nba.NewConfig(), noSharpBooks{})
+
+	journal := backtest.NewJournal(nil)
+	tracker := lifecycle.NewTrackerWithClock(lifecycle.DefaultConfig(), backtest.NewMemoryStore(), journal, nil, clock.Now)
+	engine := detector.NewReplayEngine(tracker, detectors, marketStore)
+
+	backtester := backtest.NewBacktester(backtest.Config{
+		Latency:       2 * time.Second,
+		SweepInterval: 30 * time.Second,
+	}, engine, tracker, marketStore, clock)
+
+	result, err := backtester.Run(context.Background(), &in)
+	if err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+
+	if result.Rows != 4 || result.Changes != 1 {
+		t.Errorf("expected 4 rows and 1 change, got %d rows and %d changes", result.Rows, result.Changes)
+	}
+	if want := tipOff.Add(-time.Hour + 600*time.Second); !result.To.Equal(want) {
+		t.Errorf("result ends at %s, want %s", result.To, want)
+	}
+	if want := tipOff.Add(-time.Hour + 602*time.Second); !clock.Now().Equal(want) {
+		t.Errorf("clock at %s, want received_at plus latency %s", clock.Now(), want)
+	}
+
+	// Detected on the second quote, expired by the replayed sweep five minutes later
+	entries := journal.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("expected detected and closed entries, got %+v", entries)
+	}
+	detected, closed := entries[0], entries[1]
+	if detected.Event != models.EventOpportunityDetected || detected.Opportunity.OpportunityType != models.OpportunityTypeScalp {
+		t.Errorf("expected a scalp detected first, got %s %s", detected.Event, detected.Opportunity.OpportunityType)
+	}
+	if want := tipOff.Add(-time.Hour + 7*time.Second); !detected.Opportunity.FirstSeenAt.Equal(want) {
+		t.Errorf("first seen %s, want replayed %s", detected.Opportunity.FirstSeenAt, want)
+	}
+	if closed.Event != models.EventOpportunityClosed || closed.Opportunity.CloseReason != models.CloseReasonExpired {
+		t.Errorf("expected the scalp expired, got %s %q", closed.Event, closed.Opportunity.CloseReason)
+	}
+}
+
+// overEdge is a fanduel Over 220.5 edge snapshot
+func overEdge(id int64, event models.LifecycleEvent, price int, edgePercent float64) backtest.Entry {
+	point := 220.5
+	return backtest.Entry{
+		Event: event,
+		Opportunity: models.Opportunity{
+			ID:              id,
+			OpportunityType: models.OpportunityTypeEdge,
+			EventID:         "event-1",
+			MarketKey:       "totals",
+			EdgePercent:     edgePercent,
+			Legs:            []models.OpportunityLeg{{BookKey: "fanduel", OutcomeName: "Over", Price: price, Point: &point}},
+		},
+	}
+}
+
+func TestGrader_Report(t *testing.T) {
+	half := 0.5
+	scalp := backtest.Entry{
+		Event: models.EventOpportunityDetected,
+		Opportunity: models.Opportunity{
+			ID:              2,
+			OpportunityType: models.OpportunityTypeScalp,
+			EventID:         "event-1",
+			MarketKey:       "h2h",
+			EdgePercent:     5,
+			Legs: []models.OpportunityLeg{
+				{BookKey: "fanduel", OutcomeName: "Los Angeles Lakers", Price: 110, StakeFraction: &half},
+				{BookKey: "draftkings", OutcomeName: "Boston Celtics", Price: 110, StakeFraction: &half},
+			},
+		},
+	}
+	entries := []backtest.Entry{
+		overEdge(1, models.EventOpportunityDetected, -105, 1.5),
+		scalp,
+		overEdge(1, models.EventOpportunityUpdated, -110, 3),
+		overEdge(1, models.EventOpportunityClosed, -110, 3),
+	}
+
+	scores, err := backtest.ReadScores(bytes.NewBufferString(`[{
+		"id": "event-1", "home_team": "Los Angeles Lakers", "away_team": "Boston Celtics", "completed": true,
+		"scores": [{"name": "Los Angeles Lakers", "score": "118"}, {"name": "Boston Celtics", "score": "112"}]
+	}]`))
+	if err != nil {
+		t.Fatalf("ReadScores() error: %v", err)
+	}
+	closing := map[string][]backtest.ClosingLine{
+		"event-1": {{EventID: "event-1", MarketKey: "totals", BookKey: "fanduel", OutcomeName: "Over", ClosingPrice: -130}},
+	}
+
+	rows := backtest.NewGrader(scores, closing, nil).Report(entries, []float64{0.02, 0.01})
+
+	// The edge is taken at -105 from 1% and at -110 from 2%; the total of 230 goes over
+	want := []struct {
+		opportunityType models.OpportunityType
+		minEdge         float64
+		profit          float64
+		clvCents        float64
+	}{
+		{models.OpportunityTypeEdge, 0.01, 100.0 / 105, (1/(1+100.0/130) - 1/(1+100.0/105)) * 100},
+		{models.OpportunityTypeEdge, 0.02, 100.0 / 110, (1/(1+100.0/130) - 1/(1+100.0/110)) * 100},
+		{models.OpportunityTypeScalp, 0.01, 0.05, 0},
+		{models.OpportunityTypeScalp, 0.02, 0.05, 0},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("expected %d rows, got %+v", len(want), rows)
+	}
+	for i, row := range rows {
+		w := want[i]
+		if row.OpportunityType != w.opportunityType || row.MinEdgePct != w.minEdge {
+			t.Fatalf("row %d is %s at %.2f, want %s at %.2f", i, row.OpportunityType, row.MinEdgePct, w.opportunityType, w.minEdge)
+		}
+		if row.Opportunities != 1 || row.Settled != 1 || row.Wins != 1 || row.HitRate != 1 {
+			t.Errorf("%s at %.2f: expected one settled win, got %+v", row.OpportunityType, row.MinEdgePct, row)
+		}
+		if math.Abs(row.Profit-w.profit) > 1e-9 || math.Abs(row.ROI-w.profit) > 1e-9 {
+			t.Errorf("%s at %.2f: profit %.6f roi %.6f, want %.6f", row.OpportunityType, row.MinEdgePct, row.Profit, row.ROI, w.profit)
+		}
+		if math.Abs(row.AvgCLVCents-w.clvCents) > 1e-9 {
+			t.Errorf("%s at %.2f: clv %.4f cents, want %.4f", row.OpportunityType, row.MinEdgePct, row.AvgCLVCents, w.clvCents)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package detector_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/detector"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/sports/basketball_nba"
+)
+
+// moneyline is a book's Lakers-Celtics moneyline price
+func moneyline(bookKey, outcomeName string, price int) models.NormalizedOdds {
+	decimal := 1 + 100/float64(-price)
+	if price > 0 {
+		decimal = 1 + float64(price)/100
+	}
+	return models.NormalizedOdds{
+		EventID:            "event-1",
+		SportKey:           "basketball_nba",
+		MarketKey:          "h2h",
+		BookKey:            bookKey,
+		OutcomeName:        outcomeName,
+		Price:              price,
+		DecimalOdds:        decimal,
+		ImpliedProbability: 1 / decimal,
+		ReceivedAt:         time.Now(),
+	}
+}
+
+// fakeProfiles serves book profiles by key; books without one are priced as posted
+type fakeProfiles map[string]models.BookProfile
+
+func (p fakeProfiles) GetBookProfile(bookKey string) models.BookProfile {
+	if profile, ok := p[bookKey]; ok {
+		return profile
+	}
+	return models.BookProfile{BookKey: bookKey}
+}
+
+func TestScalpDetector_BookProfiles(t *testing.T) {
+	tests := []struct {
+		name         string
+		lakers       int // fanduel
+		celtics      int // draftkings
+		profiles     fakeProfiles
+		wantAmounts  map[string]float64 // book -> stake in currency; nil = no scalp
+		wantReturn   float64
+		wantMaxStake float64 // 0 = unlimited
+	}{
+		{
+			name:        "commission priced into the stakes",
+			lakers:      110,
+			celtics:     110,
+			profiles:    fakeProfiles{"fanduel": {CommissionRate: 0.02}},
+			wantAmounts: map[string]float64{"fanduel": 1 / 2.078, "draftkings": 1 / 2.1},
+			wantReturn:  1 / (1/2.078 + 1/2.1),
+		},
+		{
+			// 2% posted, but 5% commission on both sides leaves under the 1% minimum
+			name:     "commission eats the edge",
+			lakers:   104,
+			celtics:  104,
+			profiles: fakeProfiles{"fanduel": {CommissionRate: 0.05}, "draftkings": {CommissionRate: 0.05}},
+		},
+		{
+			// fanduel's 100 limit caps the total at 195.45; draftkings' 95.45 rounds down to 95
+			name:    "capped and rounded to whole units",
+			lakers:  110,
+			celtics: 120,
+			profiles: fakeProfiles{
+				"fanduel":    {MaxStake: 100, StakeIncrement: 1},
+				"draftkings": {StakeIncrement: 1},
+			},
+			wantAmounts:  map[string]float64{"fanduel": 100, "draftkings": 95},
+			wantReturn:   math.Min(100*2.1, 95*2.2) / 195,
+			wantMaxStake: 195,
+		},
+		{
+			name:    "rounded stake below the book minimum",
+			lakers:  110,
+			celtics: 120,
+			profiles: fakeProfiles{
+				"fanduel":    {MaxStake: 100, StakeIncrement: 1},
+				"draftkings": {StakeIncrement: 1, MinStake: 100},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := detector.NewScalpDetector(basketball_nba.NewConfig(), nil, tt.profiles)
+
+			lakers := moneyline("fanduel", "Los Angeles Lakers", tt.lakers)
+			celtics := moneyline("draftkings", "Boston Celtics", tt.celtics)
+			opportunities, err := d.Detect(context.Background(), lakers, []models.NormalizedOdds{lakers, celtics})
+			if err != nil {
+				t.Fatalf("Detect() error: %v", err)
+			}
+			if tt.wantAmounts == nil {
+				if len(opportunities) != 0 {
+					t.Errorf("expected no scalp, got %+v", opportunities)
+				}
+				return
+			}
+			if len(opportunities) != 1 {
+				t.Fatalf("expected 1 scalp, got %d", len(opportunities))
+			}
+			opportunity := opportunities[0]
+
+			if opportunity.GuaranteedReturn == nil || math.Abs(*opportunity.GuaranteedReturn-tt.wantReturn) > 1e-9 {
+				t.Errorf("guaranteed return = %v, want %.6f", opportunity.GuaranteedReturn, tt.wantReturn)
+			}
+			if tt.wantMaxStake == 0 && opportunity.MaxStake != nil {
+				t.Errorf("expected no max stake, got %v", *opportunity.MaxStake)
+			}
+			if tt.wantMaxStake > 0 && (opportunity.MaxStake == nil || math.Abs(*opportunity.MaxStake-tt.wantMaxStake) > 1e-9) {
+				t.Errorf("max stake = %v, want %v", opportunity.MaxStake, tt.wantMaxStake)
+			}
+
+			stakes := normalized(tt.wantAmounts["fanduel"], tt.wantAmounts["draftkings"])
+			want := map[string]float64{"fanduel": stakes[0], "draftkings": stakes[1]}
+			for _, leg := range opportunity.Legs {
+				if leg.StakeFraction == nil || math.Abs(*leg.StakeFraction-want[leg.BookKey]) > 1e-9 {
+					t.Errorf("%s stake = %v, want %.6f", leg.BookKey, leg.StakeFraction, want[leg.BookKey])
+				}
+			}
+		})
+	}
+}
+
+func TestEdgeDetector_BookProfiles(t *testing.T) {
+	tests := []struct {
+		name         string
+		profiles     fakeProfiles
+		wantEdge     float64 // Percent; 0 = no edge
+		wantMaxStake float64 // 0 = unlimited
+	}{
+		{
+			name:     "posted price",
+			wantEdge: (0.5*2.1 - 1) * 100,
+		},
+		{
+			name:     "commission priced into the edge",
+			profiles: fakeProfiles{"fanduel": {CommissionRate: 0.02}},
+			wantEdge: (0.5*(1+1.1*0.98) - 1) * 100,
+		},
+		{
+			// 5% posted; 10% tax on the gross payout leaves a loss
+			name:     "tax eats the edge",
+			profiles: fakeProfiles{"fanduel": {TaxRate: 0.10, TaxBasis: models.TaxBasisGross}},
+		},
+		{
+			name:         "market limit carried on the edge",
+			profiles:     fakeProfiles{"fanduel": {MaxStake: 500, MarketMaxStakes: map[string]float64{"h2h": 250}}},
+			wantEdge:     (0.5*2.1 - 1) * 100,
+			wantMaxStake: 250,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := detector.NewEdgeDetector(basketball_nba.NewConfig(), sharpBooks{"pinnacle": true}, tt.profiles)
+
+			fair := 0.5
+			sharp := moneyline("pinnacle", "Los Angeles Lakers", -110)
+			sharp.NoVigProbability = &fair
+			soft := moneyline("fanduel", "Los Angeles Lakers", 110)
+
+			opportunities, err := d.Detect(context.Background(), soft, []models.NormalizedOdds{sharp, soft})
+			if err != nil {
+				t.Fatalf("Detect() error: %v", err)
+			}
+			if tt.wantEdge == 0 {
+				if len(opportunities) != 0 {
+					t.Errorf("expected no edge, got %+v", opportunities)
+				}
+				return
+			}
+			if len(opportunities) != 1 {
+				t.Fatalf("expected 1 edge, got %d", len(opportunities))
+			}
+			opportunity := opportunities[0]
+
+			if math.Abs(opportunity.EdgePercent-tt.wantEdge) > 1e-9 {
+				t.Errorf("edge = %.4f%%, want %.4f%%", opportunity.EdgePercent, tt.wantEdge)
+			}
+			if tt.wantMaxStake == 0 && opportunity.MaxStake != nil {
+				t.Errorf("expected no max stake, got %v", *opportunity.MaxStake)
+			}
+			if tt.wantMaxStake > 0 && (opportunity.MaxStake == nil || *opportunity.MaxStake != tt.wantMaxStake) {
+				t.Errorf("max stake = %v, want %v", opportunity.MaxStake, tt.wantMaxStake)
+			}
+		})
+	}
+}
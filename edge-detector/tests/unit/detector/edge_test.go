@@ -0,0 +1,43 @@
+package detector_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/detector"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/sports/basketball_nba"
+)
+
+func TestEdgeDetector_KeepsQuotedPrice(t *testing.T) {
+	d := detector.NewEdgeDetector(basketball_nba.NewConfig(), sharpBooks{"pinnacle": true}, nil)
+
+	noVig := 0.55
+	sharp := moneyline("pinnacle", "Los Angeles Lakers", -125)
+	sharp.NoVigProbability = &noVig
+
+	// 1.95 rounds to -105 (1.952...); the edge must come from the quoted 1.95
+	soft := moneyline("betfair_ex_uk", "Los Angeles Lakers", -105)
+	soft.PriceFormat = "decimal"
+	soft.PriceValue = "1.95"
+	soft.DecimalOdds = 1.95
+	soft.ImpliedProbability = 1 / 1.95
+
+	opportunities, err := d.Detect(context.Background(), soft, []models.NormalizedOdds{sharp, soft})
+	if err != nil {
+		t.Fatalf("Detect() error: %v", err)
+	}
+	if len(opportunities) != 1 {
+		t.Fatalf("expected 1 edge, got %d", len(opportunities))
+	}
+
+	wantEdge := (noVig*1.95 - 1) * 100
+	if math.Abs(opportunities[0].EdgePercent-wantEdge) > 1e-9 {
+		t.Errorf("expected edge %.4f%% at 1.95, got %.4f%%", wantEdge, opportunities[0].EdgePercent)
+	}
+	leg := opportunities[0].Legs[0]
+	if leg.Price != -105 || leg.PriceFormat != "decimal" || leg.PriceValue != "1.95" {
+		t.Errorf("expected leg -105 quoted as decimal 1.95, got %d %q %q", leg.Price, leg.PriceFormat, leg.PriceValue)
+	}
+}
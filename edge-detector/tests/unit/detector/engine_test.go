@@ -0,0 +1,96 @@
+package detector_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/backtest"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/detector"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/lifecycle"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/marketstate"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/contracts"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/sports/basketball_nba"
+)
+
+const slowType models.OpportunityType = "slow"
+
+// slowDetector finds one opportunity per quote after a delay
+type slowDetector struct {
+	delay time.Duration
+}
+
+func (d slowDetector) Detect(ctx context.Context, odds models.NormalizedOdds, marketOdds []models.NormalizedOdds) ([]models.Opportunity, error) {
+	time.Sleep(d.delay)
+	return []models.Opportunity{{
+		OpportunityType: slowType,
+		SportKey:        odds.SportKey,
+		EventID:         odds.EventID,
+		MarketKey:       odds.MarketKey,
+		EdgePercent:     2,
+		Legs:            []models.OpportunityLeg{{BookKey: odds.BookKey, OutcomeName: odds.OutcomeName, Price: odds.Price}},
+	}}, nil
+}
+
+func (d slowDetector) GetType() models.OpportunityType {
+	return slowType
+}
+
+func (d slowDetector) IsEnabled() bool {
+	return true
+}
+
+// slowRegistry has only a detector that overruns its 5ms timeout
+func slowRegistry() *detector.Registry {
+	config := basketball_nba.NewConfig()
+	config.DetectorTimeoutsMs = map[string]int{string(slowType): 5}
+
+	registry := detector.NewRegistry()
+	registry.MustRegister(slowType, func(config contracts.DetectorConfig, sharp contracts.SharpBookProvider) contracts.OpportunityDetector {
+		return slowDetector{delay: 25 * time.Millisecond}
+	})
+	registry.AddSport("basketball_nba", config, sharpBooks{"pinnacle": true})
+	return registry
+}
+
+func TestEngine_DetectorTimeout(t *testing.T) {
+	tests := []struct {
+		name         string
+		newEngine    func(*lifecycle.Tracker, *detector.Registry, *marketstate.Store) *detector.Engine
+		wantTimeouts int64
+		wantDetected int
+	}{
+		{
+			name: "live engine abandons an overrun",
+			newEngine: func(tracker *lifecycle.Tracker, registry *detector.Registry, store *marketstate.Store) *detector.Engine {
+				return detector.NewEngine(nil, tracker, registry, store, nil)
+			},
+			wantTimeouts: 1,
+			wantDetected: 0,
+		},
+		{
+			name:         "replay engine waits for every detector",
+			newEngine:    detector.NewReplayEngine,
+			wantTimeouts: 0,
+			wantDetected: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := lifecycle.NewTracker(lifecycle.DefaultConfig(), backtest.NewMemoryStore(), backtest.NewJournal(nil), nil)
+			engine := tt.newEngine(tracker, slowRegistry(), marketstate.NewStore(marketstate.DefaultConfig()))
+
+			changes := engine.ProcessOdds(context.Background(), "odds.normalized.basketball_nba", moneyline("fanduel", "Los Angeles Lakers", -110))
+
+			stats := engine.GetDetectorStats()[slowType]
+			if stats.Runs != 1 || stats.Timeouts != tt.wantTimeouts {
+				t.Errorf("expected 1 run with %d timeouts, got %d runs with %d timeouts", tt.wantTimeouts, stats.Runs, stats.Timeouts)
+			}
+			if len(changes) != tt.wantDetected {
+				t.Errorf("expected %d opportunities opened, got %d", tt.wantDetected, len(changes))
+			}
+		})
+	}
+}
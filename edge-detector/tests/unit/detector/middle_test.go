@@ -0,0 +1,177 @@
+package detector_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/detector"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/sports/basketball_nba"
+)
+
+// totalsQuote is a book's Over or Under on the game total
+func totalsQuote(bookKey, outcomeName string, point float64, price int) models.NormalizedOdds {
+	return lineQuote("totals", bookKey, outcomeName, point, price)
+}
+
+// spreadsQuote is a book's side of the Celtics-Lakers spread
+func spreadsQuote(bookKey, outcomeName string, point float64, price int) models.NormalizedOdds {
+	return lineQuote("spreads", bookKey, outcomeName, point, price)
+}
+
+// lineQuote is a fresh quote for one side of a line market
+func lineQuote(marketKey, bookKey, outcomeName string, point float64, price int) models.NormalizedOdds {
+	decimal := 1 + 100/float64(-price)
+	if price > 0 {
+		decimal = 1 + float64(price)/100
+	}
+	return models.NormalizedOdds{
+		EventID:            "event-1",
+		SportKey:           "basketball_nba",
+		MarketKey:          marketKey,
+		BookKey:            bookKey,
+		OutcomeName:        outcomeName,
+		Price:              price,
+		Point:              &point,
+		DecimalOdds:        decimal,
+		ImpliedProbability: 1 / decimal,
+		ReceivedAt:         time.Now(),
+	}
+}
+
+func TestMiddleDetector_LandingProbabilities(t *testing.T) {
+	tests := []struct {
+		name       string
+		marketKey  string
+		low        models.NormalizedOdds // Wins above its line
+		high       models.NormalizedOdds // Wins below its line
+		sharp      models.NormalizedOdds // Centers the distribution
+		bothWin    []int                 // Finals inside the window
+		push       []int                 // Finals landing on a whole-number line
+		wantMiddle bool
+	}{
+		{
+			name:       "totals three points wide",
+			marketKey:  "totals",
+			low:        totalsQuote("fanduel", "Over", 220.5, 100),
+			high:       totalsQuote("draftkings", "Under", 223.5, 100),
+			sharp:      totalsQuote("pinnacle", "Over", 222, -110),
+			bothWin:    []int{221, 222, 223},
+			wantMiddle: true,
+		},
+		{
+			name:       "totals pushing on a whole-number line",
+			marketKey:  "totals",
+			low:        totalsQuote("fanduel", "Over", 220, 100),
+			high:       totalsQuote("draftkings", "Under", 223.5, 100),
+			sharp:      totalsQuote("pinnacle", "Over", 222, -110),
+			bothWin:    []int{221, 222, 223},
+			push:       []int{220},
+			wantMiddle: true,
+		},
+		{
+			// Margins are the Celtics' (first by name): -2 covers above 2, Lakers +4 below 4, and each pushes on its line
+			name:       "spreads across favorite and underdog",
+			marketKey:  "spreads",
+			low:        spreadsQuote("draftkings", "Boston Celtics", -2, 100),
+			high:       spreadsQuote("fanduel", "Los Angeles Lakers", 4, 100),
+			sharp:      spreadsQuote("pinnacle", "Boston Celtics", -3, -110),
+			bothWin:    []int{3},
+			push:       []int{2, 4},
+			wantMiddle: true,
+		},
+		{
+			// Both +2 win on margins -1, 0 and 1, but an NBA game can't end tied
+			name:       "spreads across a pick'em",
+			marketKey:  "spreads",
+			low:        spreadsQuote("draftkings", "Boston Celtics", 2, 100),
+			high:       spreadsQuote("fanduel", "Los Angeles Lakers", 2, 100),
+			sharp:      spreadsQuote("pinnacle", "Boston Celtics", 0, -110),
+			bothWin:    []int{-1, 1},
+			push:       []int{-2, 2},
+			wantMiddle: true,
+		},
+		{
+			name:      "crossed lines",
+			marketKey: "totals",
+			low:       totalsQuote("fanduel", "Over", 223.5, 100),
+			high:      totalsQuote("draftkings", "Under", 220.5, 100),
+			sharp:     totalsQuote("pinnacle", "Over", 222, -110),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := basketball_nba.NewConfig()
+			d := detector.NewMiddleDetector(config, sharpBooks{"pinnacle": true})
+
+			opportunities, err := d.Detect(context.Background(), tt.high, []models.NormalizedOdds{tt.sharp, tt.low, tt.high})
+			if err != nil {
+				t.Fatalf("Detect() error: %v", err)
+			}
+			if !tt.wantMiddle {
+				if len(opportunities) != 0 {
+					t.Errorf("expected no middle, got %+v", opportunities)
+				}
+				return
+			}
+			if len(opportunities) != 1 {
+				t.Fatalf("expected 1 middle, got %d", len(opportunities))
+			}
+			opportunity := opportunities[0]
+
+			// Expected mass straight from the sport's distribution around the sharp line
+			dist := config.GetScoreDistribution(tt.marketKey)
+			center := *tt.sharp.Point
+			if tt.marketKey == "spreads" {
+				center = -center
+			}
+			var total, wantBoth, wantPush float64
+			lo, hi := dist.Support(center)
+			for final := lo; final <= hi; final++ {
+				total += dist.Probability(final, center)
+			}
+			for _, final := range tt.bothWin {
+				wantBoth += dist.Probability(final, center)
+			}
+			for _, final := range tt.push {
+				wantPush += dist.Probability(final, center)
+			}
+			wantOne := total - wantBoth - wantPush
+
+			for name, got := range map[string]struct {
+				value *float64
+				want  float64
+			}{
+				"both win": {opportunity.MiddleBothWinProb, wantBoth},
+				"one win":  {opportunity.MiddleOneWinProb, wantOne},
+				"push":     {opportunity.MiddlePushProb, wantPush},
+			} {
+				if got.value == nil {
+					t.Errorf("%s probability not set", name)
+				} else if math.Abs(*got.value-got.want) > 1e-9 {
+					t.Errorf("%s probability = %.6f, want %.6f", name, *got.value, got.want)
+				}
+			}
+
+			// Even money: a lone win breaks even and a push refunds one leg while the other wins
+			wantEdge := (wantBoth + wantPush/2) * 100
+			if math.Abs(opportunity.EdgePercent-wantEdge) > 1e-9 {
+				t.Errorf("edge = %.4f%%, want %.4f%%", opportunity.EdgePercent, wantEdge)
+			}
+			width := *tt.high.Point - *tt.low.Point
+			if tt.marketKey == "spreads" {
+				width = *tt.high.Point + *tt.low.Point
+			}
+			if leg := opportunity.Legs[0]; leg.MiddleWidth == nil || *leg.MiddleWidth != width {
+				t.Errorf("width = %v, want %v", leg.MiddleWidth, width)
+			}
+			if opportunity.Legs[0].BookKey != tt.low.BookKey || opportunity.Legs[1].BookKey != tt.high.BookKey {
+				t.Errorf("expected legs low %s then high %s, got %s then %s",
+					tt.low.BookKey, tt.high.BookKey, opportunity.Legs[0].BookKey, opportunity.Legs[1].BookKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package detector_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/detector"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/sports/basketball_nba"
+)
+
+// propQuote is a book's player points quote for one side of a line
+func propQuote(bookKey, player, outcomeName string, point float64, price int) models.NormalizedOdds {
+	decimal := 1 + 100/float64(-price)
+	if price > 0 {
+		decimal = 1 + float64(price)/100
+	}
+	return models.NormalizedOdds{
+		EventID:            "event-1",
+		SportKey:           "basketball_nba",
+		MarketKey:          "player_points",
+		BookKey:            bookKey,
+		Description:        player,
+		OutcomeName:        outcomeName,
+		Price:              price,
+		Point:              &point,
+		DecimalOdds:        decimal,
+		ImpliedProbability: 1 / decimal,
+		ReceivedAt:         time.Now(),
+	}
+}
+
+// devigged returns the first outcome's multiplicative no-vig probability
+func devigged(price, otherPrice int) float64 {
+	p := 1 / propQuote("", "", "", 0, price).DecimalOdds
+	q := 1 / propQuote("", "", "", 0, otherPrice).DecimalOdds
+	return p / (p + q)
+}
+
+func TestPropDetector_TwoSidedPairing(t *testing.T) {
+	t.Setenv("ENABLE_PLAYER_PROPS", "true")
+
+	soft := propQuote("fanduel", "LeBron James", "Over", 24.5, 120)
+
+	tests := []struct {
+		name     string
+		sharp    []models.NormalizedOdds
+		wantFair float64 // 0 = no opportunity
+	}{
+		{
+			name: "one sharp book quoting both sides",
+			sharp: []models.NormalizedOdds{
+				propQuote("pinnacle", "LeBron James", "Over", 24.5, -110),
+				propQuote("pinnacle", "LeBron James", "Under", 24.5, -110),
+			},
+			wantFair: 0.5,
+		},
+		{
+			name: "two sharp books averaged",
+			sharp: []models.NormalizedOdds{
+				propQuote("pinnacle", "LeBron James", "Over", 24.5, -110),
+				propQuote("pinnacle", "LeBron James", "Under", 24.5, -110),
+				propQuote("circa", "LeBron James", "Over", 24.5, -130),
+				propQuote("circa", "LeBron James", "Under", 24.5, 110),
+			},
+			wantFair: (0.5 + devigged(-130, 110)) / 2,
+		},
+		{
+			name: "one-sided book skipped",
+			sharp: []models.NormalizedOdds{
+				propQuote("pinnacle", "LeBron James", "Over", 24.5, -110),
+				propQuote("pinnacle", "LeBron James", "Under", 24.5, -110),
+				propQuote("circa", "LeBron James", "Over", 24.5, -200),
+			},
+			wantFair: 0.5,
+		},
+		{
+			name: "only one side quoted",
+			sharp: []models.NormalizedOdds{
+				propQuote("pinnacle", "LeBron James", "Over", 24.5, -110),
+			},
+		},
+		{
+			name: "sides paired across players",
+			sharp: []models.NormalizedOdds{
+				propQuote("pinnacle", "LeBron James", "Over", 24.5, -110),
+				propQuote("pinnacle", "Anthony Davis", "Under", 24.5, -110),
+			},
+		},
+		{
+			name: "sides paired across points",
+			sharp: []models.NormalizedOdds{
+				propQuote("pinnacle", "LeBron James", "Over", 24.5, -110),
+				propQuote("pinnacle", "LeBron James", "Under", 25.5, -110),
+			},
+		},
+		{
+			name: "another player's two-sided line",
+			sharp: []models.NormalizedOdds{
+				propQuote("pinnacle", "Anthony Davis", "Over", 24.5, -110),
+				propQuote("pinnacle", "Anthony Davis", "Under", 24.5, -110),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := detector.NewPropDetector(basketball_nba.NewConfig(), sharpBooks{"pinnacle": true, "circa": true}, nil)
+
+			marketOdds := append([]models.NormalizedOdds{soft}, tt.sharp...)
+			opportunities, err := d.Detect(context.Background(), soft, marketOdds)
+			if err != nil {
+				t.Fatalf("Detect() error: %v", err)
+			}
+			if tt.wantFair == 0 {
+				if len(opportunities) != 0 {
+					t.Errorf("expected no prop without a two-sided sharp price, got %+v", opportunities)
+				}
+				return
+			}
+			if len(opportunities) != 1 {
+				t.Fatalf("expected 1 prop, got %d", len(opportunities))
+			}
+
+			opportunity := opportunities[0]
+			wantEdge := (tt.wantFair*soft.DecimalOdds - 1) * 100
+			if math.Abs(opportunity.EdgePercent-wantEdge) > 1e-9 {
+				t.Errorf("edge = %.4f%%, want %.4f%%", opportunity.EdgePercent, wantEdge)
+			}
+			leg := opportunity.Legs[0]
+			if leg.PlayerName != "LeBron James" || leg.Stat != "points" {
+				t.Errorf("expected LeBron James points leg, got %q %q", leg.PlayerName, leg.Stat)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package detector_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/backtest"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/detector"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/lifecycle"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/marketstate"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/contracts"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/sports/basketball_nba"
+)
+
+const customType models.OpportunityType = "custom"
+
+// customDetector is a detector registered from outside the package
+type customDetector struct{}
+
+func (customDetector) Detect(ctx context.Context, odds models.NormalizedOdds, marketOdds []models.NormalizedOdds) ([]models.Opportunity, error) {
+	return nil, nil
+}
+
+func (customDetector) GetType() models.OpportunityType {
+	return customType
+}
+
+func (customDetector) IsEnabled() bool {
+	return true
+}
+
+func newCustomDetector(config contracts.DetectorConfig, sharp contracts.SharpBookProvider) contracts.OpportunityDetector {
+	return customDetector{}
+}
+
+func TestRegistry_DetectorTimeouts(t *testing.T) {
+	tests := []struct {
+		name      string
+		defaultMs int
+		overrides map[string]int
+		want      map[models.OpportunityType]time.Duration
+	}{
+		{
+			name:      "sport default",
+			defaultMs: 100,
+			want: map[models.OpportunityType]time.Duration{
+				models.OpportunityTypeEdge:      100 * time.Millisecond,
+				models.OpportunityTypeStaleLine: 100 * time.Millisecond,
+			},
+		},
+		{
+			name:      "per-type override",
+			defaultMs: 100,
+			overrides: map[string]int{"stale_line": 250},
+			want: map[models.OpportunityType]time.Duration{
+				models.OpportunityTypeEdge:      100 * time.Millisecond,
+				models.OpportunityTypeStaleLine: 250 * time.Millisecond,
+			},
+		},
+		{
+			name:      "unset falls back to the package default",
+			defaultMs: 0,
+			overrides: map[string]int{"stale_line": -1},
+			want: map[models.OpportunityType]time.Duration{
+				models.OpportunityTypeEdge:      detector.DefaultDetectorTimeout,
+				models.OpportunityTypeStaleLine: detector.DefaultDetectorTimeout,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := basketball_nba.NewConfig()
+			config.DetectorTimeoutMs = tt.defaultMs
+			config.DetectorTimeoutsMs = tt.overrides
+
+			registry := detector.NewDefaultRegistry(nil, nil)
+			registry.AddSport("basketball_nba", config, sharpBooks{"pinnacle": true})
+			for opportunityType, want := range tt.want {
+				registered, ok := registry.Get("basketball_nba", opportunityType)
+				if !ok {
+					t.Fatalf("%s not built", opportunityType)
+				}
+				if registered.Timeout != want {
+					t.Errorf("%s timeout = %v, want %v", opportunityType, registered.Timeout, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRegistry_Register(t *testing.T) {
+	registry := detector.NewDefaultRegistry(nil, nil)
+	if err := registry.Register(models.OpportunityTypeEdge, newCustomDetector); err == nil {
+		t.Error("expected an error registering a second edge detector")
+	}
+	if err := registry.Register(customType, newCustomDetector); err != nil {
+		t.Fatalf("Register() error: %v", err)
+	}
+
+	registry.AddSport("basketball_nba", basketball_nba.NewConfig(), sharpBooks{"pinnacle": true})
+
+	var types []models.OpportunityType
+	for _, registered := range registry.ForSport("basketball_nba") {
+		types = append(types, registered.Detector.GetType())
+	}
+	for i := 1; i < len(types); i++ {
+		if types[i-1] >= types[i] {
+			t.Fatalf("expected detectors ordered by type, got %v", types)
+		}
+	}
+	if _, ok := registry.Get("basketball_nba", customType); !ok {
+		t.Errorf("expected the custom detector built alongside the built-ins, got %v", types)
+	}
+	if sports := registry.Sports(); len(sports) != 1 || sports[0] != "basketball_nba" {
+		t.Errorf("Sports() = %v, want [basketball_nba]", sports)
+	}
+}
+
+func TestRegistry_DetectorEnabledLive(t *testing.T) {
+	config := basketball_nba.NewConfig()
+	scalps := false
+	config.ApplySettings(&models.DetectorSettings{SportKey: "basketball_nba", EnableScalps: &scalps})
+
+	// Turned off at startup, but still built
+	registry := detector.NewDefaultRegistry(nil, nil)
+	types := registry.AddSport("basketball_nba", config, sharpBooks{"pinnacle": true})
+	registered, ok := registry.Get("basketball_nba", models.OpportunityTypeScalp)
+	if !ok {
+		t.Fatalf("expected scalp built while disabled, got %v", types)
+	}
+	if registered.Enabled() {
+		t.Error("expected scalp disabled")
+	}
+
+	tracker := lifecycle.NewTracker(lifecycle.DefaultConfig(), backtest.NewMemoryStore(), backtest.NewJournal(nil), nil)
+	engine := detector.NewEngine(nil, tracker, registry, marketstate.NewStore(marketstate.DefaultConfig()), nil)
+	lakers := moneyline("fanduel", "Los Angeles Lakers", 110)
+
+	ctx := context.Background()
+	engine.ProcessOdds(ctx, "odds.normalized.basketball_nba", lakers)
+	if runs := engine.GetDetectorStats()[models.OpportunityTypeScalp].Runs; runs != 0 {
+		t.Fatalf("expected disabled scalp not to run, ran %d times", runs)
+	}
+
+	// detector_config turns scalps on from the next message
+	scalps = true
+	config.ApplySettings(&models.DetectorSettings{SportKey: "basketball_nba", EnableScalps: &scalps})
+
+	engine.ProcessOdds(ctx, "odds.normalized.basketball_nba", lakers)
+	if runs := engine.GetDetectorStats()[models.OpportunityTypeScalp].Runs; runs != 1 {
+		t.Errorf("expected scalp to run once enabled, ran %d times", runs)
+	}
+}
@@ -0,0 +1,260 @@
+package detector_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/detector"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/sports/basketball_nba"
+)
+
+// offer is one book's current price on an outcome
+func offer(bookKey, marketKey, outcomeName string, point *float64, price int) models.NormalizedOdds {
+	decimal := 1 + 100/float64(-price)
+	if price > 0 {
+		decimal = 1 + float64(price)/100
+	}
+	return models.NormalizedOdds{
+		EventID:            "event-1",
+		SportKey:           "basketball_nba",
+		MarketKey:          marketKey,
+		BookKey:            bookKey,
+		OutcomeName:        outcomeName,
+		Price:              price,
+		Point:              point,
+		DecimalOdds:        decimal,
+		ImpliedProbability: 1 / decimal,
+		ReceivedAt:         time.Now(),
+	}
+}
+
+func TestSolveArbitrage(t *testing.T) {
+	tests := []struct {
+		name       string
+		returns    [][]float64
+		wantStakes []float64
+		wantReturn float64
+		wantOK     bool
+	}{
+		{
+			name:       "two-way at even odds",
+			returns:    [][]float64{{2.10, 0}, {0, 2.10}},
+			wantStakes: []float64{0.5, 0.5},
+			wantReturn: 1.05,
+			wantOK:     true,
+		},
+		{
+			name:       "two-way staked toward the shorter price",
+			returns:    [][]float64{{2.20, 0}, {0, 1.90}},
+			wantStakes: []float64{1.90 / 4.10, 2.20 / 4.10},
+			wantReturn: 2.20 * 1.90 / 4.10,
+			wantOK:     true,
+		},
+		{
+			name:       "three-way",
+			returns:    [][]float64{{3.5, 0, 0}, {0, 3.8, 0}, {0, 0, 2.6}},
+			wantStakes: inverseShares(3.5, 3.8, 2.6),
+			wantReturn: 1 / (1/3.5 + 1/3.8 + 1/2.6),
+			wantOK:     true,
+		},
+		{
+			// Home draw no bet is refunded on a draw: 1/1.8 covers home, the draw
+			// stake covers what's left of the draw state, 1/2.6 covers away
+			name:       "draw no bet refunded on a draw",
+			returns:    [][]float64{{1.8, 0, 0}, {1, 4.0, 0}, {0, 0, 2.6}},
+			wantStakes: normalized(1/1.8, (1-1/1.8)/4.0, 1/2.6),
+			wantReturn: 1 / (1/1.8 + (1-1/1.8)/4.0 + 1/2.6),
+			wantOK:     true,
+		},
+		{
+			name:    "legs winning the same state",
+			returns: [][]float64{{2.0, 2.0}, {0, 0}},
+		},
+		{
+			name:    "single leg",
+			returns: [][]float64{{2.0}},
+		},
+		{
+			name:    "ragged matrix",
+			returns: [][]float64{{2.0, 0}, {0}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stakes, guaranteedReturn, ok := detector.SolveArbitrage(tt.returns)
+			if ok != tt.wantOK {
+				t.Fatalf("SolveArbitrage() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+
+			if math.Abs(guaranteedReturn-tt.wantReturn) > 1e-9 {
+				t.Errorf("guaranteed return = %.6f, want %.6f", guaranteedReturn, tt.wantReturn)
+			}
+			sum := 0.0
+			for i, stake := range stakes {
+				sum += stake
+				if math.Abs(stake-tt.wantStakes[i]) > 1e-9 {
+					t.Errorf("stake %d = %.6f, want %.6f", i, stake, tt.wantStakes[i])
+				}
+			}
+			if math.Abs(sum-1) > 1e-9 {
+				t.Errorf("stakes sum to %.9f, want 1", sum)
+			}
+
+			// Every state pays back the same
+			for state, row := range tt.returns {
+				payback := 0.0
+				for leg, r := range row {
+					payback += stakes[leg] * r
+				}
+				if math.Abs(payback-guaranteedReturn) > 1e-9 {
+					t.Errorf("state %d pays %.6f, want %.6f", state, payback, guaranteedReturn)
+				}
+			}
+		})
+	}
+}
+
+// fakeMarkets serves an event's other markets to the scalp detector
+type fakeMarkets map[string][]models.NormalizedOdds
+
+func (m fakeMarkets) GetMarketOdds(eventID, marketKey string) []models.NormalizedOdds {
+	return m[marketKey]
+}
+
+func TestScalpDetector_StakesAndGuaranteedReturn(t *testing.T) {
+	total, pickEm := 220.5, 0.0
+	lakersML := offer("fanduel", "h2h", "Los Angeles Lakers", nil, 110)
+	celticsML := offer("draftkings", "h2h", "Boston Celtics", nil, 110)
+	over := offer("fanduel", "totals", "Over", &total, 120)
+	under := offer("draftkings", "totals", "Under", &total, -105)
+	lakersPickEm := offer("betmgm", "spreads", "Los Angeles Lakers", &pickEm, 115)
+
+	tests := []struct {
+		name       string
+		trigger    models.NormalizedOdds
+		marketOdds []models.NormalizedOdds
+		markets    fakeMarkets
+		wantStakes map[string]float64 // book -> stake fraction; nil = no scalp
+	}{
+		{
+			name:       "moneyline across two books",
+			trigger:    lakersML,
+			marketOdds: []models.NormalizedOdds{lakersML, celticsML},
+			wantStakes: map[string]float64{"fanduel": 0.5, "draftkings": 0.5},
+		},
+		{
+			name:       "totals at the same point",
+			trigger:    under,
+			marketOdds: []models.NormalizedOdds{over, under},
+			wantStakes: stakesByBook(map[string]float64{"fanduel": over.DecimalOdds, "draftkings": under.DecimalOdds}),
+		},
+		{
+			name:       "spread 0 against the moneyline",
+			trigger:    lakersPickEm,
+			marketOdds: []models.NormalizedOdds{lakersPickEm},
+			markets:    fakeMarkets{"h2h": {celticsML}},
+			wantStakes: stakesByBook(map[string]float64{"betmgm": lakersPickEm.DecimalOdds, "draftkings": celticsML.DecimalOdds}),
+		},
+		{
+			name:       "no arbitrage at standard juice",
+			trigger:    offer("fanduel", "h2h", "Los Angeles Lakers", nil, -110),
+			marketOdds: []models.NormalizedOdds{offer("fanduel", "h2h", "Los Angeles Lakers", nil, -110), offer("draftkings", "h2h", "Boston Celtics", nil, -110)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := detector.NewScalpDetector(basketball_nba.NewConfig(), tt.markets, nil)
+
+			opportunities, err := d.Detect(context.Background(), tt.trigger, tt.marketOdds)
+			if err != nil {
+				t.Fatalf("Detect() error: %v", err)
+			}
+			if tt.wantStakes == nil {
+				if len(opportunities) != 0 {
+					t.Errorf("expected no scalp, got %+v", opportunities)
+				}
+				return
+			}
+			if len(opportunities) != 1 {
+				t.Fatalf("expected 1 scalp, got %d", len(opportunities))
+			}
+			opportunity := opportunities[0]
+
+			// Equal payback whichever leg wins
+			var wantReturn float64
+			for _, leg := range opportunity.Legs {
+				want, ok := tt.wantStakes[leg.BookKey]
+				if !ok || leg.StakeFraction == nil {
+					t.Fatalf("unexpected leg %+v", leg)
+				}
+				if math.Abs(*leg.StakeFraction-want) > 1e-9 {
+					t.Errorf("%s stake = %.6f, want %.6f", leg.BookKey, *leg.StakeFraction, want)
+				}
+				wantReturn = *leg.StakeFraction * decimalFor(leg, tt.marketOdds, tt.markets)
+			}
+			if opportunity.GuaranteedReturn == nil || math.Abs(*opportunity.GuaranteedReturn-wantReturn) > 1e-9 {
+				t.Errorf("guaranteed return = %v, want %.6f", opportunity.GuaranteedReturn, wantReturn)
+			}
+			if math.Abs(opportunity.EdgePercent-(wantReturn-1)*100) > 1e-9 {
+				t.Errorf("edge = %.4f%%, want %.4f%%", opportunity.EdgePercent, (wantReturn-1)*100)
+			}
+		})
+	}
+}
+
+// inverseShares returns stake fractions proportional to 1/decimal
+func inverseShares(decimals ...float64) []float64 {
+	inverses := make([]float64, len(decimals))
+	for i, decimal := range decimals {
+		inverses[i] = 1 / decimal
+	}
+	return normalized(inverses...)
+}
+
+// normalized scales values to sum to 1
+func normalized(values ...float64) []float64 {
+	total := 0.0
+	for _, value := range values {
+		total += value
+	}
+	result := make([]float64, len(values))
+	for i, value := range values {
+		result[i] = value / total
+	}
+	return result
+}
+
+// stakesByBook returns a two-outcome scalp's stake fraction per book
+func stakesByBook(decimals map[string]float64) map[string]float64 {
+	total := 0.0
+	for _, decimal := range decimals {
+		total += 1 / decimal
+	}
+	stakes := make(map[string]float64, len(decimals))
+	for bookKey, decimal := range decimals {
+		stakes[bookKey] = (1 / decimal) / total
+	}
+	return stakes
+}
+
+// decimalFor finds the decimal odds a leg was quoted at
+func decimalFor(leg models.OpportunityLeg, marketOdds []models.NormalizedOdds, markets fakeMarkets) float64 {
+	quotes := append([]models.NormalizedOdds{}, marketOdds...)
+	for _, other := range markets {
+		quotes = append(quotes, other...)
+	}
+	for _, quote := range quotes {
+		if quote.BookKey == leg.BookKey && quote.OutcomeName == leg.OutcomeName {
+			return quote.DecimalOdds
+		}
+	}
+	return 0
+}
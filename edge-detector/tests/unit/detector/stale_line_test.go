@@ -0,0 +1,226 @@
+package detector_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/detector"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/sports/basketball_nba"
+)
+
+// sharpBooks is a SharpBookProvider with a fixed sharp set, equally weighted in every market
+// The consensus is the plain average of each sharp quote's no-vig (else implied) probability.
+type sharpBooks map[string]bool
+
+func (s sharpBooks) GetSharpBooks(ctx context.Context, sportKey string) ([]string, error) {
+	var books []string
+	for bookKey := range s {
+		books = append(books, bookKey)
+	}
+	return books, nil
+}
+
+func (s sharpBooks) IsSharpBook(bookKey string) bool {
+	return s[bookKey]
+}
+
+func (s sharpBooks) IsSharpBookForMarket(bookKey, marketKey string) bool {
+	return s[bookKey]
+}
+
+func (s sharpBooks) GetSharpWeight(bookKey, marketKey string) float64 {
+	if s[bookKey] {
+		return 1
+	}
+	return 0
+}
+
+func (s sharpBooks) GetSharpConsensus(ctx context.Context, marketOdds []models.NormalizedOdds) (map[string]float64, error) {
+	sums := make(map[string]float64)
+	counts := make(map[string]float64)
+	for _, odds := range marketOdds {
+		if !s[odds.BookKey] {
+			continue
+		}
+		prob := odds.ImpliedProbability
+		if odds.NoVigProbability != nil {
+			prob = *odds.NoVigProbability
+		}
+		sums[odds.OutcomeName] += prob
+		counts[odds.OutcomeName]++
+	}
+	if len(sums) == 0 {
+		return nil, fmt.Errorf("no sharp books in market")
+	}
+
+	consensus := make(map[string]float64, len(sums))
+	for outcome, sum := range sums {
+		consensus[outcome] = sum / counts[outcome]
+	}
+	return consensus, nil
+}
+
+var moveStart = time.Date(2025, 1, 10, 19, 0, 0, 0, time.UTC)
+
+// lakersSpread is a book's Lakers spread quote last updated secondsIn after moveStart
+// A noVig above 0 marks it as a devigged sharp quote.
+func lakersSpread(bookKey string, point float64, price int, noVig float64, secondsIn int) models.NormalizedOdds {
+	decimal := 1 + 100/float64(-price)
+	if price > 0 {
+		decimal = 1 + float64(price)/100
+	}
+
+	odds := models.NormalizedOdds{
+		EventID:            "event-1",
+		SportKey:           "basketball_nba",
+		MarketKey:          "spreads",
+		BookKey:            bookKey,
+		OutcomeName:        "Los Angeles Lakers",
+		Price:              price,
+		Point:              &point,
+		DecimalOdds:        decimal,
+		ImpliedProbability: 1 / decimal,
+		VendorLastUpdate:   moveStart.Add(time.Duration(secondsIn) * time.Second),
+		ReceivedAt:         time.Now(),
+	}
+	if noVig > 0 {
+		odds.NoVigProbability = &noVig
+	}
+	return odds
+}
+
+func TestStaleLineDetector_Detect(t *testing.T) {
+	tests := []struct {
+		name      string
+		sharpTo   int // Pinnacle's price 30s in, moving from -110 at -3.5
+		soft      models.NormalizedOdds
+		wantCents int // 0 = not stale
+		wantLag   int
+	}{
+		{
+			name:      "soft book missed a 15 cent move",
+			sharpTo:   -125,
+			soft:      lakersSpread("fanduel", -3.5, -110, 0, -20),
+			wantCents: 15,
+			wantLag:   50,
+		},
+		{
+			name:    "soft book updated after the move began",
+			sharpTo: -125,
+			soft:    lakersSpread("fanduel", -3.5, -110, 0, 30),
+		},
+		{
+			name:    "move under the minimum",
+			sharpTo: -115,
+			soft:    lakersSpread("fanduel", -3.5, -110, 0, -20),
+		},
+		{
+			name:    "soft quote at another point",
+			sharpTo: -125,
+			soft:    lakersSpread("fanduel", -4.5, -110, 0, -20),
+		},
+		{
+			name:    "stale price no longer beats the consensus",
+			sharpTo: -125,
+			soft:    lakersSpread("fanduel", -3.5, -140, 0, -20),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := detector.NewStaleLineDetector(basketball_nba.NewConfig(), sharpBooks{"pinnacle": true})
+			ctx := context.Background()
+
+			before := lakersSpread("pinnacle", -3.5, -110, 0.50, 0)
+			if _, err := d.Detect(ctx, before, []models.NormalizedOdds{before, tt.soft}); err != nil {
+				t.Fatalf("Detect() error: %v", err)
+			}
+
+			after := lakersSpread("pinnacle", -3.5, tt.sharpTo, 0.55, 30)
+			opportunities, err := d.Detect(ctx, after, []models.NormalizedOdds{after, tt.soft})
+			if err != nil {
+				t.Fatalf("Detect() error: %v", err)
+			}
+
+			if tt.wantCents == 0 {
+				if len(opportunities) != 0 {
+					t.Errorf("expected no stale line, got %+v", opportunities)
+				}
+				return
+			}
+			if len(opportunities) != 1 {
+				t.Fatalf("expected 1 stale line, got %d", len(opportunities))
+			}
+			opportunity := opportunities[0]
+			if opportunity.SharpMoveCents == nil || *opportunity.SharpMoveCents != tt.wantCents {
+				t.Errorf("sharp move = %v cents, want %d", opportunity.SharpMoveCents, tt.wantCents)
+			}
+			if opportunity.LagSeconds == nil || *opportunity.LagSeconds != tt.wantLag {
+				t.Errorf("lag = %v seconds, want %d", opportunity.LagSeconds, tt.wantLag)
+			}
+			if leg := opportunity.Legs[0]; leg.BookKey != tt.soft.BookKey || leg.Price != tt.soft.Price {
+				t.Errorf("expected the %s %d leg, got %s %d", tt.soft.BookKey, tt.soft.Price, leg.BookKey, leg.Price)
+			}
+
+			// The same move isn't reported twice
+			again := lakersSpread("pinnacle", -3.5, tt.sharpTo, 0.55, 40)
+			opportunities, err = d.Detect(ctx, again, []models.NormalizedOdds{again, tt.soft})
+			if err != nil {
+				t.Fatalf("Detect() error: %v", err)
+			}
+			if len(opportunities) != 0 {
+				t.Errorf("expected the stale quote flagged once per move, got %+v", opportunities)
+			}
+		})
+	}
+}
+
+func TestStaleLineDetector_SoftQuoteAfterMove(t *testing.T) {
+	d := detector.NewStaleLineDetector(basketball_nba.NewConfig(), sharpBooks{"pinnacle": true})
+	ctx := context.Background()
+
+	before := lakersSpread("pinnacle", -3.5, -110, 0.50, 0)
+	after := lakersSpread("pinnacle", -3.5, -125, 0.55, 30)
+	d.Detect(ctx, before, []models.NormalizedOdds{before})
+	d.Detect(ctx, after, []models.NormalizedOdds{after})
+
+	// A soft quote arriving late but last updated before the move is checked against it
+	soft := lakersSpread("fanduel", -3.5, -110, 0, -20)
+	opportunities, err := d.Detect(ctx, soft, []models.NormalizedOdds{after, soft})
+	if err != nil {
+		t.Fatalf("Detect() error: %v", err)
+	}
+	if len(opportunities) != 1 || opportunities[0].Legs[0].BookKey != "fanduel" {
+		t.Errorf("expected fanduel flagged stale, got %+v", opportunities)
+	}
+}
+
+func TestStaleLineDetector_ConsensusAtSoftPoint(t *testing.T) {
+	d := detector.NewStaleLineDetector(basketball_nba.NewConfig(), sharpBooks{"pinnacle": true})
+	ctx := context.Background()
+
+	// Pinnacle moves the main line 15 cents; its alternate line is much longer
+	before := lakersSpread("pinnacle", -3.5, -110, 0.50, 0)
+	after := lakersSpread("pinnacle", -3.5, -125, 0.55, 30)
+	alternate := lakersSpread("pinnacle", -5.5, 120, 0.44, 30)
+	soft := lakersSpread("fanduel", -3.5, -110, 0, -20)
+
+	d.Detect(ctx, before, []models.NormalizedOdds{before, soft})
+	opportunities, err := d.Detect(ctx, after, []models.NormalizedOdds{after, alternate, soft})
+	if err != nil {
+		t.Fatalf("Detect() error: %v", err)
+	}
+	if len(opportunities) != 1 {
+		t.Fatalf("expected 1 stale line, got %d", len(opportunities))
+	}
+
+	// Priced against -3.5 alone, not averaged with the -5.5 consensus
+	wantEdge := (0.55/soft.ImpliedProbability - 1) * 100
+	if math.Abs(opportunities[0].EdgePercent-wantEdge) > 1e-9 {
+		t.Errorf("edge = %.4f%%, want %.4f%% from the -3.5 consensus", opportunities[0].EdgePercent, wantEdge)
+	}
+}
@@ -0,0 +1,444 @@
+package lifecycle_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/lifecycle"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/writer"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+)
+
+// fakeStore keeps one open row per fingerprint and records every call
+type fakeStore struct {
+	mu      sync.Mutex
+	nextID  int64
+	open    map[string]models.Opportunity // fingerprint -> open row
+	touches []int64
+	closes  map[int64]string // row ID -> close reason
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		open:   make(map[string]models.Opportunity),
+		closes: make(map[int64]string),
+	}
+}
+
+func (s *fakeStore) UpsertOpportunity(ctx context.Context, opportunity models.Opportunity) (writer.UpsertResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.open[opportunity.Fingerprint]; ok {
+		peak := existing.PeakEdgePercent
+		if opportunity.EdgePercent > peak {
+			peak = opportunity.EdgePercent
+		}
+		opportunity.ID = existing.ID
+		opportunity.FirstSeenAt = existing.FirstSeenAt
+		opportunity.PeakEdgePercent = peak
+		s.open[opportunity.Fingerprint] = opportunity
+		return writer.UpsertResult{ID: existing.ID, FirstSeenAt: existing.FirstSeenAt, PeakEdgePercent: peak}, nil
+	}
+
+	s.nextID++
+	opportunity.ID = s.nextID
+	s.open[opportunity.Fingerprint] = opportunity
+	return writer.UpsertResult{ID: s.nextID, Inserted: true, FirstSeenAt: opportunity.FirstSeenAt, PeakEdgePercent: opportunity.EdgePercent}, nil
+}
+
+func (s *fakeStore) TouchOpportunity(ctx context.Context, id int64, lastSeenAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.touches = append(s.touches, id)
+	return nil
+}
+
+func (s *fakeStore) CloseOpportunity(ctx context.Context, id int64, closedAt time.Time, reason string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closes[id] = reason
+	for fingerprint, opportunity := range s.open {
+		if opportunity.ID == id {
+			delete(s.open, fingerprint)
+		}
+	}
+	return nil
+}
+
+func (s *fakeStore) GetOpenOpportunities(ctx context.Context, sportKey string) ([]models.Opportunity, error) {
+	return nil, nil
+}
+
+// fakePublisher records published opportunities and lifecycle events
+type fakePublisher struct {
+	mu        sync.Mutex
+	published int
+	events    []models.LifecycleEvent
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, opportunity models.Opportunity) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.published++
+	return nil
+}
+
+func (p *fakePublisher) PublishEvent(ctx context.Context, event models.LifecycleEvent, opportunity models.Opportunity) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+	return nil
+}
+
+var detectedAt = time.Date(2025, 1, 10, 19, 0, 0, 0, time.UTC)
+
+// scalp is a two-leg h2h scalp at the given edge and prices
+func scalp(edge float64, lakersPrice, celticsPrice int) models.Opportunity {
+	return models.Opportunity{
+		OpportunityType: models.OpportunityTypeScalp,
+		SportKey:        "basketball_nba",
+		EventID:         "event-1",
+		MarketKey:       "h2h",
+		EdgePercent:     edge,
+		DetectedAt:      detectedAt,
+		Legs: []models.OpportunityLeg{
+			{BookKey: "fanduel", OutcomeName: "Los Angeles Lakers", Price: lakersPrice},
+			{BookKey: "draftkings", OutcomeName: "Boston Celtics", Price: celticsPrice},
+		},
+	}
+}
+
+// trigger is a quote for one of scalp's legs
+func trigger(bookKey, outcomeName string) models.NormalizedOdds {
+	return models.NormalizedOdds{
+		EventID:     "event-1",
+		SportKey:    "basketball_nba",
+		MarketKey:   "h2h",
+		BookKey:     bookKey,
+		OutcomeName: outcomeName,
+	}
+}
+
+func newTracker(store *fakeStore, publisher *fakePublisher, now *time.Time) *lifecycle.Tracker {
+	return lifecycle.NewTrackerWithClock(lifecycle.DefaultConfig(), store, publisher, nil, func() time.Time { return *now })
+}
+
+func TestTracker_Redetection(t *testing.T) {
+	tests := []struct {
+		name        string
+		redetected  func() models.Opportunity
+		wantEvent   models.LifecycleEvent // Empty = touched, nothing published
+		wantTouches int
+	}{
+		{
+			name:        "unchanged",
+			redetected:  func() models.Opportunity { return scalp(1.5, 150, -130) },
+			wantTouches: 1,
+		},
+		{
+			name:        "edge within tolerance",
+			redetected:  func() models.Opportunity { return scalp(1.5004, 150, -130) },
+			wantTouches: 1,
+		},
+		{
+			name:       "edge moved",
+			redetected: func() models.Opportunity { return scalp(1.8, 150, -130) },
+			wantEvent:  models.EventOpportunityUpdated,
+		},
+		{
+			name:       "leg re-priced",
+			redetected: func() models.Opportunity { return scalp(1.5, 155, -130) },
+			wantEvent:  models.EventOpportunityUpdated,
+		},
+		{
+			name: "quoted price moved under the same American price",
+			redetected: func() models.Opportunity {
+				opportunity := scalp(1.5, 150, -130)
+				opportunity.Legs[0].PriceFormat = "decimal"
+				opportunity.Legs[0].PriceValue = "2.501"
+				return opportunity
+			},
+			wantEvent: models.EventOpportunityUpdated,
+		},
+		{
+			name: "max stake changed",
+			redetected: func() models.Opportunity {
+				opportunity := scalp(1.5, 150, -130)
+				maxStake := 500.0
+				opportunity.MaxStake = &maxStake
+				return opportunity
+			},
+			wantEvent: models.EventOpportunityUpdated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newFakeStore()
+			publisher := &fakePublisher{}
+			now := detectedAt
+			tracker := newTracker(store, publisher, &now)
+			ctx := context.Background()
+			completed := []models.OpportunityType{models.OpportunityTypeScalp}
+
+			changes, err := tracker.Observe(ctx, trigger("fanduel", "Los Angeles Lakers"), []models.Opportunity{scalp(1.5, 150, -130)}, completed)
+			if err != nil {
+				t.Fatalf("Observe() error: %v", err)
+			}
+			if len(changes) != 1 || changes[0].Event != models.EventOpportunityDetected {
+				t.Fatalf("expected one detected change, got %+v", changes)
+			}
+			opened := changes[0].Opportunity
+
+			changes, err = tracker.Observe(ctx, trigger("fanduel", "Los Angeles Lakers"), []models.Opportunity{tt.redetected()}, completed)
+			if err != nil {
+				t.Fatalf("Observe() error: %v", err)
+			}
+
+			if tt.wantEvent == "" {
+				if len(changes) != 0 {
+					t.Errorf("expected no changes, got %+v", changes)
+				}
+			} else if len(changes) != 1 || changes[0].Event != tt.wantEvent {
+				t.Errorf("expected one %s change, got %+v", tt.wantEvent, changes)
+			} else if changes[0].Opportunity.ID != opened.ID {
+				t.Errorf("expected the same row %d (same fingerprint), got %d", opened.ID, changes[0].Opportunity.ID)
+			}
+			if len(store.touches) != tt.wantTouches {
+				t.Errorf("expected %d touches, got %d", tt.wantTouches, len(store.touches))
+			}
+			if publisher.published != 1 {
+				t.Errorf("expected opportunities.detected once, got %d", publisher.published)
+			}
+			if stats := tracker.Stats(); stats.Open != 1 {
+				t.Errorf("expected 1 open opportunity, got %d", stats.Open)
+			}
+		})
+	}
+}
+
+func TestTracker_FingerprintSeparatesLegs(t *testing.T) {
+	store := newFakeStore()
+	now := detectedAt
+	tracker := newTracker(store, &fakePublisher{}, &now)
+
+	// Same market, different book on one leg: a different opportunity
+	other := scalp(1.5, 150, -130)
+	other.Legs[1].BookKey = "betmgm"
+
+	changes, err := tracker.Observe(context.Background(), trigger("fanduel", "Los Angeles Lakers"),
+		[]models.Opportunity{scalp(1.5, 150, -130), other}, []models.OpportunityType{models.OpportunityTypeScalp})
+	if err != nil {
+		t.Fatalf("Observe() error: %v", err)
+	}
+	if len(changes) != 2 || changes[0].Opportunity.ID == changes[1].Opportunity.ID {
+		t.Fatalf("expected two rows, got %+v", changes)
+	}
+	if changes[0].Opportunity.Fingerprint != models.Fingerprint(scalp(1.5, 150, -130)) {
+		t.Errorf("expected the stored fingerprint to match models.Fingerprint")
+	}
+}
+
+func TestTracker_GoneClose(t *testing.T) {
+	tests := []struct {
+		name      string
+		trigger   models.NormalizedOdds
+		completed []models.OpportunityType
+		wantGone  bool
+	}{
+		{
+			name:      "leg quote updated and not re-detected",
+			trigger:   trigger("draftkings", "Boston Celtics"),
+			completed: []models.OpportunityType{models.OpportunityTypeScalp},
+			wantGone:  true,
+		},
+		{
+			name:      "scalp detector didn't complete",
+			trigger:   trigger("draftkings", "Boston Celtics"),
+			completed: []models.OpportunityType{models.OpportunityTypeEdge},
+		},
+		{
+			name:      "quote from a book without a leg",
+			trigger:   trigger("betmgm", "Boston Celtics"),
+			completed: []models.OpportunityType{models.OpportunityTypeScalp},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newFakeStore()
+			publisher := &fakePublisher{}
+			now := detectedAt
+			tracker := newTracker(store, publisher, &now)
+			ctx := context.Background()
+
+			changes, err := tracker.Observe(ctx, trigger("fanduel", "Los Angeles Lakers"),
+				[]models.Opportunity{scalp(1.5, 150, -130)}, []models.OpportunityType{models.OpportunityTypeScalp})
+			if err != nil || len(changes) != 1 {
+				t.Fatalf("expected the scalp to open, got %+v (err: %v)", changes, err)
+			}
+			id := changes[0].Opportunity.ID
+
+			now = detectedAt.Add(5 * time.Second)
+			changes, err = tracker.Observe(ctx, tt.trigger, nil, tt.completed)
+			if err != nil {
+				t.Fatalf("Observe() error: %v", err)
+			}
+
+			if !tt.wantGone {
+				if len(changes) != 0 || len(store.closes) != 0 {
+					t.Errorf("expected the scalp to stay open, got %+v", changes)
+				}
+				return
+			}
+			if len(changes) != 1 || changes[0].Event != models.EventOpportunityClosed {
+				t.Fatalf("expected one closed change, got %+v", changes)
+			}
+			closed := changes[0].Opportunity
+			if closed.CloseReason != models.CloseReasonGone || closed.ClosedAt == nil || !closed.ClosedAt.Equal(now) {
+				t.Errorf("expected gone at %s, got %s at %v", now, closed.CloseReason, closed.ClosedAt)
+			}
+			if store.closes[id] != models.CloseReasonGone {
+				t.Errorf("expected row %d closed as gone in the store, got %q", id, store.closes[id])
+			}
+			if stats := tracker.Stats(); stats.Open != 0 || stats.Gone != 1 {
+				t.Errorf("expected 0 open and 1 gone, got %d open and %d gone", stats.Open, stats.Gone)
+			}
+		})
+	}
+}
+
+func TestTracker_AlternateLineLeavesOpen(t *testing.T) {
+	store := newFakeStore()
+	now := detectedAt
+	tracker := newTracker(store, &fakePublisher{}, &now)
+	ctx := context.Background()
+	completed := []models.OpportunityType{models.OpportunityTypeMiddle}
+
+	over, under := 221.5, 223.5
+	middle := models.Opportunity{
+		OpportunityType: models.OpportunityTypeMiddle,
+		SportKey:        "basketball_nba",
+		EventID:         "event-1",
+		MarketKey:       "totals",
+		EdgePercent:     2.0,
+		DetectedAt:      detectedAt,
+		Legs: []models.OpportunityLeg{
+			{BookKey: "fanduel", OutcomeName: "Over", Price: -110, Point: &over},
+			{BookKey: "draftkings", OutcomeName: "Under", Price: -110, Point: &under},
+		},
+	}
+	quote := func(bookKey, outcomeName string, point float64) models.NormalizedOdds {
+		return models.NormalizedOdds{
+			EventID:     "event-1",
+			SportKey:    "basketball_nba",
+			MarketKey:   "totals",
+			BookKey:     bookKey,
+			OutcomeName: outcomeName,
+			Point:       &point,
+		}
+	}
+
+	changes, err := tracker.Observe(ctx, quote("fanduel", "Over", over), []models.Opportunity{middle}, completed)
+	if err != nil || len(changes) != 1 {
+		t.Fatalf("expected the middle to open, got %+v (err: %v)", changes, err)
+	}
+
+	// FanDuel's alternate Over 224.5 says nothing about its Over 221.5
+	now = detectedAt.Add(5 * time.Second)
+	changes, err = tracker.Observe(ctx, quote("fanduel", "Over", 224.5), nil, completed)
+	if err != nil {
+		t.Fatalf("Observe() error: %v", err)
+	}
+	if len(changes) != 0 || len(store.closes) != 0 {
+		t.Errorf("expected the middle to stay open, got %+v", changes)
+	}
+	if stats := tracker.Stats(); stats.Open != 1 {
+		t.Errorf("expected 1 open opportunity, got %d", stats.Open)
+	}
+
+	// The leg's own line re-quoted without the middle closes it
+	changes, err = tracker.Observe(ctx, quote("fanduel", "Over", over), nil, completed)
+	if err != nil {
+		t.Fatalf("Observe() error: %v", err)
+	}
+	if len(changes) != 1 || changes[0].Event != models.EventOpportunityClosed {
+		t.Errorf("expected one closed change, got %+v", changes)
+	}
+}
+
+func TestTracker_SweepExpires(t *testing.T) {
+	store := newFakeStore()
+	now := detectedAt
+	tracker := newTracker(store, &fakePublisher{}, &now)
+	ctx := context.Background()
+
+	if _, err := tracker.Observe(ctx, trigger("fanduel", "Los Angeles Lakers"),
+		[]models.Opportunity{scalp(1.5, 150, -130)}, []models.OpportunityType{models.OpportunityTypeScalp}); err != nil {
+		t.Fatalf("Observe() error: %v", err)
+	}
+
+	now = detectedAt.Add(lifecycle.DefaultConfig().TTL)
+	if expired := tracker.Sweep(ctx); expired != 0 {
+		t.Fatalf("expected nothing expired at exactly the TTL, got %d", expired)
+	}
+
+	now = now.Add(time.Second)
+	if expired := tracker.Sweep(ctx); expired != 1 {
+		t.Fatalf("expected 1 expired, got %d", expired)
+	}
+	if stats := tracker.Stats(); stats.Open != 0 || stats.Expired != 1 {
+		t.Errorf("expected 0 open and 1 expired, got %d open and %d expired", stats.Open, stats.Expired)
+	}
+}
+
+// blockingStore holds upserts until released
+type blockingStore struct {
+	*fakeStore
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingStore) UpsertOpportunity(ctx context.Context, opportunity models.Opportunity) (writer.UpsertResult, error) {
+	s.entered <- struct{}{}
+	<-s.release
+	return s.fakeStore.UpsertOpportunity(ctx, opportunity)
+}
+
+func TestTracker_StoreWriteDoesNotBlockTracker(t *testing.T) {
+	store := &blockingStore{fakeStore: newFakeStore(), entered: make(chan struct{}), release: make(chan struct{})}
+	tracker := lifecycle.NewTracker(lifecycle.DefaultConfig(), store, &fakePublisher{}, nil)
+	ctx := context.Background()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := tracker.Observe(ctx, trigger("fanduel", "Los Angeles Lakers"),
+			[]models.Opportunity{scalp(1.5, 150, -130)}, []models.OpportunityType{models.OpportunityTypeScalp})
+		done <- err
+	}()
+	<-store.entered
+
+	// Stats and the sweep go on while the write is in flight
+	stats := make(chan lifecycle.Stats, 1)
+	go func() {
+		tracker.Sweep(ctx)
+		stats <- tracker.Stats()
+	}()
+	select {
+	case <-stats:
+	case <-time.After(time.Second):
+		t.Fatal("tracker blocked behind a store write")
+	}
+
+	close(store.release)
+	if err := <-done; err != nil {
+		t.Fatalf("Observe() error: %v", err)
+	}
+	if open := tracker.Stats().Open; open != 1 {
+		t.Errorf("expected 1 open opportunity, got %d", open)
+	}
+}
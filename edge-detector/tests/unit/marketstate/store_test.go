@@ -0,0 +1,62 @@
+package marketstate_test
+
+import (
+	"testing"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/marketstate"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+)
+
+// spread is a FanDuel Lakers quote in the given spread market
+func spread(marketKey string, price int, point float64) models.NormalizedOdds {
+	return models.NormalizedOdds{
+		EventID:     "event-1",
+		SportKey:    "basketball_nba",
+		MarketKey:   marketKey,
+		BookKey:     "fanduel",
+		OutcomeName: "Los Angeles Lakers",
+		Price:       price,
+		Point:       &point,
+	}
+}
+
+func TestStore_QuoteKeyByPoint(t *testing.T) {
+	tests := []struct {
+		name       string
+		marketKey  string
+		wantPrices map[float64]int
+	}{
+		{
+			name:       "moved main line replaces the old one",
+			marketKey:  "spreads",
+			wantPrices: map[float64]int{-8.5: -105},
+		},
+		{
+			name:       "alternate lines coexist",
+			marketKey:  "alternate_spreads",
+			wantPrices: map[float64]int{-7.5: -110, -8.5: -105},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := marketstate.NewStore(marketstate.DefaultConfig())
+			store.Upsert(spread(tt.marketKey, -110, -7.5))
+			store.Upsert(spread(tt.marketKey, -105, -8.5))
+
+			quotes := store.GetMarketOdds("event-1", tt.marketKey)
+			prices := make(map[float64]int)
+			for _, quote := range quotes {
+				prices[*quote.Point] = quote.Price
+			}
+			if len(quotes) != len(tt.wantPrices) {
+				t.Fatalf("got %d quotes %v, want %v", len(quotes), prices, tt.wantPrices)
+			}
+			for point, price := range tt.wantPrices {
+				if prices[point] != price {
+					t.Errorf("prices by line = %v, want %v", prices, tt.wantPrices)
+				}
+			}
+		})
+	}
+}
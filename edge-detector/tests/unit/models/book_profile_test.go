@@ -0,0 +1,118 @@
+package models_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+)
+
+func TestBookProfile_NetDecimal(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile models.BookProfile
+		decimal float64
+		want    float64
+	}{
+		{
+			name:    "no costs pays as posted",
+			profile: models.BookProfile{},
+			decimal: 2.10,
+			want:    2.10,
+		},
+		{
+			name:    "commission on winnings",
+			profile: models.BookProfile{CommissionRate: 0.02},
+			decimal: 2.10,
+			want:    1 + 1.10*0.98,
+		},
+		{
+			name:    "tax on net winnings after commission",
+			profile: models.BookProfile{CommissionRate: 0.02, TaxRate: 0.10, TaxBasis: models.TaxBasisNet},
+			decimal: 2.10,
+			want:    1 + 1.10*0.98*0.90,
+		},
+		{
+			name:    "tax on the gross payout",
+			profile: models.BookProfile{TaxRate: 0.05, TaxBasis: models.TaxBasisGross},
+			decimal: 2.10,
+			want:    2.10 * 0.95,
+		},
+		{
+			name:    "gross tax after commission",
+			profile: models.BookProfile{CommissionRate: 0.05, TaxRate: 0.05, TaxBasis: models.TaxBasisGross},
+			decimal: 3.00,
+			want:    (1 + 2*0.95) * 0.95,
+		},
+		{
+			name:    "empty basis taxes net winnings",
+			profile: models.BookProfile{TaxRate: 0.10},
+			decimal: 1.50,
+			want:    1 + 0.50*0.90,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.profile.NetDecimal(tt.decimal); math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("NetDecimal(%.2f) = %.6f, want %.6f", tt.decimal, got, tt.want)
+			}
+			if got, want := tt.profile.HasCosts(), tt.want != tt.decimal; got != want {
+				t.Errorf("HasCosts() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestBookProfile_MaxStakeFor(t *testing.T) {
+	profile := models.BookProfile{
+		MaxStake:        500,
+		MarketMaxStakes: map[string]float64{"player_points": 100, "totals": 0},
+	}
+
+	tests := []struct {
+		marketKey string
+		want      float64
+	}{
+		{marketKey: "h2h", want: 500},
+		{marketKey: "player_points", want: 100},
+		{marketKey: "totals", want: 500}, // A zero override falls back to the default
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.marketKey, func(t *testing.T) {
+			if got := profile.MaxStakeFor(tt.marketKey); got != tt.want {
+				t.Errorf("MaxStakeFor(%q) = %v, want %v", tt.marketKey, got, tt.want)
+			}
+		})
+	}
+
+	if got := (models.BookProfile{}).MaxStakeFor("h2h"); got != 0 {
+		t.Errorf("expected no limit without a profile, got %v", got)
+	}
+}
+
+func TestBookProfile_RoundStake(t *testing.T) {
+	tests := []struct {
+		name      string
+		increment float64
+		stake     float64
+		want      float64
+	}{
+		{name: "no increment", increment: 0, stake: 123.456, want: 123.456},
+		{name: "whole units", increment: 1, stake: 95.99, want: 95},
+		{name: "cents", increment: 0.01, stake: 10.00, want: 10.00},
+		{name: "cents rounded down", increment: 0.01, stake: 10.019, want: 10.01},
+		{name: "fives", increment: 5, stake: 149.99, want: 145},
+		{name: "below one increment", increment: 5, stake: 4.99, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := models.BookProfile{StakeIncrement: tt.increment}
+			if got := profile.RoundStake(tt.stake); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("RoundStake(%v) = %v, want %v", tt.stake, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+)
+
+func TestDetectorSettings_Validate(t *testing.T) {
+	float := func(value float64) *float64 { return &value }
+	integer := func(value int) *int { return &value }
+
+	tests := []struct {
+		name     string
+		settings models.DetectorSettings
+		wantErr  bool
+	}{
+		{name: "every field left to the environment", settings: models.DetectorSettings{}},
+		{
+			name: "in bounds",
+			settings: models.DetectorSettings{
+				MinEdgePct:             float(0.5),
+				MaxDataAgeSeconds:      integer(3600),
+				EnabledMarkets:         []string{"h2h", "player_points"},
+				StaleLineMinMoveCents:  integer(1),
+				StaleLineWindowSeconds: integer(10),
+			},
+		},
+		{name: "zero min edge", settings: models.DetectorSettings{MinEdgePct: float(0)}, wantErr: true},
+		{name: "min edge given in percent", settings: models.DetectorSettings{MinEdgePct: float(2)}, wantErr: true},
+		{name: "max data age over an hour", settings: models.DetectorSettings{MaxDataAgeSeconds: integer(3601)}, wantErr: true},
+		{name: "no markets", settings: models.DetectorSettings{EnabledMarkets: []string{}}, wantErr: true},
+		{name: "bad market key", settings: models.DetectorSettings{EnabledMarkets: []string{"H2H"}}, wantErr: true},
+		{name: "stale line move over 500 cents", settings: models.DetectorSettings{StaleLineMinMoveCents: integer(501)}, wantErr: true},
+		{name: "stale line window under 10s", settings: models.DetectorSettings{StaleLineWindowSeconds: integer(5)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.settings.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+)
+
+// totalsScalp is an Over/Under scalp between fanduel and draftkings at a point and edge
+func totalsScalp(over, under, edge float64) models.Opportunity {
+	return models.Opportunity{
+		OpportunityType: models.OpportunityTypeScalp,
+		EventID:         "event-1",
+		MarketKey:       "totals",
+		EdgePercent:     edge,
+		Legs: []models.OpportunityLeg{
+			{BookKey: "fanduel", MarketKey: "totals", OutcomeName: "Over", Price: 120, Point: &over},
+			{BookKey: "draftkings", MarketKey: "totals", OutcomeName: "Under", Price: -105, Point: &under},
+		},
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	base := models.Fingerprint(totalsScalp(220.5, 220.5, 2.1))
+
+	repriced := totalsScalp(220.5, 220.5, 3.4)
+	repriced.Legs[0].Price = 125
+
+	reordered := totalsScalp(220.5, 220.5, 2.1)
+	reordered.Legs[0], reordered.Legs[1] = reordered.Legs[1], reordered.Legs[0]
+
+	otherBook := totalsScalp(220.5, 220.5, 2.1)
+	otherBook.Legs[1].BookKey = "betmgm"
+
+	otherType := totalsScalp(220.5, 220.5, 2.1)
+	otherType.OpportunityType = models.OpportunityTypeMiddle
+
+	tests := []struct {
+		name        string
+		opportunity models.Opportunity
+		wantSame    bool
+	}{
+		{name: "re-priced", opportunity: repriced, wantSame: true},
+		{name: "legs in another order", opportunity: reordered, wantSame: true},
+		{name: "leg at another point", opportunity: totalsScalp(221.5, 220.5, 2.1)},
+		{name: "leg at another book", opportunity: otherBook},
+		{name: "another opportunity type", opportunity: otherType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if same := models.Fingerprint(tt.opportunity) == base; same != tt.wantSame {
+				t.Errorf("same fingerprint = %v, want %v", same, tt.wantSame)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package sharpness_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/internal/sharpness"
+)
+
+var commence = time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+// h2h returns a book's two-way moneyline snapshot, minutesBefore the tip
+func h2h(bookKey string, minutesBefore int, lakers, celtics int) []sharpness.Line {
+	return twoWay("h2h", bookKey, minutesBefore, nil, "Los Angeles Lakers", lakers, "Boston Celtics", celtics)
+}
+
+// totals returns a book's over/under snapshot at a point, minutesBefore the tip
+func totals(bookKey string, minutesBefore int, point float64, over, under int) []sharpness.Line {
+	return twoWay("totals", bookKey, minutesBefore, &point, "Over", over, "Under", under)
+}
+
+// twoWay returns both outcomes of one book's snapshot
+func twoWay(marketKey, bookKey string, minutesBefore int, point *float64, first string, firstPrice int, second string, secondPrice int) []sharpness.Line {
+	at := commence.Add(-time.Duration(minutesBefore) * time.Minute)
+	line := sharpness.Line{EventID: "event-1", MarketKey: marketKey, BookKey: bookKey, Point: point, At: at, CommenceTime: commence}
+
+	firstLine, secondLine := line, line
+	firstLine.OutcomeName, firstLine.Price = first, firstPrice
+	secondLine.OutcomeName, secondLine.Price = second, secondPrice
+	return []sharpness.Line{firstLine, secondLine}
+}
+
+// lines flattens snapshots in the order the scorer reads them
+func lines(snapshots ...[]sharpness.Line) []sharpness.Line {
+	var all []sharpness.Line
+	for _, snapshot := range snapshots {
+		all = append(all, snapshot...)
+	}
+	return all
+}
+
+// implied is an American price's implied probability
+func implied(price int) float64 {
+	if price > 0 {
+		return 100 / float64(price+100)
+	}
+	return float64(-price) / float64(100-price)
+}
+
+// missBy returns a two-way price's no-vig error against a -150/+130 close
+func missBy(price, otherPrice int) float64 {
+	noVig := func(a, b int) float64 {
+		p, q := implied(a), implied(b)
+		return p / (p + q)
+	}
+	return math.Abs(noVig(price, otherPrice) - noVig(-150, 130))
+}
+
+func TestScorer_Scores(t *testing.T) {
+	// Four books close -150/+130; pre-close prices miss it by increasing amounts
+	closing := lines(h2h("pinnacle", 0, -150, 130), h2h("circa", 0, -150, 130), h2h("fanduel", 0, -150, 130), h2h("draftkings", 0, -150, 130))
+	miss145, miss140, miss120, miss110 := missBy(-145, 125), missBy(-140, 120), missBy(-120, 100), missBy(-110, -110)
+	field := (miss145 + miss140 + miss120 + miss110) / 4
+	sharpWeights := (math.Pow(field/miss145, 2) + math.Pow(field/miss140, 2)) / 2
+	prices := lines(h2h("circa", 60, -140, 120), h2h("draftkings", 60, -110, -110), h2h("fanduel", 60, -120, 100), h2h("pinnacle", 60, -145, 125))
+
+	type want struct {
+		samples  int
+		events   int
+		intraday float64
+		opening  float64
+		score    float64
+		sharp    bool
+		weight   float64
+	}
+
+	tests := []struct {
+		name    string
+		config  sharpness.Config
+		closing []sharpness.Line
+		prices  []sharpness.Line
+		want    map[string]want // book -> scores; books missing weren't scored
+	}{
+		{
+			name:    "scored against the field and weighted by accuracy",
+			config:  sharpness.Config{MinSamples: 1, MinConsensusBooks: 3, SharpScore: 0.15},
+			closing: closing,
+			prices:  prices,
+			want: map[string]want{
+				"pinnacle":   {1, 1, miss145, miss145, 1 - miss145/field, true, math.Pow(field/miss145, 2) / sharpWeights},
+				"circa":      {1, 1, miss140, miss140, 1 - miss140/field, true, math.Pow(field/miss140, 2) / sharpWeights},
+				"fanduel":    {1, 1, miss120, miss120, 1 - miss120/field, false, 0},
+				"draftkings": {1, 1, miss110, miss110, 1 - miss110/field, false, 0},
+			},
+		},
+		{
+			name:    "too few samples to be sharp",
+			config:  sharpness.Config{MinSamples: 2, MinConsensusBooks: 3, SharpScore: 0.15},
+			closing: closing,
+			prices:  prices,
+			want: map[string]want{
+				"pinnacle":   {1, 1, miss145, miss145, 1 - miss145/field, false, 0},
+				"circa":      {1, 1, miss140, miss140, 1 - miss140/field, false, 0},
+				"fanduel":    {1, 1, miss120, miss120, 1 - miss120/field, false, 0},
+				"draftkings": {1, 1, miss110, miss110, 1 - miss110/field, false, 0},
+			},
+		},
+		{
+			name:    "too few other books closed",
+			config:  sharpness.Config{MinSamples: 1, MinConsensusBooks: 4, SharpScore: 0.15},
+			closing: closing,
+			prices:  prices,
+		},
+		{
+			// pinnacle's price is fanduel's close; fanduel's misses pinnacle's close
+			name:    "own close left out of the consensus",
+			config:  sharpness.Config{MinSamples: 1, MinConsensusBooks: 1, SharpScore: 0.15},
+			closing: lines(h2h("fanduel", 0, -110, -110), h2h("pinnacle", 0, -150, 130)),
+			prices:  lines(h2h("fanduel", 60, -145, 125), h2h("pinnacle", 60, -110, -110)),
+			want: map[string]want{
+				"fanduel":  {1, 1, miss145, miss145, -1, false, 0},
+				"pinnacle": {1, 1, 0, 0, 1, true, 1},
+			},
+		},
+		{
+			// circa's close has no Celtics price, leaving pinnacle one book to compare with
+			name:    "incomplete closing line left out of the consensus",
+			config:  sharpness.Config{MinSamples: 1, MinConsensusBooks: 2, SharpScore: 0.15},
+			closing: lines(h2h("pinnacle", 0, -150, 130), h2h("fanduel", 0, -150, 130), h2h("circa", 0, -150, 0)),
+			prices:  lines(h2h("pinnacle", 60, -145, 125)),
+		},
+		{
+			name:    "price at a point the market didn't close on",
+			config:  sharpness.Config{MinSamples: 1, MinConsensusBooks: 1, SharpScore: 0.15},
+			closing: lines(totals("pinnacle", 0, 220.5, -110, -110), totals("fanduel", 0, 220.5, -110, -110)),
+			prices:  lines(totals("fanduel", 60, 221.5, -110, -110)),
+		},
+		{
+			// draftkings opens wide and closes the gap; one event, two samples
+			name:    "opening and intraday snapshots",
+			config:  sharpness.Config{MinSamples: 1, MinConsensusBooks: 3, SharpScore: 0.15},
+			closing: closing,
+			prices:  lines(h2h("draftkings", 120, -110, -110), h2h("draftkings", 60, -145, 125), h2h("fanduel", 60, -120, 100)),
+			want: map[string]want{
+				"draftkings": {
+					2, 1, (miss110 + miss145) / 2, miss110,
+					1 - (0.5*((miss110+miss145)/2)/(((miss110+miss145)/2+miss120)/2) + 0.5*miss110/((miss110+miss120)/2)),
+					false, 0,
+				},
+				"fanduel": {
+					1, 1, miss120, miss120,
+					1 - (0.5*miss120/(((miss110+miss145)/2+miss120)/2) + 0.5*miss120/((miss110+miss120)/2)),
+					false, 0,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scorer := sharpness.NewScorer(tt.config, tt.closing)
+			for _, line := range tt.prices {
+				scorer.Add(line)
+			}
+
+			scores := scorer.Scores("basketball_nba", commence)
+			if len(scores) != len(tt.want) {
+				t.Fatalf("expected %d scored books, got %+v", len(tt.want), scores)
+			}
+			for _, got := range scores {
+				want, ok := tt.want[got.BookKey]
+				if !ok {
+					t.Fatalf("unexpected score for %s", got.BookKey)
+				}
+				if got.Samples != want.samples || got.Events != want.events {
+					t.Errorf("%s: %d samples over %d events, want %d over %d", got.BookKey, got.Samples, got.Events, want.samples, want.events)
+				}
+				if got.IntradayError == nil || math.Abs(*got.IntradayError-want.intraday) > 1e-9 {
+					t.Errorf("%s: intraday error = %v, want %.6f", got.BookKey, got.IntradayError, want.intraday)
+				}
+				if got.OpeningError == nil || math.Abs(*got.OpeningError-want.opening) > 1e-9 {
+					t.Errorf("%s: opening error = %v, want %.6f", got.BookKey, got.OpeningError, want.opening)
+				}
+				if math.Abs(got.Score-want.score) > 1e-9 {
+					t.Errorf("%s: score = %.6f, want %.6f", got.BookKey, got.Score, want.score)
+				}
+				if got.IsSharp != want.sharp || math.Abs(got.Weight-want.weight) > 1e-9 {
+					t.Errorf("%s: sharp %v weight %.6f, want sharp %v weight %.6f", got.BookKey, got.IsSharp, got.Weight, want.sharp, want.weight)
+				}
+			}
+		})
+	}
+}
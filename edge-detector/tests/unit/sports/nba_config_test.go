@@ -0,0 +1,56 @@
+package sports_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/XavierBriggs/fortuna/services/edge-detector/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/edge-detector/sports/basketball_nba"
+)
+
+func TestNBAConfig_ApplySettings(t *testing.T) {
+	t.Setenv("MIN_EDGE_PCT", "0.02")
+	t.Setenv("ENABLE_SCALPS", "false")
+	config := basketball_nba.NewConfig()
+
+	minEdge, window, scalps := 0.035, 600, true
+	config.ApplySettings(&models.DetectorSettings{
+		SportKey:               "basketball_nba",
+		MinEdgePct:             &minEdge,
+		StaleLineWindowSeconds: &window,
+		EnableScalps:           &scalps,
+		EnabledMarkets:         []string{"h2h"},
+	})
+
+	if got := config.GetMinEdgePercent(); got != 0.035 {
+		t.Errorf("min edge = %v, want the row's 0.035", got)
+	}
+	if got := config.GetStaleLineWindowSeconds(); got != 600 {
+		t.Errorf("stale line window = %d, want the row's 600", got)
+	}
+	if !config.IsScalpDetectionEnabled() {
+		t.Error("expected the row to turn scalps on")
+	}
+	if got := config.GetEnabledMarkets(); !reflect.DeepEqual(got, []string{"h2h"}) {
+		t.Errorf("enabled markets = %v, want [h2h]", got)
+	}
+	if got := config.GetMaxDataAgeSeconds(); got != 10 {
+		t.Errorf("max data age = %d, want the unset field's default 10", got)
+	}
+
+	// A deleted row puts every threshold back to its environment value
+	config.ApplySettings(nil)
+
+	if got := config.GetMinEdgePercent(); got != 0.02 {
+		t.Errorf("min edge = %v, want MIN_EDGE_PCT 0.02", got)
+	}
+	if got := config.GetStaleLineWindowSeconds(); got != 300 {
+		t.Errorf("stale line window = %d, want the default 300", got)
+	}
+	if config.IsScalpDetectionEnabled() {
+		t.Error("expected ENABLE_SCALPS=false restored")
+	}
+	if got := config.GetEnabledMarkets(); !reflect.DeepEqual(got, []string{"h2h", "spreads", "totals"}) {
+		t.Errorf("enabled markets = %v, want the defaults", got)
+	}
+}
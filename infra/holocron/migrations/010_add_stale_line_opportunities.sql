@@ -0,0 +1,20 @@
+-- Migration: Add stale_line opportunity type
+-- Description: Allows stale soft-book line opportunities and records the sharp move they missed
+-- Author: Fortuna System
+-- Date: 2026-10-16
+
+-- Widen the opportunity_type check (inline constraint from 001 gets the default name)
+ALTER TABLE opportunities DROP CONSTRAINT IF EXISTS opportunities_opportunity_type_check;
+ALTER TABLE opportunities
+  ADD CONSTRAINT opportunities_opportunity_type_check
+    CHECK (opportunity_type IN ('edge', 'middle', 'scalp', 'stale_line'));
+
+-- Sharp move context (stale_line only)
+ALTER TABLE opportunities
+  ADD COLUMN IF NOT EXISTS sharp_move_cents INT CHECK (sharp_move_cents > 0 OR sharp_move_cents IS NULL),
+  ADD COLUMN IF NOT EXISTS lag_seconds INT CHECK (lag_seconds >= 0 OR lag_seconds IS NULL);
+
+-- Comments for new columns
+COMMENT ON COLUMN opportunities.opportunity_type IS 'Type of opportunity: edge (single +EV bet), middle (both sides +EV), scalp (guaranteed profit), stale_line (soft book lagging a sharp move)';
+COMMENT ON COLUMN opportunities.sharp_move_cents IS 'Size in cents of the sharp move the soft book has not followed (stale_line only)';
+COMMENT ON COLUMN opportunities.lag_seconds IS 'Seconds the soft quote trails the latest sharp quote (stale_line only)';
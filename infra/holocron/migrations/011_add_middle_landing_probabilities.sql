@@ -0,0 +1,21 @@
+-- Migration: Add middle landing probabilities
+-- Description: Middles now pair different lines; record the window width and how likely the final lands in it
+-- Author: Fortuna System
+-- Date: 2026-10-16
+
+-- Landing probabilities from the sport's final margin/total distribution (middle only)
+ALTER TABLE opportunities
+  ADD COLUMN IF NOT EXISTS middle_both_win_prob DECIMAL(6,5) CHECK (middle_both_win_prob BETWEEN 0 AND 1 OR middle_both_win_prob IS NULL),
+  ADD COLUMN IF NOT EXISTS middle_one_win_prob DECIMAL(6,5) CHECK (middle_one_win_prob BETWEEN 0 AND 1 OR middle_one_win_prob IS NULL),
+  ADD COLUMN IF NOT EXISTS middle_push_prob DECIMAL(6,5) CHECK (middle_push_prob BETWEEN 0 AND 1 OR middle_push_prob IS NULL);
+
+-- Points between the two lines, on each leg of a middle
+ALTER TABLE opportunity_legs
+  ADD COLUMN IF NOT EXISTS middle_width DECIMAL(6,2) CHECK (middle_width > 0 OR middle_width IS NULL);
+
+-- Comments for new columns
+COMMENT ON COLUMN opportunities.opportunity_type IS 'Type of opportunity: edge (single +EV bet), middle (opposite sides at different lines, +EV over the landing window), scalp (guaranteed profit), stale_line (soft book lagging a sharp move)';
+COMMENT ON COLUMN opportunities.middle_both_win_prob IS 'Probability the final lands strictly inside the middle window (middle only)';
+COMMENT ON COLUMN opportunities.middle_one_win_prob IS 'Probability one leg wins and the other loses (middle only)';
+COMMENT ON COLUMN opportunities.middle_push_prob IS 'Probability a leg pushes on a whole-number line (middle only)';
+COMMENT ON COLUMN opportunity_legs.middle_width IS 'Points between the two lines of the middle this leg belongs to (middle only)';
@@ -0,0 +1,18 @@
+-- Migration: Add scalp stakes and guaranteed return
+-- Description: Scalps are solved across N-way and equivalent markets; record each leg's market and stake share
+-- Author: Fortuna System
+-- Date: 2026-10-16
+
+-- Payback per unit staked whichever leg wins (scalp only)
+ALTER TABLE opportunities
+  ADD COLUMN IF NOT EXISTS guaranteed_return DECIMAL(8,6) CHECK (guaranteed_return > 0 OR guaranteed_return IS NULL);
+
+-- Cross-market scalps mix markets, so each leg records its own
+ALTER TABLE opportunity_legs
+  ADD COLUMN IF NOT EXISTS market_key VARCHAR(100),
+  ADD COLUMN IF NOT EXISTS stake_fraction DECIMAL(8,7) CHECK (stake_fraction BETWEEN 0 AND 1 OR stake_fraction IS NULL);
+
+-- Comments for new columns
+COMMENT ON COLUMN opportunities.guaranteed_return IS 'Payback per unit staked in every result, e.g. 1.012 for a 1.2% scalp (scalp only)';
+COMMENT ON COLUMN opportunity_legs.market_key IS 'Market this leg is quoted in; differs from the opportunity market on cross-market scalps (scalp only)';
+COMMENT ON COLUMN opportunity_legs.stake_fraction IS 'Share of the total stake placed on this leg; legs sum to 1 (scalp only)';
@@ -0,0 +1,60 @@
+-- Migration: Create book_profiles table
+-- Description: Per-book commission, tax and stake limits so detectors price what we actually net
+-- Author: Fortuna System
+-- Date: 2026-10-16
+
+CREATE TABLE IF NOT EXISTS book_profiles (
+  book_key VARCHAR(50) PRIMARY KEY,
+
+  -- Costs on a winning bet
+  commission_rate DECIMAL(5,4) NOT NULL DEFAULT 0 CHECK (commission_rate >= 0 AND commission_rate < 1),
+  tax_rate DECIMAL(5,4) NOT NULL DEFAULT 0 CHECK (tax_rate >= 0 AND tax_rate < 1),
+  tax_basis VARCHAR(10) NOT NULL DEFAULT 'net' CHECK (tax_basis IN ('net', 'gross')),
+
+  -- Stake limits
+  min_stake DECIMAL(10,2) NOT NULL DEFAULT 0 CHECK (min_stake >= 0),
+  max_stake DECIMAL(12,2) CHECK (max_stake > 0 OR max_stake IS NULL),     -- NULL = no limit
+  market_max_stakes JSONB NOT NULL DEFAULT '{}'::jsonb,                     -- market_key -> max stake
+  stake_increment DECIMAL(10,2) NOT NULL DEFAULT 0.01 CHECK (stake_increment > 0),
+
+  -- Metadata
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Trigger to update updated_at timestamp
+CREATE OR REPLACE FUNCTION update_book_profiles_timestamp()
+RETURNS TRIGGER AS $$
+BEGIN
+  NEW.updated_at = NOW();
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trigger_update_book_profiles_timestamp
+  BEFORE UPDATE ON book_profiles
+  FOR EACH ROW
+  EXECUTE FUNCTION update_book_profiles_timestamp();
+
+-- Exchanges charge commission on net winnings
+INSERT INTO book_profiles (book_key, commission_rate, min_stake, stake_increment) VALUES
+  ('betfair_ex_uk', 0.0500, 1.00, 0.01),
+  ('betfair_ex_eu', 0.0500, 1.00, 0.01),
+  ('betfair_ex_au', 0.0500, 1.00, 0.01),
+  ('matchbook', 0.0200, 1.00, 0.01),
+  ('smarkets', 0.0200, 1.00, 0.01)
+ON CONFLICT (book_key) DO NOTHING;
+
+-- Largest total stake the books' limits allow on an opportunity
+ALTER TABLE opportunities
+  ADD COLUMN IF NOT EXISTS max_stake DECIMAL(12,2) CHECK (max_stake > 0 OR max_stake IS NULL);
+
+-- Comments
+COMMENT ON TABLE book_profiles IS 'Per-book costs and limits applied by the edge and scalp detectors';
+COMMENT ON COLUMN book_profiles.commission_rate IS 'Share of net winnings kept by the book (exchange commission)';
+COMMENT ON COLUMN book_profiles.tax_rate IS 'Tax withheld on a winning bet';
+COMMENT ON COLUMN book_profiles.tax_basis IS 'net: tax on winnings after commission; gross: tax on the whole payout including stake';
+COMMENT ON COLUMN book_profiles.min_stake IS 'Smallest bet the book accepts';
+COMMENT ON COLUMN book_profiles.max_stake IS 'Default largest bet the book accepts (NULL = no limit)';
+COMMENT ON COLUMN book_profiles.market_max_stakes IS 'Per-market overrides of max_stake, e.g. {"player_points": 250}';
+COMMENT ON COLUMN book_profiles.stake_increment IS 'Stakes are rounded down to a multiple of this';
+COMMENT ON COLUMN opportunities.max_stake IS 'Largest total stake the books'' limits allow (edge: the leg''s max; scalp: across all legs)';
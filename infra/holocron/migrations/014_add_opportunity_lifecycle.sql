@@ -0,0 +1,43 @@
+-- Migration: Add opportunity lifecycle
+-- Description: One row per opportunity while it stays open (upserted by fingerprint) instead of a row per tick
+-- Author: Fortuna System
+-- Date: 2026-10-16
+
+ALTER TABLE opportunities
+  ADD COLUMN IF NOT EXISTS fingerprint VARCHAR(40),
+  ADD COLUMN IF NOT EXISTS first_seen_at TIMESTAMPTZ,
+  ADD COLUMN IF NOT EXISTS last_seen_at TIMESTAMPTZ,
+  ADD COLUMN IF NOT EXISTS peak_edge_pct DECIMAL(6,3),
+  ADD COLUMN IF NOT EXISTS closed_at TIMESTAMPTZ,
+  ADD COLUMN IF NOT EXISTS close_reason VARCHAR(20) CHECK (close_reason IN ('gone', 'expired') OR close_reason IS NULL);
+
+-- Rows written before lifecycle tracking were single-tick snapshots: seen once, already gone
+UPDATE opportunities
+SET first_seen_at = COALESCE(first_seen_at, detected_at),
+    last_seen_at = COALESCE(last_seen_at, detected_at),
+    peak_edge_pct = COALESCE(peak_edge_pct, edge_pct),
+    closed_at = COALESCE(closed_at, detected_at),
+    close_reason = COALESCE(close_reason, 'expired')
+WHERE first_seen_at IS NULL;
+
+ALTER TABLE opportunities
+  ALTER COLUMN first_seen_at SET DEFAULT NOW(),
+  ALTER COLUMN first_seen_at SET NOT NULL,
+  ALTER COLUMN last_seen_at SET DEFAULT NOW(),
+  ALTER COLUMN last_seen_at SET NOT NULL;
+
+-- At most one open row per fingerprint; a reappearing opportunity after close gets a new row
+CREATE UNIQUE INDEX IF NOT EXISTS idx_opportunities_open_fingerprint
+  ON opportunities(fingerprint) WHERE closed_at IS NULL;
+
+-- Live board: open opportunities by recency
+CREATE INDEX IF NOT EXISTS idx_opportunities_open_last_seen
+  ON opportunities(sport_key, last_seen_at DESC) WHERE closed_at IS NULL;
+
+-- Comments for new columns
+COMMENT ON COLUMN opportunities.fingerprint IS 'SHA-1 of type, event, market and each leg''s book, market, outcome and point';
+COMMENT ON COLUMN opportunities.first_seen_at IS 'When the opportunity was first detected (same as detected_at)';
+COMMENT ON COLUMN opportunities.last_seen_at IS 'When the opportunity was last re-detected';
+COMMENT ON COLUMN opportunities.peak_edge_pct IS 'Highest edge_pct seen while open';
+COMMENT ON COLUMN opportunities.closed_at IS 'When the opportunity disappeared (NULL while open)';
+COMMENT ON COLUMN opportunities.close_reason IS 'gone: a leg''s quote moved and it was not re-detected; expired: not re-detected within the TTL';
@@ -0,0 +1,24 @@
+-- Migration: Add player_prop opportunity type
+-- Description: Allows player prop opportunities and records each leg's player and stat
+-- Author: Fortuna System
+-- Date: 2026-10-16
+
+-- Widen the opportunity_type check (replaces the constraint from 010)
+ALTER TABLE opportunities DROP CONSTRAINT IF EXISTS opportunities_opportunity_type_check;
+ALTER TABLE opportunities
+  ADD CONSTRAINT opportunities_opportunity_type_check
+    CHECK (opportunity_type IN ('edge', 'middle', 'scalp', 'stale_line', 'player_prop'));
+
+-- Player and stat a prop leg settles on (props only)
+ALTER TABLE opportunity_legs
+  ADD COLUMN IF NOT EXISTS player_name VARCHAR(100),
+  ADD COLUMN IF NOT EXISTS stat VARCHAR(50);
+
+-- Prop history by player
+CREATE INDEX IF NOT EXISTS idx_opportunity_legs_player
+  ON opportunity_legs(player_name) WHERE player_name IS NOT NULL;
+
+-- Comments for new columns
+COMMENT ON COLUMN opportunities.opportunity_type IS 'Type of opportunity: edge (single +EV bet), middle (both sides +EV), scalp (guaranteed profit), stale_line (soft book lagging a sharp move), player_prop (single +EV player prop vs a two-sided sharp price)';
+COMMENT ON COLUMN opportunity_legs.player_name IS 'Player the prop settles on (props only)';
+COMMENT ON COLUMN opportunity_legs.stat IS 'Stat the prop settles on, e.g. points or points_rebounds_assists (props only)';
@@ -0,0 +1,45 @@
+-- Migration: Create book_sharpness table
+-- Description: Per-sport, per-market book accuracy against the closing consensus, driving the sharp book set and consensus weights
+-- Author: Fortuna System
+-- Date: 2026-10-16
+
+CREATE TABLE IF NOT EXISTS book_sharpness (
+  sport_key VARCHAR(50) NOT NULL,
+  market_key VARCHAR(50) NOT NULL,
+  book_key VARCHAR(50) NOT NULL,
+
+  -- Sample size
+  events INTEGER NOT NULL DEFAULT 0 CHECK (events >= 0),
+  samples INTEGER NOT NULL DEFAULT 0 CHECK (samples >= 0),
+
+  -- Mean absolute error of the book's no-vig probability vs the closing consensus
+  opening_error DECIMAL(8,6),
+  intraday_error DECIMAL(8,6),
+
+  -- Accuracy relative to the average book, and what it means for detection
+  score DECIMAL(8,4) NOT NULL,
+  weight DECIMAL(8,4) NOT NULL DEFAULT 0 CHECK (weight >= 0),
+  is_sharp BOOLEAN NOT NULL DEFAULT FALSE,
+
+  -- Metadata
+  window_start TIMESTAMPTZ NOT NULL,
+  window_end TIMESTAMPTZ NOT NULL,
+  scored_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+  PRIMARY KEY (sport_key, market_key, book_key)
+);
+
+-- Sharp set lookups by sport
+CREATE INDEX IF NOT EXISTS idx_book_sharpness_sport_sharp ON book_sharpness(sport_key, is_sharp);
+
+-- Comments
+COMMENT ON TABLE book_sharpness IS 'Book accuracy vs the closing consensus per sport and market, written by edge-detector sharpness';
+COMMENT ON COLUMN book_sharpness.events IS 'Events with at least one scored price from the book';
+COMMENT ON COLUMN book_sharpness.samples IS 'Pre-close price snapshots compared to the closing consensus';
+COMMENT ON COLUMN book_sharpness.opening_error IS 'Mean |no-vig probability - closing consensus| of the book''s first price per event';
+COMMENT ON COLUMN book_sharpness.intraday_error IS 'Mean |no-vig probability - closing consensus| over every pre-close price';
+COMMENT ON COLUMN book_sharpness.score IS '1 - error / average book error (opening and intraday averaged); 0 = average, higher = sharper';
+COMMENT ON COLUMN book_sharpness.weight IS 'Relative weight in the sharp consensus (0 unless is_sharp)';
+COMMENT ON COLUMN book_sharpness.is_sharp IS 'Book is in the market''s sharp set (score at or above the threshold with enough samples)';
+COMMENT ON COLUMN book_sharpness.window_start IS 'Earliest commence_time of the scored events';
+COMMENT ON COLUMN book_sharpness.window_end IS 'Latest commence_time of the scored events';
@@ -0,0 +1,52 @@
+-- Migration: Create detector_config and detector_config_audit tables
+-- Description: Per-sport detection thresholds the edge detector applies live, with an audit history of every change
+-- Author: Fortuna System
+-- Date: 2026-10-16
+
+CREATE TABLE IF NOT EXISTS detector_config (
+  sport_key VARCHAR(50) PRIMARY KEY,
+
+  -- Thresholds (NULL = the edge detector's environment default)
+  min_edge_pct DECIMAL(6,4) CHECK (min_edge_pct > 0 AND min_edge_pct <= 0.5),
+  max_data_age_seconds INTEGER CHECK (max_data_age_seconds BETWEEN 1 AND 3600),
+  enabled_markets TEXT[] CHECK (cardinality(enabled_markets) > 0),
+  stale_line_min_move_cents INTEGER CHECK (stale_line_min_move_cents BETWEEN 1 AND 500),
+  stale_line_window_seconds INTEGER CHECK (stale_line_window_seconds BETWEEN 10 AND 86400),
+
+  -- Detector switches (NULL = environment default), applied live like the thresholds
+  enable_middles BOOLEAN,
+  enable_scalps BOOLEAN,
+  enable_stale_lines BOOLEAN,
+  enable_player_props BOOLEAN,
+
+  -- Metadata
+  version BIGINT NOT NULL DEFAULT 1,
+  updated_by VARCHAR(100) NOT NULL DEFAULT 'system',
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS detector_config_audit (
+  id BIGSERIAL PRIMARY KEY,
+  sport_key VARCHAR(50) NOT NULL,
+  version BIGINT NOT NULL,
+  changed_by VARCHAR(100) NOT NULL,
+  reason TEXT,
+  previous JSONB,                      -- Row before the change (NULL when the row was created)
+  config JSONB NOT NULL,               -- Row after the change
+  changes JSONB NOT NULL,              -- field -> {"old": ..., "new": ...}
+  changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- History by sport, newest first
+CREATE INDEX IF NOT EXISTS idx_detector_config_audit_sport ON detector_config_audit(sport_key, changed_at DESC);
+
+-- Comments
+COMMENT ON TABLE detector_config IS 'Per-sport detection thresholds, applied live by the edge detector on a detector.config.updated notification';
+COMMENT ON COLUMN detector_config.min_edge_pct IS 'Minimum edge to create an opportunity (0.01 = 1%), as MIN_EDGE_PCT';
+COMMENT ON COLUMN detector_config.max_data_age_seconds IS 'Maximum quote age, as MAX_DATA_AGE_SECONDS';
+COMMENT ON COLUMN detector_config.enabled_markets IS 'Markets monitored, as ENABLED_MARKETS';
+COMMENT ON COLUMN detector_config.stale_line_min_move_cents IS 'Sharp move that makes lagging soft quotes stale, as STALE_LINE_MIN_MOVE_CENTS';
+COMMENT ON COLUMN detector_config.stale_line_window_seconds IS 'Sharp price history kept, as STALE_LINE_WINDOW_SECONDS';
+COMMENT ON COLUMN detector_config.version IS 'Incremented on every change; the edge detector ignores versions it has applied';
+COMMENT ON TABLE detector_config_audit IS 'Every detector_config change, written by the API gateway in the same transaction';
+COMMENT ON COLUMN detector_config_audit.changes IS 'Changed fields with their old and new values';
@@ -0,0 +1,14 @@
+-- Migration: Add leg quoted prices
+-- Description: Non-US books quote in decimal, fractional or Asian formats; keep each leg's price as quoted
+-- Author: Fortuna System
+-- Date: 2026-10-16
+
+-- Price as the book quoted it (price holds the rounded American equivalent)
+ALTER TABLE opportunity_legs
+  ADD COLUMN IF NOT EXISTS price_format VARCHAR(20)
+    CHECK (price_format IN ('american', 'decimal', 'fractional', 'hongkong', 'malay', 'indonesian') OR price_format IS NULL),
+  ADD COLUMN IF NOT EXISTS price_value VARCHAR(20);
+
+-- Comments for new columns
+COMMENT ON COLUMN opportunity_legs.price_format IS 'Odds format of price_value: american, decimal, fractional, hongkong, malay or indonesian (NULL when the book quotes American)';
+COMMENT ON COLUMN opportunity_legs.price_value IS 'Price exactly as the book quoted it, e.g. 2.10 or 11/10; price is its American equivalent';
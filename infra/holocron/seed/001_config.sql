@@ -3,29 +3,18 @@
 -- Author: Fortuna System
 -- Date: 2025-11-07
 
--- Note: These values can be overridden by environment variables
+-- Note: Environment variables set the defaults; detector_config rows override them live
 -- This seed is for reference and to establish baseline values
 
--- Configuration table (optional - can use env vars only)
--- Uncomment if you want database-backed configuration
+-- Detector thresholds live in detector_config (migration 017), one row per sport,
+-- and are changed through the API gateway (PUT /api/v1/detector-config/{sport}).
+-- Columns left NULL keep the edge detector's environment defaults.
+-- Uncomment to pin NBA thresholds in the database
 
 /*
-CREATE TABLE IF NOT EXISTS detector_config (
-  config_key VARCHAR(50) PRIMARY KEY,
-  config_value TEXT NOT NULL,
-  description TEXT,
-  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
-);
-
-INSERT INTO detector_config (config_key, config_value, description) VALUES
-  ('min_edge_pct', '0.01', 'Minimum edge percentage to create opportunity (1% = 0.01)'),
-  ('max_data_age_seconds', '10', 'Maximum allowed data age for alerts'),
-  ('alert_rate_limit', '10', 'Maximum alerts per minute'),
-  ('dedup_ttl_minutes', '5', 'Deduplication TTL in minutes'),
-  ('enable_middles', 'true', 'Enable middle opportunity detection'),
-  ('enable_scalps', 'true', 'Enable scalp/arbitrage detection'),
-  ('sharp_book_minimum', '1', 'Minimum number of sharp books required for consensus')
-ON CONFLICT (config_key) DO NOTHING;
+INSERT INTO detector_config (sport_key, min_edge_pct, max_data_age_seconds, enabled_markets)
+VALUES ('basketball_nba', 0.0100, 10, ARRAY['h2h', 'spreads', 'totals'])
+ON CONFLICT (sport_key) DO NOTHING;
 */
 
 -- Initial test data (optional - for development)
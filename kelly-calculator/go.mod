@@ -3,9 +3,9 @@ module github.com/XavierBriggs/fortuna/services/kelly-calculator
 go 1.23
 
 require (
+	github.com/XavierBriggs/fortuna/services/shared v0.0.0
 	github.com/go-chi/chi/v5 v5.0.11
 	github.com/go-chi/cors v1.2.1
 )
 
-
-
+replace github.com/XavierBriggs/fortuna/services/shared => ../shared
@@ -2,7 +2,9 @@ package calculator
 
 import (
 	"fmt"
+
 	"github.com/XavierBriggs/fortuna/services/kelly-calculator/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/shared/odds"
 )
 
 // CalculateEdgeKelly calculates Kelly Criterion stake for an edge bet
@@ -22,7 +24,7 @@ func CalculateEdgeKelly(opportunity models.Opportunity, bankroll, kellyFraction,
 	// Calculate fair probability from edge
 	// edge = (fairProb / impliedProb) - 1
 	// fairProb = (edge + 1) * impliedProb
-	impliedProb := calculateImpliedProbability(leg.Price)
+	impliedProb := odds.AmericanToImpliedProbability(leg.Price)
 	fairProb := (edgePercent/100 + 1.0) * impliedProb
 
 	if fairProb >= 1.0 {
@@ -30,7 +32,7 @@ func CalculateEdgeKelly(opportunity models.Opportunity, bankroll, kellyFraction,
 	}
 
 	// Calculate Kelly percentage
-	decimal := americanToDecimal(leg.Price)
+	decimal := odds.AmericanToDecimal(leg.Price)
 	b := decimal - 1.0 // Net odds
 	p := fairProb
 	q := 1.0 - fairProb
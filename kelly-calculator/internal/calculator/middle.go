@@ -2,7 +2,9 @@ package calculator
 
 import (
 	"fmt"
+
 	"github.com/XavierBriggs/fortuna/services/kelly-calculator/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/shared/odds"
 )
 
 // CalculateMiddleKelly calculates dual independent Kelly stakes for a middle
@@ -30,7 +32,7 @@ func CalculateMiddleKelly(opportunity models.Opportunity, bankroll, kellyFractio
 		}
 
 		// Calculate fair probability from edge
-		impliedProb := calculateImpliedProbability(leg.Price)
+		impliedProb := odds.AmericanToImpliedProbability(leg.Price)
 		fairProb := (edgePercent/100 + 1.0) * impliedProb
 
 		if fairProb >= 1.0 {
@@ -38,7 +40,7 @@ func CalculateMiddleKelly(opportunity models.Opportunity, bankroll, kellyFractio
 		}
 
 		// Calculate Kelly percentage
-		decimal := americanToDecimal(leg.Price)
+		decimal := odds.AmericanToDecimal(leg.Price)
 		b := decimal - 1.0
 		p := fairProb
 		q := 1.0 - fairProb
@@ -2,7 +2,9 @@ package calculator
 
 import (
 	"fmt"
+
 	"github.com/XavierBriggs/fortuna/services/kelly-calculator/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/shared/odds"
 )
 
 // CalculateScalpStakes calculates optimal stake distribution for arbitrage
@@ -15,32 +17,44 @@ func CalculateScalpStakes(opportunity models.Opportunity, totalStake float64) (*
 	// Convert to decimal odds
 	decimalOdds := make([]float64, len(opportunity.Legs))
 	for i, leg := range opportunity.Legs {
-		decimalOdds[i] = americanToDecimal(leg.Price)
+		decimalOdds[i] = odds.AmericanToDecimal(leg.Price)
 	}
 
-	// Calculate inverse sum to verify arbitrage exists
-	inverseSum := 0.0
-	for _, decimal := range decimalOdds {
-		inverseSum += 1.0 / decimal
-	}
+	var stakes []float64
+	var profitMargin, guaranteedProfit float64
+	if fractions, ok := solvedStakes(opportunity); ok {
+		// The edge detector solved the legs (cross-market legs can refund on a push)
+		stakes = make([]float64, len(fractions))
+		for i, fraction := range fractions {
+			stakes[i] = round(totalStake * fraction)
+		}
+		profitMargin = (*opportunity.GuaranteedReturn - 1.0) * 100.0
+		guaranteedProfit = round(totalStake * (*opportunity.GuaranteedReturn - 1.0))
+	} else {
+		// Calculate inverse sum to verify arbitrage exists
+		inverseSum := 0.0
+		for _, decimal := range decimalOdds {
+			inverseSum += 1.0 / decimal
+		}
 
-	if inverseSum >= 1.0 {
-		return nil, fmt.Errorf("no arbitrage exists: inverse sum = %.4f", inverseSum)
-	}
+		if inverseSum >= 1.0 {
+			return nil, fmt.Errorf("no arbitrage exists: inverse sum = %.4f", inverseSum)
+		}
 
-	// Calculate profit margin
-	profitMargin := (1.0 - inverseSum) * 100.0
+		// Calculate profit margin
+		profitMargin = (1.0 - inverseSum) * 100.0
 
-	// Calculate stake for each leg
-	stakes := make([]float64, len(decimalOdds))
-	for i, decimal := range decimalOdds {
-		stakePercent := (1.0 / decimal) / inverseSum
-		stakes[i] = round(totalStake * stakePercent)
-	}
+		// Calculate stake for each leg
+		stakes = make([]float64, len(decimalOdds))
+		for i, decimal := range decimalOdds {
+			stakePercent := (1.0 / decimal) / inverseSum
+			stakes[i] = round(totalStake * stakePercent)
+		}
 
-	// Calculate potential returns (should all be equal)
-	potentialReturn := round(stakes[0] * decimalOdds[0])
-	guaranteedProfit := round(potentialReturn - totalStake)
+		// Calculate potential returns (should all be equal)
+		potentialReturn := round(stakes[0] * decimalOdds[0])
+		guaranteedProfit = round(potentialReturn - totalStake)
+	}
 
 	// Build response
 	legs := make([]models.LegRecommendation, len(opportunity.Legs))
@@ -55,7 +69,7 @@ func CalculateScalpStakes(opportunity models.Opportunity, totalStake float64) (*
 		}
 	}
 
-	instructions := "Place both bets simultaneously for guaranteed profit"
+	instructions := "Place all legs simultaneously for guaranteed profit"
 	warnings := []string{}
 	
 	// Add warnings
@@ -77,6 +91,18 @@ func CalculateScalpStakes(opportunity models.Opportunity, totalStake float64) (*
 	}, nil
 }
 
+// solvedStakes returns the edge detector's stake fractions when every leg carries one
+func solvedStakes(opportunity models.Opportunity) ([]float64, bool) {
+	if opportunity.GuaranteedReturn == nil || *opportunity.GuaranteedReturn <= 1.0 {
+		return nil, false
+	}
 
-
-
+	fractions := make([]float64, len(opportunity.Legs))
+	for i, leg := range opportunity.Legs {
+		if leg.StakeFraction == nil {
+			return nil, false
+		}
+		fractions[i] = *leg.StakeFraction
+	}
+	return fractions, true
+}
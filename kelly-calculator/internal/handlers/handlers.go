@@ -74,7 +74,8 @@ func (h *Handler) CalculateFromOpportunity(w http.ResponseWriter, r *http.Reques
 	var err error
 
 	switch req.Opportunity.OpportunityType {
-	case "edge":
+	case "edge", "stale_line", "player_prop":
+		// Stale lines and player props are single +EV legs, sized like an edge
 		response, err = calculator.CalculateEdgeKelly(
 			req.Opportunity,
 			req.Bankroll,
@@ -10,8 +10,9 @@ type OpportunityRequest struct {
 // Opportunity represents a betting opportunity
 type Opportunity struct {
 	ID              int64            `json:"id"`
-	OpportunityType string           `json:"opportunity_type"` // edge, middle, scalp
+	OpportunityType string           `json:"opportunity_type"` // edge, middle, scalp, stale_line, player_prop
 	EdgePercent     float64          `json:"edge_pct"`
+	GuaranteedReturn *float64        `json:"guaranteed_return"` // Scalps: payback per unit staked
 	Legs            []OpportunityLeg `json:"legs"`
 }
 
@@ -22,6 +23,7 @@ type OpportunityLeg struct {
 	Price         int      `json:"price"`          // American odds
 	Point         *float64 `json:"point"`          // Optional
 	LegEdgePercent *float64 `json:"leg_edge_pct"`  // Optional
+	StakeFraction  *float64 `json:"stake_fraction"` // Scalps: share of the total stake, from the edge detector
 }
 
 // KellyResponse is the unified response for all opportunity types
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/deadletter"
+	"github.com/redis/go-redis/v9"
+)
+
+// runDeadLetter inspects, purges and re-drives odds.deadletter.{sport} streams
+//
+// Usage:
+//
+//	normalizer deadletter list -sport basketball_nba [-count 50] [-json]
+//	normalizer deadletter purge -sport basketball_nba [-id 1700000000000-0,...]
+//	normalizer deadletter redrive -sport basketball_nba [-id ...] [-count 100]
+//
+// Re-driven messages go back onto their source stream (odds.raw.{sport}) and
+// are removed from the dead-letter stream; a repeat failure lands them back
+// here with the attempt count incremented.
+func runDeadLetter(args []string) int {
+	if len(args) == 0 {
+		fmt.Println("usage: normalizer deadletter <list|purge|redrive> -sport <sport_key> [flags]")
+		return 2
+	}
+	action := args[0]
+
+	flags := flag.NewFlagSet("deadletter "+action, flag.ContinueOnError)
+	sportKey := flags.String("sport", "", "sport key (required)")
+	idList := flags.String("id", "", "comma-separated dead-letter IDs (default: all)")
+	count := flags.Int64("count", 100, "max entries to list or re-drive (ignored with -id)")
+	asJSON := flags.Bool("json", false, "list entries as JSON lines")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if *sportKey == "" {
+		fmt.Println("❌ -sport is required")
+		return 2
+	}
+
+	var ids []string
+	if *idList != "" {
+		ids = strings.Split(*idList, ",")
+	}
+
+	config := loadConfig()
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     config.RedisURL,
+		Password: config.RedisPassword,
+		DB:       0,
+	})
+	defer redisClient.Close()
+
+	ctx := context.Background()
+	if err := redisClient.Ping(ctx).Err(); err != nil {
+		fmt.Printf("❌ Failed to connect to Redis: %v\n", err)
+		return 1
+	}
+
+	queue := deadletter.NewQueue(redisClient, config.DeadLetterMaxLen)
+
+	switch action {
+	case "list":
+		total, err := queue.Count(ctx, *sportKey)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return 1
+		}
+		entries, err := queue.List(ctx, *sportKey, *count)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return 1
+		}
+
+		if *asJSON {
+			encoder := json.NewEncoder(os.Stdout)
+			for _, entry := range entries {
+				if err := encoder.Encode(entry); err != nil {
+					fmt.Printf("❌ %v\n", err)
+					return 1
+				}
+			}
+			return 0
+		}
+
+		fmt.Printf("📊 %s: %d dead letters (showing %d)\n", deadletter.StreamKey(*sportKey), total, len(entries))
+		for _, entry := range entries {
+			fmt.Printf("  %s  source=%s class=%s attempts=%d failed_at=%s\n    %s\n",
+				entry.ID, entry.SourceID, entry.ErrorClass, entry.Attempts,
+				entry.FailedAt.Format("2006-01-02T15:04:05Z07:00"), entry.ErrorMessage)
+		}
+
+	case "purge":
+		removed, err := queue.Purge(ctx, *sportKey, ids...)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			return 1
+		}
+		fmt.Printf("✓ Purged %d dead letters from %s\n", removed, deadletter.StreamKey(*sportKey))
+
+	case "redrive":
+		redriven, err := queue.Redrive(ctx, *sportKey, *count, ids...)
+		if err != nil {
+			fmt.Printf("❌ %v (re-drove %d before failing)\n", err, redriven)
+			return 1
+		}
+		fmt.Printf("✓ Re-drove %d dead letters from %s\n", redriven, deadletter.StreamKey(*sportKey))
+
+	default:
+		fmt.Printf("❌ unknown deadletter action %q (want list, purge or redrive)\n", action)
+		return 2
+	}
+
+	return 0
+}
@@ -1,14 +1,25 @@
 module github.com/XavierBriggs/fortuna/services/normalizer
 
-go 1.21
+go 1.23.0
 
-require github.com/redis/go-redis/v9 v9.0.5
+require (
+	github.com/XavierBriggs/fortuna/services/shared v0.0.0
+	github.com/lib/pq v1.10.9
+	github.com/prometheus/client_golang v1.23.0
+	github.com/redis/go-redis/v9 v9.0.5
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
-	github.com/cespare/xxhash/v2 v2.2.0 // indirect
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
 	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
+	github.com/prometheus/client_model v0.6.2 // indirect
+	github.com/prometheus/common v0.65.0 // indirect
+	github.com/prometheus/procfs v0.16.1 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+	google.golang.org/protobuf v1.36.6 // indirect
 )
 
-
-
-
+replace github.com/XavierBriggs/fortuna/services/shared => ../shared
@@ -0,0 +1,46 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/deadletter"
+	"github.com/XavierBriggs/fortuna/services/shared/streams"
+	"github.com/redis/go-redis/v9"
+)
+
+// RecoveryConfig controls how idle pending entries are reclaimed
+type RecoveryConfig = streams.RecoveryConfig
+
+// PendingStats describes a stream's backlog for the consumer group
+type PendingStats = streams.PendingStats
+
+// DefaultRecoveryConfig returns the default recovery settings
+func DefaultRecoveryConfig() RecoveryConfig {
+	return streams.DefaultRecoveryConfig()
+}
+
+// deadLetterTo writes poison entries to the sport's dead-letter stream as Entries
+// They can then be inspected and re-driven with `normalizer deadletter`.
+func deadLetterTo(deadLetters *deadletter.Queue) streams.DeadLetterFunc {
+	return func(ctx context.Context, streamKey string, message redis.XMessage, deliveries int64) error {
+		payload, _ := message.Values["data"].(string)
+		attempts := 0
+		if value, ok := message.Values["attempts"].(string); ok {
+			attempts, _ = strconv.Atoi(value)
+		}
+
+		return deadLetters.Write(ctx, deadletter.Entry{
+			SportKey:     deadletter.SportFromStream(streamKey),
+			SourceStream: streamKey,
+			SourceID:     message.ID,
+			ErrorClass:   deadletter.ClassMaxDeliveries,
+			ErrorMessage: fmt.Sprintf("not acked after %d deliveries", deliveries),
+			Attempts:     attempts + int(deliveries),
+			Payload:      payload,
+			FailedAt:     time.Now(),
+		})
+	}
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/deadletter"
 	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/shared/streams"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -17,16 +20,24 @@ type StreamConsumer struct {
 	groupName   string
 	batchSize   int64
 	blockTime   time.Duration
+	recovery    *streams.PendingRecovery
 }
 
-// NewStreamConsumer creates a new stream consumer
+// NewStreamConsumer creates a new stream consumer with default pending recovery
+// Poison messages go to an unbounded odds.deadletter.{sport} stream
 func NewStreamConsumer(redisClient *redis.Client, consumerID, groupName string) *StreamConsumer {
+	return NewStreamConsumerWithRecovery(redisClient, consumerID, groupName, DefaultRecoveryConfig(), deadletter.NewQueue(redisClient, 0))
+}
+
+// NewStreamConsumerWithRecovery creates a stream consumer with custom pending recovery
+func NewStreamConsumerWithRecovery(redisClient *redis.Client, consumerID, groupName string, recovery RecoveryConfig, deadLetters *deadletter.Queue) *StreamConsumer {
 	return &StreamConsumer{
 		redis:      redisClient,
 		consumerID: consumerID,
 		groupName:  groupName,
 		batchSize:  100,
 		blockTime:  5 * time.Second,
+		recovery:   streams.NewPendingRecovery(redisClient, groupName, consumerID, recovery, deadLetterTo(deadLetters)),
 	}
 }
 
@@ -53,6 +64,21 @@ func (c *StreamConsumer) ConsumeStream(ctx context.Context, streamKey string) (<
 			case <-ctx.Done():
 				return
 			default:
+				// Retry entries left pending by a crashed consumer (or by us before a restart)
+				if c.recovery.Due(streamKey) {
+					claimed, err := c.claimMessages(ctx, streamKey)
+					if err != nil {
+						errorCh <- fmt.Errorf("error claiming pending entries: %w", err)
+					}
+					for _, msg := range claimed {
+						select {
+						case messageCh <- msg:
+						case <-ctx.Done():
+							return
+						}
+					}
+				}
+
 				messages, err := c.readMessages(ctx, streamKey)
 				if err != nil {
 					errorCh <- fmt.Errorf("error reading messages: %w", err)
@@ -100,37 +126,76 @@ func (c *StreamConsumer) readMessages(ctx context.Context, streamKey string) ([]
 
 	for _, stream := range streams {
 		for _, xmsg := range stream.Messages {
-			// Parse message data
-			data, ok := xmsg.Values["data"].(string)
-			if !ok {
-				fmt.Printf("DEBUG %s: no 'data' field\n", xmsg.ID)
-				continue
+			if msg, ok := c.parseMessage(streamKey, xmsg); ok {
+				messages = append(messages, msg)
 			}
+		}
+	}
+
+	return messages, nil
+}
 
-			// DEBUG: Print raw JSON
-			fmt.Printf("DEBUG %s: raw JSON: %s\n", xmsg.ID, data[:200])
+// claimMessages reclaims idle pending entries and parses the ones to retry
+func (c *StreamConsumer) claimMessages(ctx context.Context, streamKey string) ([]Message, error) {
+	claimed, err := c.recovery.Claim(ctx, streamKey)
 
-			var rawOdds models.RawOdds
-			if err := json.Unmarshal([]byte(data), &rawOdds); err != nil {
-				fmt.Printf("error unmarshaling message %s: %v\n", xmsg.ID, err)
-				// ACK the message anyway to prevent reprocessing
-				c.ackMessage(context.Background(), streamKey, xmsg.ID)
-				continue
-			}
-			
-			// DEBUG: Print parsed odds with all fields
-			fmt.Printf("DEBUG %s: PARSED - sport_key='%s' (len=%d) event_id='%s' market='%s' book='%s' price=%d\n", 
-				xmsg.ID, rawOdds.SportKey, len(rawOdds.SportKey), rawOdds.EventID, rawOdds.MarketKey, rawOdds.BookKey, rawOdds.Price)
-
-			messages = append(messages, Message{
-				ID:       xmsg.ID,
-				RawOdds:  rawOdds,
-				StreamKey: streamKey,
-			})
+	var messages []Message
+	for _, xmsg := range claimed {
+		if msg, ok := c.parseMessage(streamKey, xmsg); ok {
+			messages = append(messages, msg)
 		}
 	}
 
-	return messages, nil
+	return messages, err
+}
+
+// parseMessage decodes a stream entry into a Message
+// Returns false for entries without a "data" field
+func (c *StreamConsumer) parseMessage(streamKey string, xmsg redis.XMessage) (Message, bool) {
+	// Parse message data
+	data, ok := xmsg.Values["data"].(string)
+	if !ok {
+		fmt.Printf("DEBUG %s: no 'data' field\n", xmsg.ID)
+		return Message{}, false
+	}
+
+	// DEBUG: Print raw JSON (short malformed payloads must not panic here)
+	preview := data
+	if len(preview) > 200 {
+		preview = preview[:200]
+	}
+	fmt.Printf("DEBUG %s: raw JSON: %s\n", xmsg.ID, preview)
+
+	// Re-driven dead letters carry their previous attempt count
+	attempts := 0
+	if value, ok := xmsg.Values["attempts"].(string); ok {
+		attempts, _ = strconv.Atoi(value)
+	}
+
+	var rawOdds models.RawOdds
+	if err := json.Unmarshal([]byte(data), &rawOdds); err != nil {
+		fmt.Printf("error unmarshaling message %s: %v\n", xmsg.ID, err)
+		// Hand it to the processor so it is dead-lettered rather than lost
+		return Message{
+			ID:        xmsg.ID,
+			StreamKey: streamKey,
+			Data:      data,
+			Attempts:  attempts,
+			DecodeErr: err,
+		}, true
+	}
+
+	// DEBUG: Print parsed odds with all fields
+	fmt.Printf("DEBUG %s: PARSED - sport_key='%s' (len=%d) event_id='%s' market='%s' book='%s' price=%d\n",
+		xmsg.ID, rawOdds.SportKey, len(rawOdds.SportKey), rawOdds.EventID, rawOdds.MarketKey, rawOdds.BookKey, rawOdds.Price)
+
+	return Message{
+		ID:        xmsg.ID,
+		RawOdds:   rawOdds,
+		StreamKey: streamKey,
+		Data:      data,
+		Attempts:  attempts,
+	}, true
 }
 
 // AckMessage acknowledges a message has been processed
@@ -138,6 +203,11 @@ func (c *StreamConsumer) AckMessage(ctx context.Context, streamKey, messageID st
 	return c.ackMessage(ctx, streamKey, messageID)
 }
 
+// PendingStats returns the backlog and recovery counts for a stream
+func (c *StreamConsumer) PendingStats(ctx context.Context, streamKey string) (PendingStats, error) {
+	return c.recovery.Stats(ctx, streamKey)
+}
+
 // ackMessage internal implementation
 func (c *StreamConsumer) ackMessage(ctx context.Context, streamKey, messageID string) error {
 	return c.redis.XAck(ctx, streamKey, c.groupName, messageID).Err()
@@ -158,5 +228,8 @@ type Message struct {
 	ID        string
 	RawOdds   models.RawOdds
 	StreamKey string
+	Data      string // Original payload, kept for dead-lettering
+	Attempts  int    // Previous failures (non-zero only for re-driven messages)
+	DecodeErr error  // Set when Data could not be decoded into RawOdds
 }
 
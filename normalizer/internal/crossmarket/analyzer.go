@@ -0,0 +1,361 @@
+package crossmarket
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/oddsmath"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/pricing"
+)
+
+// Config controls cross-market consistency checks
+type Config struct {
+	MoneylineMarket string        // Market key of the moneyline
+	SpreadMarket    string        // Market key of the full-game spread
+	MinProbDiff     float64       // Cover probability gap that publishes a warning (e.g. 0.04 = 4%)
+	MinEdge         float64       // EV on the stale market that upgrades a warning to an opportunity
+	TTL             time.Duration // Reported inconsistencies are forgotten after TTL
+}
+
+// DefaultConfig returns cross-market defaults
+func DefaultConfig() Config {
+	return Config{
+		MoneylineMarket: "h2h",
+		SpreadMarket:    "spreads",
+		MinProbDiff:     0.04,
+		MinEdge:         0.01,
+		TTL:             30 * time.Minute,
+	}
+}
+
+// Stats is a point-in-time snapshot of analyzer metrics
+type Stats struct {
+	Tracked       int   `json:"tracked"` // Inconsistent book+event pairs already reported
+	Checks        int64 `json:"checks"`  // Book moneyline/spread pairs compared
+	Warnings      int64 `json:"warnings"`
+	Opportunities int64 `json:"opportunities"`
+}
+
+// Publisher publishes cross-market signals
+type Publisher interface {
+	PublishCrossMarket(ctx context.Context, signal *models.CrossMarketSignal) error
+}
+
+// MarginModel is implemented by sport normalizers with a final margin distribution
+// Sports without one (soccer, generic) are not checked.
+type MarginModel interface {
+	GetMarginDistribution() oddsmath.MarginDistribution
+}
+
+// Lookup returns the latest quotes for the event+market of the given odds
+type Lookup func(odds models.RawOdds) []models.RawOdds
+
+// reported is the book's last published inconsistency for an event
+type reported struct {
+	signature string // Prices the signal was computed from
+	at        time.Time
+}
+
+// Analyzer compares each book's moneyline with its own spread
+// The moneyline is converted into a cover probability at the book's spread point using
+// the sport's margin distribution. When the two disagree by MinProbDiff or more, the
+// market the book updated least recently is treated as stale: if betting it is +EV
+// against the fresher market the signal is an opportunity, otherwise a warning.
+type Analyzer struct {
+	config    Config
+	publisher Publisher
+
+	mu       sync.Mutex
+	reported map[string]reported // event:book -> last published signal
+	stats    Stats
+}
+
+// NewAnalyzer creates a cross-market analyzer
+// publisher may be nil when only Analyze is used
+func NewAnalyzer(config Config, publisher Publisher) *Analyzer {
+	return &Analyzer{
+		config:    config,
+		publisher: publisher,
+		reported:  make(map[string]reported),
+	}
+}
+
+// Handles reports whether quotes in the market can change the comparison
+func (a *Analyzer) Handles(marketKey string) bool {
+	return marketKey == a.config.MoneylineMarket || marketKey == a.config.SpreadMarket
+}
+
+// Check compares the quote's book across markets and publishes any new signal
+func (a *Analyzer) Check(ctx context.Context, raw models.RawOdds, lookup Lookup, dist oddsmath.MarginDistribution, isSharp bool) error {
+	if !a.Handles(raw.MarketKey) {
+		return nil
+	}
+
+	moneyline := lookup(models.RawOdds{EventID: raw.EventID, SportKey: raw.SportKey, MarketKey: a.config.MoneylineMarket})
+	spreads := lookup(models.RawOdds{EventID: raw.EventID, SportKey: raw.SportKey, MarketKey: a.config.SpreadMarket})
+
+	signal := a.Analyze(raw, moneyline, spreads, dist, isSharp)
+	if signal == nil || a.publisher == nil {
+		return nil
+	}
+	return a.publisher.PublishCrossMarket(ctx, signal)
+}
+
+// Analyze returns a signal when the book's moneyline and spread disagree
+// Each inconsistency is returned once; it is reported again only after the book
+// changes one of the prices involved, or after it resolves and reappears.
+func (a *Analyzer) Analyze(raw models.RawOdds, moneyline, spreads []models.RawOdds, dist oddsmath.MarginDistribution, isSharp bool) *models.CrossMarketSignal {
+	signal, signature, ok := a.compare(raw, moneyline, spreads, dist)
+	if !ok {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.stats.Checks++
+	key := raw.EventID + ":" + raw.BookKey
+	if math.Abs(signal.ProbDiff) < a.config.MinProbDiff {
+		delete(a.reported, key)
+		return nil
+	}
+	if last, ok := a.reported[key]; ok && last.signature == signature {
+		return nil
+	}
+	a.reported[key] = reported{signature: signature, at: raw.VendorLastUpdate}
+
+	signal.IsSharp = isSharp
+	if signal.Bet != nil && signal.Bet.EdgePct >= a.config.MinEdge*100 {
+		signal.Type = models.CrossMarketOpportunity
+		a.stats.Opportunities++
+	} else {
+		signal.Type = models.CrossMarketWarning
+		signal.Bet = nil
+		a.stats.Warnings++
+	}
+	return signal
+}
+
+// compare builds the book's comparison and a signature of the prices it used
+func (a *Analyzer) compare(raw models.RawOdds, moneyline, spreads []models.RawOdds, dist oddsmath.MarginDistribution) (*models.CrossMarketSignal, string, bool) {
+	moneyline = bookQuotes(moneyline, raw.BookKey)
+	spreads = bookQuotes(spreads, raw.BookKey)
+
+	// Moneyline: two teams and optionally a draw
+	var teams []models.RawOdds
+	hasDraw := false
+	for _, odds := range moneyline {
+		if strings.EqualFold(odds.OutcomeName, "Draw") {
+			hasDraw = true
+			continue
+		}
+		teams = append(teams, odds)
+	}
+	if len(teams) != 2 {
+		return nil, "", false
+	}
+
+	teamProb, err := oddsmath.RawImpliedProbability(teams[0])
+	if err != nil {
+		return nil, "", false
+	}
+	otherProb, err := oddsmath.RawImpliedProbability(teams[1])
+	if err != nil {
+		return nil, "", false
+	}
+
+	// Compare from the favorite's side (the draw drops out of the two-team share)
+	team, other := teams[0], teams[1]
+	if otherProb > teamProb || (otherProb == teamProb && other.OutcomeName < team.OutcomeName) {
+		team, other = other, team
+		teamProb, otherProb = otherProb, teamProb
+	}
+	winProb := teamProb / (teamProb + otherProb)
+
+	// Spread: the favorite's line and the mirrored side
+	var teamSpread *models.RawOdds
+	for i := range spreads {
+		if spreads[i].OutcomeName == team.OutcomeName && spreads[i].Point != nil {
+			teamSpread = &spreads[i]
+			break
+		}
+	}
+	if teamSpread == nil {
+		return nil, "", false
+	}
+	otherSpread := pricing.FindOppositeSide(*teamSpread, spreads)
+	if otherSpread == nil {
+		return nil, "", false
+	}
+
+	coverProb, err := oddsmath.RawImpliedProbability(*teamSpread)
+	if err != nil {
+		return nil, "", false
+	}
+	otherCoverProb, err := oddsmath.RawImpliedProbability(*otherSpread)
+	if err != nil {
+		return nil, "", false
+	}
+	spreadProb := coverProb / (coverProb + otherCoverProb)
+
+	point := *teamSpread.Point
+	impliedProb, err := oddsmath.MoneylineToSpreadProbability(winProb, point, dist)
+	if err != nil {
+		return nil, "", false
+	}
+	impliedSpread, err := oddsmath.ImpliedSpread(winProb, dist)
+	if err != nil {
+		return nil, "", false
+	}
+
+	signal := &models.CrossMarketSignal{
+		EventID:           raw.EventID,
+		SportKey:          raw.SportKey,
+		BookKey:           raw.BookKey,
+		Team:              team.OutcomeName,
+		MoneylinePrice:    team.Price,
+		MoneylineProb:     winProb,
+		ImpliedSpread:     impliedSpread,
+		SpreadPoint:       point,
+		SpreadPrice:       teamSpread.Price,
+		SpreadProb:        spreadProb,
+		ImpliedSpreadProb: impliedProb,
+		ProbDiff:          impliedProb - spreadProb,
+		DetectedAt:        raw.VendorLastUpdate,
+	}
+
+	// The market the book touched least recently is the one that hasn't caught up
+	moneylineUpdated := latest(team, other)
+	spreadUpdated := latest(*teamSpread, *otherSpread)
+	if moneylineUpdated.Before(spreadUpdated) {
+		signal.StaleMarket = a.config.MoneylineMarket
+		signal.Bet = a.moneylineBet(team, other, spreadProb, point, hasDraw, dist)
+	} else {
+		signal.StaleMarket = a.config.SpreadMarket
+		signal.Bet = a.spreadBet(*teamSpread, *otherSpread, impliedProb)
+	}
+
+	signature := fmt.Sprintf("%d/%d|%g:%d/%d", team.Price, other.Price, point, teamSpread.Price, otherSpread.Price)
+	return signal, signature, true
+}
+
+// spreadBet prices the stale spread with the cover probability implied by the moneyline
+func (a *Analyzer) spreadBet(teamSpread, otherSpread models.RawOdds, impliedProb float64) *models.CrossMarketBet {
+	if bet := newBet(teamSpread, impliedProb); bet != nil && bet.EdgePct > 0 {
+		return bet
+	}
+	if bet := newBet(otherSpread, 1-impliedProb); bet != nil && bet.EdgePct > 0 {
+		return bet
+	}
+	return nil
+}
+
+// moneylineBet prices the stale moneyline with the win probability implied by the spread
+// When the book also prices the draw, a tie loses the bet instead of refunding it.
+func (a *Analyzer) moneylineBet(team, other models.RawOdds, spreadProb, point float64, hasDraw bool, dist oddsmath.MarginDistribution) *models.CrossMarketBet {
+	winProb, err := oddsmath.AdjustSpreadProbability(spreadProb, point, 0, dist)
+	if err != nil {
+		return nil
+	}
+
+	noTie := 1.0
+	if hasDraw {
+		noTie = 1 - dist.PushProbability(0)
+	}
+
+	if bet := newBet(team, winProb*noTie); bet != nil && bet.EdgePct > 0 {
+		return bet
+	}
+	if bet := newBet(other, (1-winProb)*noTie); bet != nil && bet.EdgePct > 0 {
+		return bet
+	}
+	return nil
+}
+
+// newBet prices a quote at a fair probability
+func newBet(odds models.RawOdds, fairProb float64) *models.CrossMarketBet {
+	decimal, err := oddsmath.RawDecimal(odds)
+	if err != nil {
+		return nil
+	}
+
+	return &models.CrossMarketBet{
+		MarketKey:   odds.MarketKey,
+		OutcomeName: odds.OutcomeName,
+		Point:       odds.Point,
+		Price:       odds.Price,
+		FairProb:    fairProb,
+		EdgePct:     (fairProb*decimal - 1) * 100,
+	}
+}
+
+// Sweep forgets reported inconsistencies older than the TTL
+// Returns the number removed
+func (a *Analyzer) Sweep(now time.Time) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	cutoff := now.Add(-a.config.TTL)
+	removed := 0
+	for key, last := range a.reported {
+		if last.at.Before(cutoff) {
+			delete(a.reported, key)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Run sweeps reported inconsistencies every TTL until ctx is cancelled
+func (a *Analyzer) Run(ctx context.Context) {
+	interval := a.config.TTL
+	if interval <= 0 {
+		interval = DefaultConfig().TTL
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			a.Sweep(now)
+		}
+	}
+}
+
+// Stats returns a snapshot of analyzer metrics
+func (a *Analyzer) Stats() Stats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	stats := a.stats
+	stats.Tracked = len(a.reported)
+	return stats
+}
+
+// bookQuotes returns one book's quotes
+func bookQuotes(quotes []models.RawOdds, bookKey string) []models.RawOdds {
+	var filtered []models.RawOdds
+	for _, odds := range quotes {
+		if odds.BookKey == bookKey {
+			filtered = append(filtered, odds)
+		}
+	}
+	return filtered
+}
+
+// latest returns the most recent vendor update of two quotes
+func latest(a, b models.RawOdds) time.Time {
+	if a.VendorLastUpdate.After(b.VendorLastUpdate) {
+		return a.VendorLastUpdate
+	}
+	return b.VendorLastUpdate
+}
@@ -0,0 +1,43 @@
+package crossmarket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// StreamPublisher publishes cross-market signals to Redis Streams
+type StreamPublisher struct {
+	redis *redis.Client
+}
+
+// NewStreamPublisher creates a cross-market stream publisher
+func NewStreamPublisher(redisClient *redis.Client) *StreamPublisher {
+	return &StreamPublisher{redis: redisClient}
+}
+
+// PublishCrossMarket publishes a cross-market signal
+// Stream key format: odds.crossmarket.{sport_key}
+func (p *StreamPublisher) PublishCrossMarket(ctx context.Context, signal *models.CrossMarketSignal) error {
+	streamKey := fmt.Sprintf("odds.crossmarket.%s", signal.SportKey)
+
+	data, err := json.Marshal(signal)
+	if err != nil {
+		return fmt.Errorf("error marshaling cross-market signal: %w", err)
+	}
+
+	if err := p.redis.XAdd(ctx, &redis.XAddArgs{
+		Stream: streamKey,
+		Values: map[string]interface{}{
+			"type": string(signal.Type),
+			"data": string(data),
+		},
+	}).Err(); err != nil {
+		return fmt.Errorf("error publishing to stream %s: %w", streamKey, err)
+	}
+
+	return nil
+}
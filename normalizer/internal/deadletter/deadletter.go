@@ -0,0 +1,251 @@
+package deadletter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/shared/streams"
+	"github.com/redis/go-redis/v9"
+)
+
+// Error classes recorded on dead-lettered messages
+const (
+	ClassDecode        = "decode"         // Payload is not valid RawOdds JSON
+	ClassNoNormalizer  = "no_normalizer"  // Sport has no registered normalizer
+	ClassNormalization = "normalization"  // Normalizer rejected the odds
+	ClassPublish       = "publish"        // Normalized odds could not be published
+	ClassMaxDeliveries = "max_deliveries" // Redelivered too many times without an ack
+	ClassUnknown       = "unknown"
+)
+
+// Error tags a processing error with its dead-letter class
+type Error struct {
+	Class string
+	Err   error
+}
+
+// NewError wraps err with a dead-letter class
+func NewError(class string, err error) *Error {
+	return &Error{Class: class, Err: err}
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Classify returns the dead-letter class of an error
+func Classify(err error) string {
+	var dlErr *Error
+	if errors.As(err, &dlErr) {
+		return dlErr.Class
+	}
+	return ClassUnknown
+}
+
+// Entry is a failed message on a dead-letter stream
+type Entry struct {
+	ID           string    `json:"id"` // Dead-letter stream ID (set when read)
+	SportKey     string    `json:"sport_key"`
+	SourceStream string    `json:"source_stream"` // Stream the message is re-driven to
+	SourceID     string    `json:"source_id"`     // Original message ID
+	ErrorClass   string    `json:"error_class"`
+	ErrorMessage string    `json:"error_message"`
+	Attempts     int       `json:"attempts"` // Times the message has failed
+	Payload      string    `json:"payload"`  // Original "data" field, unmodified
+	FailedAt     time.Time `json:"failed_at"`
+}
+
+// StreamKey returns the dead-letter stream for a sport's raw odds stream
+// Stream key format: odds.deadletter.{sport_key}
+func StreamKey(sportKey string) string {
+	return streams.DeadLetterStream(fmt.Sprintf("odds.raw.%s", sportKey))
+}
+
+// SportFromStream extracts the sport from a source stream key (odds.raw.{sport_key})
+func SportFromStream(streamKey string) string {
+	if i := strings.LastIndex(streamKey, "."); i >= 0 {
+		return streamKey[i+1:]
+	}
+	return streamKey
+}
+
+// Values encodes the entry as stream fields
+func (e Entry) Values() map[string]interface{} {
+	return map[string]interface{}{
+		"sport_key":     e.SportKey,
+		"source_stream": e.SourceStream,
+		"source_id":     e.SourceID,
+		"error_class":   e.ErrorClass,
+		"error_message": e.ErrorMessage,
+		"attempts":      strconv.Itoa(e.Attempts),
+		"data":          e.Payload,
+		"failed_at":     e.FailedAt.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+// EntryFromMessage decodes a dead-letter stream message
+func EntryFromMessage(msg redis.XMessage) Entry {
+	field := func(key string) string {
+		value, _ := msg.Values[key].(string)
+		return value
+	}
+
+	attempts, _ := strconv.Atoi(field("attempts"))
+	failedAt, _ := time.Parse(time.RFC3339Nano, field("failed_at"))
+
+	return Entry{
+		ID:           msg.ID,
+		SportKey:     field("sport_key"),
+		SourceStream: field("source_stream"),
+		SourceID:     field("source_id"),
+		ErrorClass:   field("error_class"),
+		ErrorMessage: field("error_message"),
+		Attempts:     attempts,
+		Payload:      field("data"),
+		FailedAt:     failedAt,
+	}
+}
+
+// Queue writes, inspects and re-drives dead-letter streams in Redis
+type Queue struct {
+	redis  *redis.Client
+	maxLen int64
+}
+
+// NewQueue creates a dead-letter queue
+// maxLen caps each dead-letter stream (approximate trim, 0 = unbounded)
+func NewQueue(redisClient *redis.Client, maxLen int64) *Queue {
+	return &Queue{
+		redis:  redisClient,
+		maxLen: maxLen,
+	}
+}
+
+// Write appends a failed message to its sport's dead-letter stream
+func (q *Queue) Write(ctx context.Context, entry Entry) error {
+	args := &redis.XAddArgs{
+		Stream: StreamKey(entry.SportKey),
+		Values: entry.Values(),
+	}
+	if q.maxLen > 0 {
+		args.MaxLen = q.maxLen
+		args.Approx = true
+	}
+
+	if err := q.redis.XAdd(ctx, args).Err(); err != nil {
+		return fmt.Errorf("error writing dead letter for %s: %w", entry.SourceID, err)
+	}
+	return nil
+}
+
+// List returns up to count entries, oldest first
+func (q *Queue) List(ctx context.Context, sportKey string, count int64) ([]Entry, error) {
+	msgs, err := q.redis.XRangeN(ctx, StreamKey(sportKey), "-", "+", count).Result()
+	if err != nil {
+		return nil, fmt.Errorf("error reading dead letters: %w", err)
+	}
+
+	entries := make([]Entry, len(msgs))
+	for i, msg := range msgs {
+		entries[i] = EntryFromMessage(msg)
+	}
+	return entries, nil
+}
+
+// Count returns the number of entries on a sport's dead-letter stream
+func (q *Queue) Count(ctx context.Context, sportKey string) (int64, error) {
+	return q.redis.XLen(ctx, StreamKey(sportKey)).Result()
+}
+
+// Purge deletes the given entries, or the whole stream when no IDs are given
+// Returns the number of entries removed
+func (q *Queue) Purge(ctx context.Context, sportKey string, ids ...string) (int64, error) {
+	streamKey := StreamKey(sportKey)
+
+	if len(ids) == 0 {
+		count, err := q.redis.XLen(ctx, streamKey).Result()
+		if err != nil {
+			return 0, fmt.Errorf("error counting dead letters: %w", err)
+		}
+		if err := q.redis.Del(ctx, streamKey).Err(); err != nil {
+			return 0, fmt.Errorf("error purging dead letters: %w", err)
+		}
+		return count, nil
+	}
+
+	removed, err := q.redis.XDel(ctx, streamKey, ids...).Result()
+	if err != nil {
+		return 0, fmt.Errorf("error purging dead letters: %w", err)
+	}
+	return removed, nil
+}
+
+// Redrive re-publishes entries to their source stream and removes them
+// With no IDs every entry (up to limit) is re-driven; explicit IDs are looked up
+// directly, wherever they sit in the stream, and nothing is re-driven if any of
+// them is missing. The attempt count travels with the message so a repeat
+// failure is recorded as attempt N+1.
+// Returns the number of entries re-driven.
+func (q *Queue) Redrive(ctx context.Context, sportKey string, limit int64, ids ...string) (int, error) {
+	var entries []Entry
+	var err error
+	if len(ids) == 0 {
+		entries, err = q.List(ctx, sportKey, limit)
+	} else {
+		entries, err = q.get(ctx, sportKey, ids)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	redriven := 0
+	for _, entry := range entries {
+		if err := q.redis.XAdd(ctx, &redis.XAddArgs{
+			Stream: entry.SourceStream,
+			Values: map[string]interface{}{
+				"data":     entry.Payload,
+				"attempts": strconv.Itoa(entry.Attempts),
+			},
+		}).Err(); err != nil {
+			return redriven, fmt.Errorf("error re-driving %s: %w", entry.ID, err)
+		}
+
+		if err := q.redis.XDel(ctx, StreamKey(sportKey), entry.ID).Err(); err != nil {
+			return redriven, fmt.Errorf("error removing re-driven %s: %w", entry.ID, err)
+		}
+		redriven++
+	}
+
+	return redriven, nil
+}
+
+// get reads the given entries by ID, failing if any is not on the stream
+func (q *Queue) get(ctx context.Context, sportKey string, ids []string) ([]Entry, error) {
+	entries := make([]Entry, 0, len(ids))
+	var missing []string
+
+	for _, id := range ids {
+		msgs, err := q.redis.XRange(ctx, StreamKey(sportKey), id, id).Result()
+		if err != nil {
+			return nil, fmt.Errorf("error reading dead letter %s: %w", id, err)
+		}
+		if len(msgs) == 0 {
+			missing = append(missing, id)
+			continue
+		}
+		entries = append(entries, EntryFromMessage(msgs[0]))
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("dead letters not found on %s: %s", StreamKey(sportKey), strings.Join(missing, ", "))
+	}
+	return entries, nil
+}
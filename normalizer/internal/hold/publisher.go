@@ -0,0 +1,55 @@
+package hold
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisPublisher writes hold summaries to a Redis hash per sport
+type RedisPublisher struct {
+	redis  *redis.Client
+	expiry time.Duration
+}
+
+// NewRedisPublisher creates a hold publisher
+// expiry lets a summary disappear if the normalizer stops publishing (0 = never)
+func NewRedisPublisher(redisClient *redis.Client, expiry time.Duration) *RedisPublisher {
+	return &RedisPublisher{redis: redisClient, expiry: expiry}
+}
+
+// PublishHold replaces the sport's summary hash
+// Key format: books.hold.{sport_key}, field {market_type}:{book_key}
+func (p *RedisPublisher) PublishHold(ctx context.Context, sportKey string, summaries []models.BookHoldSummary) error {
+	key := fmt.Sprintf("books.hold.%s", sportKey)
+
+	fields := make(map[string]interface{}, len(summaries))
+	for _, summary := range summaries {
+		data, err := json.Marshal(summary)
+		if err != nil {
+			return fmt.Errorf("error marshaling hold summary: %w", err)
+		}
+		fields[summary.MarketType+":"+summary.BookKey] = string(data)
+	}
+
+	// Replace atomically so books that dropped out don't linger
+	_, err := p.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		if len(fields) > 0 {
+			pipe.HSet(ctx, key, fields)
+			if p.expiry > 0 {
+				pipe.Expire(ctx, key, p.expiry)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error writing %s: %w", key, err)
+	}
+
+	return nil
+}
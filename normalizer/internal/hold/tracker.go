@@ -0,0 +1,305 @@
+package hold
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/oddsmath"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/pricing"
+)
+
+// Config controls per-book hold tracking
+type Config struct {
+	TTL             time.Duration // Drop a market's hold when the book hasn't quoted it within TTL
+	PublishInterval time.Duration // How often summaries are published
+}
+
+// DefaultConfig returns hold tracking defaults
+func DefaultConfig() Config {
+	return Config{
+		TTL:             30 * time.Minute,
+		PublishInterval: 30 * time.Second,
+	}
+}
+
+// Stats is a point-in-time snapshot of tracker metrics
+type Stats struct {
+	Markets   int   `json:"markets"`   // Complete book markets held
+	Published int64 `json:"published"` // Summaries published
+	Expired   int64 `json:"expired"`   // Markets removed by Sweep
+}
+
+// Publisher publishes per-book hold summaries for a sport
+type Publisher interface {
+	PublishHold(ctx context.Context, sportKey string, summaries []models.BookHoldSummary) error
+}
+
+// marketHold is one book's latest hold on one event+market line
+type marketHold struct {
+	sportKey     string
+	marketType   string
+	bookKey      string
+	isSharp      bool
+	overroundPct float64
+	holdPct      float64
+	updatedAt    time.Time
+}
+
+// Tracker keeps each book's latest hold per event+market and rolls it up by sport and market type
+// A market only counts once the book prices every outcome (both sides of a two-way
+// line, or all outcomes of a moneyline).
+type Tracker struct {
+	config    Config
+	publisher Publisher
+
+	mu      sync.Mutex
+	markets map[string]*marketHold // book market key -> latest hold
+	stats   Stats
+}
+
+// NewTracker creates a hold tracker
+// publisher may be nil when only Summary is used
+func NewTracker(config Config, publisher Publisher) *Tracker {
+	return &Tracker{
+		config:    config,
+		publisher: publisher,
+		markets:   make(map[string]*marketHold),
+	}
+}
+
+// Observe records the book's hold for the quote's market, if the book's market is complete
+// marketOdds is the latest quote per book for the event+market; outcomeCount is the
+// number of outcomes a complete market has (0 = any two or more).
+func (t *Tracker) Observe(raw models.RawOdds, marketType models.MarketType, marketOdds []models.RawOdds, outcomeCount int, isSharp bool) bool {
+	overroundPct, holdPct, ok := CalculateBookHold(raw, marketType, marketOdds, outcomeCount)
+	if !ok {
+		return false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.markets[marketKey(raw)] = &marketHold{
+		sportKey:     raw.SportKey,
+		marketType:   string(marketType),
+		bookKey:      raw.BookKey,
+		isSharp:      isSharp,
+		overroundPct: overroundPct,
+		holdPct:      holdPct,
+		updatedAt:    raw.VendorLastUpdate,
+	}
+	return true
+}
+
+// CalculateBookHold returns a book's overround and hold (both in percent) for the quote's market
+// Two-way and props markets use the quote and its opposite side; moneylines use every
+// outcome the book prices. Double chance outcomes each cover two results, so their
+// probabilities are halved before measuring the margin.
+func CalculateBookHold(raw models.RawOdds, marketType models.MarketType, marketOdds []models.RawOdds, outcomeCount int) (overroundPct, holdPct float64, ok bool) {
+	impliedProb, err := oddsmath.RawImpliedProbability(raw)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	var probs []float64
+	switch marketType {
+	case models.MarketTypeTwoWay, models.MarketTypeProps:
+		opposite := pricing.FindOppositeSide(raw, marketOdds)
+		if opposite == nil {
+			return 0, 0, false
+		}
+		oppositeProb, err := oddsmath.RawImpliedProbability(*opposite)
+		if err != nil {
+			return 0, 0, false
+		}
+		probs = []float64{impliedProb, oppositeProb}
+
+	case models.MarketTypeThreeWay:
+		probs = pricing.CollectBookOutcomes(impliedProb, raw, marketOdds)
+		if (outcomeCount > 0 && len(probs) != outcomeCount) || len(probs) < 2 {
+			return 0, 0, false
+		}
+
+	case models.MarketTypeDoubleChance:
+		probs = pricing.CollectBookOutcomes(impliedProb, raw, marketOdds)
+		if len(probs) != 3 {
+			return 0, 0, false
+		}
+		for i := range probs {
+			probs[i] /= 2
+		}
+
+	default:
+		return 0, 0, false
+	}
+
+	overroundPct, err = oddsmath.CalculateVigPercentage(probs)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	// Hold is the share of total stakes the book keeps when action is balanced
+	total := 1 + overroundPct/100
+	holdPct = (1 - 1/total) * 100
+
+	return overroundPct, holdPct, true
+}
+
+// Summary rolls up tracked markets by market type and book for a sport
+// Sorted by market type, then lowest average hold first.
+func (t *Tracker) Summary(sportKey string) []models.BookHoldSummary {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	groups := make(map[string]*models.BookHoldSummary)
+	for _, market := range t.markets {
+		if market.sportKey != sportKey {
+			continue
+		}
+
+		key := market.marketType + ":" + market.bookKey
+		summary, ok := groups[key]
+		if !ok {
+			summary = &models.BookHoldSummary{
+				SportKey:   sportKey,
+				MarketType: market.marketType,
+				BookKey:    market.bookKey,
+				IsSharp:    market.isSharp,
+				MinHoldPct: market.holdPct,
+				MaxHoldPct: market.holdPct,
+			}
+			groups[key] = summary
+		}
+
+		summary.Markets++
+		summary.AvgHoldPct += market.holdPct
+		summary.AvgOverroundPct += market.overroundPct
+		summary.MinHoldPct = math.Min(summary.MinHoldPct, market.holdPct)
+		summary.MaxHoldPct = math.Max(summary.MaxHoldPct, market.holdPct)
+		if market.updatedAt.After(summary.UpdatedAt) {
+			summary.UpdatedAt = market.updatedAt
+		}
+	}
+
+	summaries := make([]models.BookHoldSummary, 0, len(groups))
+	for _, summary := range groups {
+		summary.AvgHoldPct /= float64(summary.Markets)
+		summary.AvgOverroundPct /= float64(summary.Markets)
+		summaries = append(summaries, *summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].MarketType != summaries[j].MarketType {
+			return summaries[i].MarketType < summaries[j].MarketType
+		}
+		if summaries[i].AvgHoldPct != summaries[j].AvgHoldPct {
+			return summaries[i].AvgHoldPct < summaries[j].AvgHoldPct
+		}
+		return summaries[i].BookKey < summaries[j].BookKey
+	})
+
+	return summaries
+}
+
+// Sports returns the sports with tracked markets
+func (t *Tracker) Sports() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	seen := make(map[string]struct{})
+	for _, market := range t.markets {
+		seen[market.sportKey] = struct{}{}
+	}
+
+	sports := make([]string, 0, len(seen))
+	for sportKey := range seen {
+		sports = append(sports, sportKey)
+	}
+	sort.Strings(sports)
+	return sports
+}
+
+// Sweep removes markets not quoted within the TTL
+// Returns the number of markets removed
+func (t *Tracker) Sweep(now time.Time) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cutoff := now.Add(-t.config.TTL)
+	removed := 0
+	for key, market := range t.markets {
+		if market.updatedAt.Before(cutoff) {
+			delete(t.markets, key)
+			removed++
+		}
+	}
+
+	t.stats.Expired += int64(removed)
+	return removed
+}
+
+// Run sweeps and publishes summaries every PublishInterval until ctx is cancelled
+func (t *Tracker) Run(ctx context.Context) {
+	interval := t.config.PublishInterval
+	if interval <= 0 {
+		interval = DefaultConfig().PublishInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			t.Sweep(now)
+			if err := t.Publish(ctx); err != nil {
+				fmt.Printf("⚠️  Failed to publish book hold: %v\n", err)
+			}
+		}
+	}
+}
+
+// Publish publishes the current summary for every tracked sport
+func (t *Tracker) Publish(ctx context.Context) error {
+	if t.publisher == nil {
+		return nil
+	}
+
+	for _, sportKey := range t.Sports() {
+		summaries := t.Summary(sportKey)
+		if err := t.publisher.PublishHold(ctx, sportKey, summaries); err != nil {
+			return fmt.Errorf("error publishing hold for %s: %w", sportKey, err)
+		}
+
+		t.mu.Lock()
+		t.stats.Published += int64(len(summaries))
+		t.mu.Unlock()
+	}
+	return nil
+}
+
+// Stats returns a snapshot of tracker metrics
+func (t *Tracker) Stats() Stats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := t.stats
+	stats.Markets = len(t.markets)
+	return stats
+}
+
+// marketKey identifies one book's line in an event+market (both sides of a spread share it)
+func marketKey(odds models.RawOdds) string {
+	line := ""
+	if odds.Point != nil {
+		line = fmt.Sprintf("%g", math.Abs(*odds.Point))
+	}
+	return fmt.Sprintf("%s:%s:%s:%s:%s:%s", odds.SportKey, odds.EventID, odds.MarketKey, odds.Description, line, odds.BookKey)
+}
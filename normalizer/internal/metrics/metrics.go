@@ -0,0 +1,188 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/consumer"
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/deadletter"
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/marketstate"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "normalizer"
+
+// scrapeTimeout bounds the Redis calls made while collecting stream gauges
+const scrapeTimeout = 2 * time.Second
+
+// latencyBuckets cover sub-millisecond normalization up to multi-second stream backlogs
+var latencyBuckets = []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30}
+
+// Recorder holds the normalizer's Prometheus collectors
+// A nil *Recorder is valid and records nothing (replay and tests run without one).
+type Recorder struct {
+	registry *prometheus.Registry
+
+	processed    *prometheus.CounterVec
+	errors       *prometheus.CounterVec
+	deadLettered *prometheus.CounterVec
+	latency      *prometheus.HistogramVec
+}
+
+// MarketCacheFunc reports the market state store's current size
+type MarketCacheFunc func() marketstate.Stats
+
+// PendingFunc reports backlog and recovery counts for every consumed stream
+type PendingFunc func(ctx context.Context) ([]consumer.PendingStats, error)
+
+// NewRecorder creates a recorder with its own registry (plus Go and process collectors)
+func NewRecorder() *Recorder {
+	r := &Recorder{
+		registry: prometheus.NewRegistry(),
+		processed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "messages_processed_total",
+			Help:      "Raw odds messages normalized and published.",
+		}, []string{"sport", "stream"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "messages_errors_total",
+			Help:      "Raw odds messages that failed, by dead-letter error class.",
+		}, []string{"sport", "stream", "class"}),
+		deadLettered: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "messages_dead_lettered_total",
+			Help:      "Failed messages written to odds.deadletter.{sport}.",
+		}, []string{"sport", "stream"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "normalize_latency_seconds",
+			Help:      "Time from received_at (ingestion) to normalized_at.",
+			Buckets:   latencyBuckets,
+		}, []string{"sport", "stream"}),
+	}
+
+	r.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		r.processed,
+		r.errors,
+		r.deadLettered,
+		r.latency,
+	)
+	return r
+}
+
+// Registry returns the registry served at /metrics
+func (r *Recorder) Registry() *prometheus.Registry {
+	return r.registry
+}
+
+// Processed counts a published message and observes its ingestion → normalization latency
+func (r *Recorder) Processed(streamKey string, receivedAt, normalizedAt time.Time) {
+	if r == nil {
+		return
+	}
+	sport := deadletter.SportFromStream(streamKey)
+	r.processed.WithLabelValues(sport, streamKey).Inc()
+	if !receivedAt.IsZero() && !normalizedAt.Before(receivedAt) {
+		r.latency.WithLabelValues(sport, streamKey).Observe(normalizedAt.Sub(receivedAt).Seconds())
+	}
+}
+
+// Error counts a failed message under its dead-letter class
+func (r *Recorder) Error(streamKey string, err error) {
+	if r == nil {
+		return
+	}
+	r.errors.WithLabelValues(deadletter.SportFromStream(streamKey), streamKey, deadletter.Classify(err)).Inc()
+}
+
+// DeadLettered counts a failed message written to the dead-letter stream
+func (r *Recorder) DeadLettered(streamKey string) {
+	if r == nil {
+		return
+	}
+	r.deadLettered.WithLabelValues(deadletter.SportFromStream(streamKey), streamKey).Inc()
+}
+
+// WatchMarketCache exports the market state store's size, read on every scrape
+func (r *Recorder) WatchMarketCache(stats MarketCacheFunc) {
+	r.registry.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "market_cache_markets",
+			Help:      "Event+market groups held in the market state store.",
+		}, func() float64 { return float64(stats().Markets) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "market_cache_quotes",
+			Help:      "Book quotes held across all cached markets.",
+		}, func() float64 { return float64(stats().Quotes) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "market_cache_expired_total",
+			Help:      "Markets dropped after their TTL.",
+		}, func() float64 { return float64(stats().Expired) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "market_cache_evicted_total",
+			Help:      "Markets evicted to stay under the market cap.",
+		}, func() float64 { return float64(stats().Evicted) }),
+	)
+}
+
+// WatchStreams exports consumer group lag and pending counts, read on every scrape
+func (r *Recorder) WatchStreams(pending PendingFunc) {
+	r.registry.MustRegister(newStreamCollector(pending))
+}
+
+// streamCollector reads consumer group state from Redis when scraped
+type streamCollector struct {
+	pending PendingFunc
+
+	lag          *prometheus.Desc
+	pendingCount *prometheus.Desc
+	claimed      *prometheus.Desc
+	deadLettered *prometheus.Desc
+}
+
+func newStreamCollector(pending PendingFunc) *streamCollector {
+	labels := []string{"sport", "stream"}
+	return &streamCollector{
+		pending: pending,
+		lag: prometheus.NewDesc(prometheus.BuildFQName(namespace, "stream", "lag"),
+			"Entries not yet delivered to the consumer group (-1 if unknown).", labels, nil),
+		pendingCount: prometheus.NewDesc(prometheus.BuildFQName(namespace, "stream", "pending"),
+			"Entries delivered to the consumer group but not acknowledged.", labels, nil),
+		claimed: prometheus.NewDesc(prometheus.BuildFQName(namespace, "stream", "claimed_total"),
+			"Idle entries reclaimed by this consumer.", labels, nil),
+		deadLettered: prometheus.NewDesc(prometheus.BuildFQName(namespace, "stream", "dead_lettered_total"),
+			"Entries dead-lettered by recovery after MaxDeliveries.", labels, nil),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *streamCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.lag
+	ch <- c.pendingCount
+	ch <- c.claimed
+	ch <- c.deadLettered
+}
+
+// Collect implements prometheus.Collector
+// Streams read before a Redis error are still exported.
+func (c *streamCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), scrapeTimeout)
+	defer cancel()
+
+	stats, _ := c.pending(ctx)
+	for _, stream := range stats {
+		sport := deadletter.SportFromStream(stream.Stream)
+		ch <- prometheus.MustNewConstMetric(c.lag, prometheus.GaugeValue, float64(stream.Lag), sport, stream.Stream)
+		ch <- prometheus.MustNewConstMetric(c.pendingCount, prometheus.GaugeValue, float64(stream.Pending), sport, stream.Stream)
+		ch <- prometheus.MustNewConstMetric(c.claimed, prometheus.CounterValue, float64(stream.Claimed), sport, stream.Stream)
+		ch <- prometheus.MustNewConstMetric(c.deadLettered, prometheus.CounterValue, float64(stream.DeadLettered), sport, stream.Stream)
+	}
+}
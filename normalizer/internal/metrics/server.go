@@ -0,0 +1,158 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// checkTimeout bounds each dependency check behind /health
+const checkTimeout = 2 * time.Second
+
+// Health statuses reported by /health
+const (
+	StatusHealthy   = "healthy"   // Every check passed
+	StatusDegraded  = "degraded"  // Only optional checks failed
+	StatusUnhealthy = "unhealthy" // A required check failed (served as 503)
+)
+
+// CheckFunc pings a dependency (e.g. redis.Client.Ping, sql.DB.PingContext)
+type CheckFunc func(ctx context.Context) error
+
+// check is a named dependency behind /health
+type check struct {
+	name     string
+	required bool
+	fn       CheckFunc
+}
+
+// CheckResult is one dependency's entry in the /health response
+type CheckResult struct {
+	Status    string `json:"status"` // "ok" or "error"
+	Required  bool   `json:"required"`
+	Error     string `json:"error,omitempty"`
+	LatencyMs int64  `json:"latency_ms"`
+}
+
+// HealthResponse is the /health body
+type HealthResponse struct {
+	Status  string                 `json:"status"`
+	Service string                 `json:"service"`
+	Checks  map[string]CheckResult `json:"checks"`
+}
+
+// Server serves /metrics and /health
+type Server struct {
+	addr     string
+	service  string
+	recorder *Recorder
+	checks   []check
+}
+
+// NewServer creates a metrics and health server listening on addr
+func NewServer(addr, service string, recorder *Recorder) *Server {
+	return &Server{
+		addr:     addr,
+		service:  service,
+		recorder: recorder,
+	}
+}
+
+// AddCheck registers a dependency for /health
+// A failing required check makes the service unhealthy (503); optional checks only degrade it.
+func (s *Server) AddCheck(name string, required bool, fn CheckFunc) {
+	s.checks = append(s.checks, check{name: name, required: required, fn: fn})
+}
+
+// Handler returns the HTTP routes
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(s.recorder.Registry(), promhttp.HandlerOpts{}))
+	mux.HandleFunc("/health", s.handleHealth)
+	return mux
+}
+
+// Run serves until ctx is cancelled, then shuts down
+func (s *Server) Run(ctx context.Context) error {
+	server := &http.Server{
+		Addr:              s.addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		if err != nil && err != http.ErrServerClosed {
+			return fmt.Errorf("metrics server error: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+// Health runs every check concurrently and summarizes them
+func (s *Server) Health(ctx context.Context) HealthResponse {
+	response := HealthResponse{
+		Status:  StatusHealthy,
+		Service: s.service,
+		Checks:  make(map[string]CheckResult, len(s.checks)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, c := range s.checks {
+		wg.Add(1)
+		go func(c check) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err := c.fn(checkCtx)
+			result := CheckResult{Status: "ok", Required: c.required, LatencyMs: time.Since(start).Milliseconds()}
+			if err != nil {
+				result.Status = "error"
+				result.Error = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			response.Checks[c.name] = result
+			switch {
+			case err == nil:
+			case c.required:
+				response.Status = StatusUnhealthy
+			case response.Status == StatusHealthy:
+				response.Status = StatusDegraded
+			}
+		}(c)
+	}
+	wg.Wait()
+
+	return response
+}
+
+// handleHealth serves the health summary (503 when a required dependency is down)
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	health := s.Health(r.Context())
+
+	w.Header().Set("Content-Type", "application/json")
+	if health.Status == StatusUnhealthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(health)
+}
@@ -0,0 +1,43 @@
+package movement
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// StreamPublisher publishes movement events to Redis Streams
+type StreamPublisher struct {
+	redis *redis.Client
+}
+
+// NewStreamPublisher creates a movement stream publisher
+func NewStreamPublisher(redisClient *redis.Client) *StreamPublisher {
+	return &StreamPublisher{redis: redisClient}
+}
+
+// PublishMovement publishes a movement event
+// Stream key format: odds.movements.{sport_key}
+func (p *StreamPublisher) PublishMovement(ctx context.Context, movement *models.LineMovement) error {
+	streamKey := fmt.Sprintf("odds.movements.%s", movement.SportKey)
+
+	data, err := json.Marshal(movement)
+	if err != nil {
+		return fmt.Errorf("error marshaling movement: %w", err)
+	}
+
+	if err := p.redis.XAdd(ctx, &redis.XAddArgs{
+		Stream: streamKey,
+		Values: map[string]interface{}{
+			"type": string(movement.Type),
+			"data": string(data),
+		},
+	}).Err(); err != nil {
+		return fmt.Errorf("error publishing to stream %s: %w", streamKey, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,536 @@
+package movement
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/marketstate"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/shared/odds"
+)
+
+// Config controls line movement and steam detection
+type Config struct {
+	Window        time.Duration // Price history kept per sharp book+outcome+point
+	MinCents      int           // Single sharp book move that publishes a line_move
+	SteamMinBooks int           // Sharp books moving the same way that publish a steam
+	SteamMinCents int           // Minimum move for a book to count toward steam
+	MaxSamples    int           // Upper bound on samples kept per book+outcome
+}
+
+// DefaultConfig returns detection defaults
+func DefaultConfig() Config {
+	return Config{
+		Window:        5 * time.Minute,
+		MinCents:      10,
+		SteamMinBooks: 2,
+		SteamMinCents: 5,
+		MaxSamples:    32,
+	}
+}
+
+// Stats is a point-in-time snapshot of tracker metrics
+type Stats struct {
+	Histories int   `json:"histories"` // Sharp book+outcome+point histories held
+	LineMoves int64 `json:"line_moves"`
+	Steams    int64 `json:"steams"`
+	Expired   int64 `json:"expired"` // Histories removed by Sweep
+}
+
+// Publisher publishes movement events
+type Publisher interface {
+	PublishMovement(ctx context.Context, movement *models.LineMovement) error
+}
+
+// sample is one observed sharp price
+type sample struct {
+	price int
+	at    time.Time
+}
+
+// history is a sharp book's recent prices for one outcome at one point
+type history struct {
+	lineKey string
+	bookKey string
+	point   *float64
+	samples []sample // oldest first
+
+	// Line move already published from the current first sample
+	reported     int
+	reportedFrom time.Time
+}
+
+// line is a sharp book's current point for an outcome in a main market
+type line struct {
+	point *float64
+	price int
+	at    time.Time
+	moved *pointMove // Latest change of point, nil once published as steam
+}
+
+// pointMove is a sharp book moving its main line (e.g. -3 → -3.5)
+type pointMove struct {
+	fromPoint, toPoint *float64
+	fromPrice, toPrice int
+	fromTime, toTime   time.Time
+	direction          int // 1 = shortened, -1 = lengthened
+}
+
+// Tracker keeps a short price history per sharp book, outcome and point
+// Histories are keyed on vendor time so replays produce the same events as live.
+// Price moves are measured in cents at the same point; a book moving its main
+// line to another point (e.g. -3 → -3.5) is a move of its own.
+type Tracker struct {
+	config    Config
+	publisher Publisher
+
+	mu        sync.Mutex
+	histories map[string]*history            // outcome+point+book key -> history
+	books     map[string]map[string]struct{} // outcome+point key -> book keys with history
+	lines     map[string]map[string]*line    // outcome key -> book key -> main line
+	stats     Stats
+}
+
+// NewTracker creates a line movement tracker
+// publisher may be nil when only Observe is used
+func NewTracker(config Config, publisher Publisher) *Tracker {
+	return &Tracker{
+		config:    config,
+		publisher: publisher,
+		histories: make(map[string]*history),
+		books:     make(map[string]map[string]struct{}),
+		lines:     make(map[string]map[string]*line),
+	}
+}
+
+// Track observes a quote and publishes any resulting movement events
+func (t *Tracker) Track(ctx context.Context, raw models.RawOdds, marketOdds []models.RawOdds, isSharp func(bookKey string) bool) error {
+	for _, movement := range t.Observe(raw, marketOdds, isSharp) {
+		if t.publisher == nil {
+			continue
+		}
+		if err := t.publisher.PublishMovement(ctx, movement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Observe records a sharp quote and returns movement events it triggers
+// marketOdds is the latest quote per book for the event+market (before this quote),
+// used to list soft books that have not moved yet. Non-sharp quotes are ignored.
+func (t *Tracker) Observe(raw models.RawOdds, marketOdds []models.RawOdds, isSharp func(bookKey string) bool) []*models.LineMovement {
+	if !isSharp(raw.BookKey) {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	lineKey := LineKey(raw)
+	h := t.record(lineKey, raw)
+
+	if moved := t.recordLine(OutcomeKey(raw), raw); moved != nil {
+		return []*models.LineMovement{t.pointMovement(raw, moved, marketOdds, isSharp)}
+	}
+
+	move, ok := t.move(h)
+	if !ok {
+		return nil
+	}
+
+	// Steam: enough sharp books moved the same way at the same point within the window
+	steam := []*history{h}
+	for bookKey := range t.books[lineKey] {
+		if bookKey == raw.BookKey {
+			continue
+		}
+		other := t.histories[lineKey+"|"+bookKey]
+		if otherMove, ok := t.move(other); ok && sameDirection(otherMove, move) && abs(otherMove) >= t.config.SteamMinCents {
+			steam = append(steam, other)
+		}
+	}
+
+	var movement *models.LineMovement
+	switch {
+	case t.config.SteamMinBooks > 0 && len(steam) >= t.config.SteamMinBooks && abs(move) >= t.config.SteamMinCents:
+		movement = t.newMovement(models.MovementTypeSteam, raw, move, steam, marketOdds, isSharp)
+		t.stats.Steams++
+		// Start the steamed books over from their current price so the same steam isn't re-published
+		for _, moved := range steam {
+			moved.samples = moved.samples[len(moved.samples)-1:]
+			moved.reported = 0
+		}
+
+	case t.config.MinCents > 0 && abs(move) >= t.config.MinCents && t.unreported(h, move):
+		movement = t.newMovement(models.MovementTypeLineMove, raw, move, steam[:1], marketOdds, isSharp)
+		t.stats.LineMoves++
+
+		// Keep the history for steam, but only re-publish after another MinCents
+		h.reported = move
+		h.reportedFrom = h.samples[0].at
+
+	default:
+		return nil
+	}
+
+	return []*models.LineMovement{movement}
+}
+
+// record appends the quote to its book's history at the quote's point (caller holds mu)
+func (t *Tracker) record(lineKey string, raw models.RawOdds) *history {
+	key := lineKey + "|" + raw.BookKey
+	h, ok := t.histories[key]
+	if !ok {
+		h = &history{lineKey: lineKey, bookKey: raw.BookKey, point: copyPoint(raw.Point)}
+		t.histories[key] = h
+		if t.books[lineKey] == nil {
+			t.books[lineKey] = make(map[string]struct{})
+		}
+		t.books[lineKey][raw.BookKey] = struct{}{}
+	}
+
+	if n := len(h.samples); n == 0 || h.samples[n-1].price != raw.Price {
+		h.samples = append(h.samples, sample{price: raw.Price, at: raw.VendorLastUpdate})
+	}
+
+	// Drop samples outside the window, always keeping the latest
+	cutoff := raw.VendorLastUpdate.Add(-t.config.Window)
+	drop := 0
+	for drop < len(h.samples)-1 && h.samples[drop].at.Before(cutoff) {
+		drop++
+	}
+	if t.config.MaxSamples > 0 && len(h.samples)-drop > t.config.MaxSamples {
+		drop = len(h.samples) - t.config.MaxSamples
+	}
+	h.samples = h.samples[drop:]
+
+	return h
+}
+
+// recordLine updates the book's main line, returning the move when its point changed
+// Alternate lines and props are quoted at several points side by side, so only main
+// markets with a point have a line to move (caller holds mu)
+func (t *Tracker) recordLine(outcomeKey string, raw models.RawOdds) *pointMove {
+	if raw.Point == nil || marketstate.HasLines(raw) {
+		return nil
+	}
+
+	books := t.lines[outcomeKey]
+	if books == nil {
+		books = make(map[string]*line)
+		t.lines[outcomeKey] = books
+	}
+
+	current, ok := books[raw.BookKey]
+	if !ok {
+		books[raw.BookKey] = &line{point: copyPoint(raw.Point), price: raw.Price, at: raw.VendorLastUpdate}
+		return nil
+	}
+
+	var moved *pointMove
+	if !samePoint(current.point, raw.Point) {
+		moved = &pointMove{
+			fromPoint: current.point,
+			toPoint:   copyPoint(raw.Point),
+			fromPrice: current.price,
+			toPrice:   raw.Price,
+			fromTime:  current.at,
+			toTime:    raw.VendorLastUpdate,
+			direction: pointDirection(raw.OutcomeName, *current.point, *raw.Point),
+		}
+		current.point = copyPoint(raw.Point)
+		current.moved = moved
+	}
+	current.price = raw.Price
+	current.at = raw.VendorLastUpdate
+	return moved
+}
+
+// pointMovement builds the event for a book that moved its main line, as steam when
+// enough sharp books moved theirs the same way within the window (caller holds mu)
+func (t *Tracker) pointMovement(
+	raw models.RawOdds,
+	moved *pointMove,
+	marketOdds []models.RawOdds,
+	isSharp func(bookKey string) bool,
+) *models.LineMovement {
+	books := t.lines[OutcomeKey(raw)]
+	cutoff := raw.VendorLastUpdate.Add(-t.config.Window)
+
+	steam := map[string]*pointMove{raw.BookKey: moved}
+	for bookKey, other := range books {
+		if bookKey == raw.BookKey || other.moved == nil {
+			continue
+		}
+		if other.moved.direction == moved.direction && !other.moved.toTime.Before(cutoff) {
+			steam[bookKey] = other.moved
+		}
+	}
+
+	movementType := models.MovementTypeLineMove
+	if t.config.SteamMinBooks > 0 && len(steam) >= t.config.SteamMinBooks {
+		movementType = models.MovementTypeSteam
+		t.stats.Steams++
+		// Clear the steamed moves so the same steam isn't re-published
+		for bookKey := range steam {
+			books[bookKey].moved = nil
+		}
+	} else {
+		t.stats.LineMoves++
+	}
+
+	direction := models.MovementShorten
+	if moved.direction < 0 {
+		direction = models.MovementLengthen
+	}
+
+	movement := &models.LineMovement{
+		Type:          movementType,
+		Direction:     direction,
+		EventID:       raw.EventID,
+		SportKey:      raw.SportKey,
+		MarketKey:     raw.MarketKey,
+		OutcomeName:   raw.OutcomeName,
+		Description:   raw.Description,
+		Point:         copyPoint(raw.Point),
+		Moves:         make([]models.BookMove, 0, len(steam)),
+		WindowSeconds: int(t.config.Window.Seconds()),
+		DetectedAt:    raw.VendorLastUpdate,
+	}
+
+	moveStart := raw.VendorLastUpdate
+	for bookKey, move := range steam {
+		movement.Moves = append(movement.Moves, models.BookMove{
+			BookKey:   bookKey,
+			FromPrice: move.fromPrice,
+			ToPrice:   move.toPrice,
+			FromPoint: copyPoint(move.fromPoint),
+			ToPoint:   copyPoint(move.toPoint),
+			FromTime:  move.fromTime,
+			ToTime:    move.toTime,
+		})
+		if move.toTime.Before(moveStart) {
+			moveStart = move.toTime
+		}
+	}
+	sort.Slice(movement.Moves, func(i, j int) bool {
+		return movement.Moves[i].BookKey < movement.Moves[j].BookKey
+	})
+
+	// Soft books still on the old line
+	movement.StaleBooks = staleBooks(raw, moved.fromPoint, moveStart, marketOdds, isSharp)
+
+	return movement
+}
+
+// unreported checks that a line move hasn't already been published (caller holds mu)
+func (t *Tracker) unreported(h *history, move int) bool {
+	if h.reported == 0 || !h.reportedFrom.Equal(h.samples[0].at) || !sameDirection(h.reported, move) {
+		return true
+	}
+	return abs(move)-abs(h.reported) >= t.config.MinCents
+}
+
+// move returns the signed cents moved across the history (positive = shortened)
+func (t *Tracker) move(h *history) (int, bool) {
+	if h == nil || len(h.samples) < 2 {
+		return 0, false
+	}
+	cents := odds.PriceMoveCents(h.samples[0].price, h.samples[len(h.samples)-1].price)
+	return cents, cents != 0
+}
+
+// newMovement builds the event for the moved books (caller holds mu)
+func (t *Tracker) newMovement(
+	movementType models.MovementType,
+	raw models.RawOdds,
+	move int,
+	moved []*history,
+	marketOdds []models.RawOdds,
+	isSharp func(bookKey string) bool,
+) *models.LineMovement {
+	direction := models.MovementShorten
+	if move < 0 {
+		direction = models.MovementLengthen
+	}
+
+	movement := &models.LineMovement{
+		Type:          movementType,
+		Direction:     direction,
+		EventID:       raw.EventID,
+		SportKey:      raw.SportKey,
+		MarketKey:     raw.MarketKey,
+		OutcomeName:   raw.OutcomeName,
+		Description:   raw.Description,
+		Point:         copyPoint(raw.Point),
+		Moves:         make([]models.BookMove, 0, len(moved)),
+		WindowSeconds: int(t.config.Window.Seconds()),
+		DetectedAt:    raw.VendorLastUpdate,
+	}
+
+	moveStart := raw.VendorLastUpdate
+	for _, h := range moved {
+		from, to := h.samples[0], h.samples[len(h.samples)-1]
+		movement.Moves = append(movement.Moves, models.BookMove{
+			BookKey:   h.bookKey,
+			FromPrice: from.price,
+			ToPrice:   to.price,
+			Cents:     abs(odds.PriceMoveCents(from.price, to.price)),
+			FromTime:  from.at,
+			ToTime:    to.at,
+		})
+		if from.at.Before(moveStart) {
+			moveStart = from.at
+		}
+	}
+	sort.Slice(movement.Moves, func(i, j int) bool {
+		return movement.Moves[i].BookKey < movement.Moves[j].BookKey
+	})
+
+	// Soft books quoting the same outcome that haven't updated since the move began
+	movement.StaleBooks = staleBooks(raw, raw.Point, moveStart, marketOdds, isSharp)
+
+	return movement
+}
+
+// staleBooks lists soft books quoting raw's outcome at point that haven't updated since since
+func staleBooks(raw models.RawOdds, point *float64, since time.Time, marketOdds []models.RawOdds, isSharp func(bookKey string) bool) []models.BookQuote {
+	stale := []models.BookQuote{}
+	for _, odds := range marketOdds {
+		if isSharp(odds.BookKey) || odds.OutcomeName != raw.OutcomeName ||
+			odds.Description != raw.Description || !samePoint(odds.Point, point) {
+			continue
+		}
+		if odds.VendorLastUpdate.After(since) {
+			continue
+		}
+		stale = append(stale, models.BookQuote{
+			BookKey:    odds.BookKey,
+			Price:      odds.Price,
+			LastUpdate: odds.VendorLastUpdate,
+		})
+	}
+	sort.Slice(stale, func(i, j int) bool {
+		return stale[i].BookKey < stale[j].BookKey
+	})
+	return stale
+}
+
+// Sweep removes histories whose latest sample is older than the window
+// Returns the number of histories removed
+func (t *Tracker) Sweep(now time.Time) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cutoff := now.Add(-t.config.Window)
+	removed := 0
+	for key, h := range t.histories {
+		if h.samples[len(h.samples)-1].at.After(cutoff) {
+			continue
+		}
+		delete(t.histories, key)
+		delete(t.books[h.lineKey], h.bookKey)
+		if len(t.books[h.lineKey]) == 0 {
+			delete(t.books, h.lineKey)
+		}
+		removed++
+	}
+
+	// Main lines are kept as long as their histories
+	for outcomeKey, books := range t.lines {
+		for bookKey, current := range books {
+			if !current.at.After(cutoff) {
+				delete(books, bookKey)
+			}
+		}
+		if len(books) == 0 {
+			delete(t.lines, outcomeKey)
+		}
+	}
+
+	t.stats.Expired += int64(removed)
+	return removed
+}
+
+// Run sweeps idle histories every window until ctx is cancelled
+func (t *Tracker) Run(ctx context.Context) {
+	interval := t.config.Window
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			t.Sweep(now)
+		}
+	}
+}
+
+// Stats returns a snapshot of tracker metrics
+func (t *Tracker) Stats() Stats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := t.stats
+	stats.Histories = len(t.histories)
+	return stats
+}
+
+// OutcomeKey identifies an outcome across books (event, market, player, outcome)
+func OutcomeKey(odds models.RawOdds) string {
+	return fmt.Sprintf("%s:%s:%s:%s", odds.EventID, odds.MarketKey, odds.Description, odds.OutcomeName)
+}
+
+// LineKey identifies an outcome at one point across books
+func LineKey(odds models.RawOdds) string {
+	if odds.Point == nil {
+		return OutcomeKey(odds)
+	}
+	return fmt.Sprintf("%s:%g", OutcomeKey(odds), *odds.Point)
+}
+
+// pointDirection is 1 when moving the line from one point to the other makes the
+// outcome more likely at its old point (a spread laid further, an Over total raised)
+func pointDirection(outcomeName string, from, to float64) int {
+	if strings.EqualFold(outcomeName, "Over") == (to > from) {
+		return 1
+	}
+	return -1
+}
+
+func samePoint(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func copyPoint(point *float64) *float64 {
+	if point == nil {
+		return nil
+	}
+	value := *point
+	return &value
+}
+
+func sameDirection(a, b int) bool {
+	return (a > 0) == (b > 0)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -7,36 +7,82 @@ import (
 	"time"
 
 	"github.com/XavierBriggs/fortuna/services/normalizer/internal/consumer"
-	"github.com/XavierBriggs/fortuna/services/normalizer/internal/publisher"
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/crossmarket"
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/deadletter"
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/hold"
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/marketstate"
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/metrics"
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/movement"
 	"github.com/XavierBriggs/fortuna/services/normalizer/internal/registry"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/contracts"
 	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/oddsmath"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/pricing"
 )
 
+// Publisher delivers normalized odds (Redis stream for live, stream or file for replay)
+type Publisher interface {
+	Publish(ctx context.Context, normalized *models.NormalizedOdds) error
+}
+
 // Processor orchestrates normalization of raw odds
 type Processor struct {
 	consumer  *consumer.StreamConsumer
-	publisher *publisher.StreamPublisher
+	publisher Publisher
 	registry  *registry.NormalizerRegistry
 	
-	// Market state cache for grouping odds by event+market
-	marketCache sync.Map // key: "eventID:marketKey" -> []models.RawOdds
-	
+	// Market state for grouping odds by event+market
+	marketStore *marketstate.Store
+
+	// Failed messages are written here before they are acked (nil = drop)
+	deadLetters *deadletter.Queue
+
+	// Sharp line movement and steam detection (nil = disabled)
+	movements *movement.Tracker
+
+	// Per-book hold by sport and market type (nil = disabled)
+	holds *hold.Tracker
+
+	// Moneyline vs spread consistency per book (nil = disabled)
+	crossMarket *crossmarket.Analyzer
+
+	// Prometheus counters and latency histograms (nil = disabled)
+	metrics *metrics.Recorder
+
 	// Metrics
-	processedCount int64
-	errorCount     int64
-	mu             sync.Mutex
+	processedCount  int64
+	errorCount      int64
+	deadLetterCount int64
+	mu              sync.Mutex
+}
+
+// Options are a processor's optional collaborators; the zero value disables them all
+type Options struct {
+	DeadLetters *deadletter.Queue     // Failed messages are written here before they are acked (nil = drop)
+	Movements   *movement.Tracker     // Sharp line movement and steam detection
+	Holds       *hold.Tracker         // Per-book hold by sport and market type
+	CrossMarket *crossmarket.Analyzer // Moneyline vs spread consistency per book
+	Metrics     *metrics.Recorder     // Prometheus counters and latency histograms
 }
 
 // NewProcessor creates a new processor
 func NewProcessor(
 	consumer *consumer.StreamConsumer,
-	publisher *publisher.StreamPublisher,
+	publisher Publisher,
 	registry *registry.NormalizerRegistry,
+	marketStore *marketstate.Store,
+	options Options,
 ) *Processor {
 	return &Processor{
-		consumer:  consumer,
-		publisher: publisher,
-		registry:  registry,
+		consumer:    consumer,
+		publisher:   publisher,
+		registry:    registry,
+		marketStore: marketStore,
+		deadLetters: options.DeadLetters,
+		movements:   options.Movements,
+		holds:       options.Holds,
+		crossMarket: options.CrossMarket,
+		metrics:     options.Metrics,
 	}
 }
 
@@ -48,6 +94,24 @@ func (p *Processor) Start(ctx context.Context) error {
 		return fmt.Errorf("no sport normalizers registered")
 	}
 
+	// Evict markets nobody has updated within the TTL
+	go p.marketStore.Run(ctx)
+
+	// Drop price histories for outcomes sharp books stopped quoting
+	if p.movements != nil {
+		go p.movements.Run(ctx)
+	}
+
+	// Publish rolling per-book hold summaries
+	if p.holds != nil {
+		go p.holds.Run(ctx)
+	}
+
+	// Forget reported cross-market inconsistencies
+	if p.crossMarket != nil {
+		go p.crossMarket.Run(ctx)
+	}
+
 	var wg sync.WaitGroup
 
 	for _, norm := range normalizers {
@@ -88,6 +152,13 @@ func (p *Processor) processStream(ctx context.Context, streamKey string) {
 			if err := p.processMessage(ctx, msg); err != nil {
 				fmt.Printf("error processing message %s: %v\n", msg.ID, err)
 				p.incrementErrorCount()
+				p.metrics.Error(msg.StreamKey, err)
+
+				// Keep the message pending if it can't be dead-lettered, so it isn't lost
+				if dlErr := p.deadLetter(ctx, msg, err); dlErr != nil {
+					fmt.Printf("❌ %v\n", dlErr)
+					continue
+				}
 			} else {
 				p.incrementProcessedCount()
 			}
@@ -106,86 +177,126 @@ func (p *Processor) processMessage(ctx context.Context, msg consumer.Message) er
 	fmt.Printf("PROCESSOR DEBUG %s: sport_key='%s' (len=%d) event_id='%s' book='%s'\n",
 		msg.ID, msg.RawOdds.SportKey, len(msg.RawOdds.SportKey), msg.RawOdds.EventID, msg.RawOdds.BookKey)
 	
+	if msg.DecodeErr != nil {
+		return deadletter.NewError(deadletter.ClassDecode, msg.DecodeErr)
+	}
+
+	normalized, err := p.processRawOdds(ctx, msg.RawOdds)
+	if err != nil {
+		return err
+	}
+
+	p.metrics.Processed(msg.StreamKey, normalized.ReceivedAt, normalized.NormalizedAt)
+	return nil
+}
+
+// deadLetter records a failed message on odds.deadletter.{sport} with its error class
+// A nil dead-letter queue keeps the old behavior (log and ack)
+func (p *Processor) deadLetter(ctx context.Context, msg consumer.Message, processErr error) error {
+	if p.deadLetters == nil {
+		return nil
+	}
+
+	entry := deadletter.Entry{
+		SportKey:     deadletter.SportFromStream(msg.StreamKey),
+		SourceStream: msg.StreamKey,
+		SourceID:     msg.ID,
+		ErrorClass:   deadletter.Classify(processErr),
+		ErrorMessage: processErr.Error(),
+		Attempts:     msg.Attempts + 1,
+		Payload:      msg.Data,
+		FailedAt:     time.Now(),
+	}
+
+	if err := p.deadLetters.Write(ctx, entry); err != nil {
+		return fmt.Errorf("failed to dead-letter message %s: %w", msg.ID, err)
+	}
+
+	p.mu.Lock()
+	p.deadLetterCount++
+	p.mu.Unlock()
+	p.metrics.DeadLettered(msg.StreamKey)
+	return nil
+}
+
+// ProcessRawOdds normalizes a single raw quote against the market state and publishes it
+// Used directly by replay, which feeds historical rows without a stream consumer
+func (p *Processor) ProcessRawOdds(ctx context.Context, raw models.RawOdds) error {
+	_, err := p.processRawOdds(ctx, raw)
+	return err
+}
+
+// processRawOdds normalizes and publishes a raw quote, returning what was published
+func (p *Processor) processRawOdds(ctx context.Context, raw models.RawOdds) (*models.NormalizedOdds, error) {
 	// Get the appropriate normalizer for this sport
-	normalizer, exists := p.registry.Get(msg.RawOdds.SportKey)
+	normalizer, exists := p.registry.Get(raw.SportKey)
 	if !exists {
-		return fmt.Errorf("no normalizer registered for sport: %s", msg.RawOdds.SportKey)
+		return nil, deadletter.NewError(deadletter.ClassNoNormalizer, fmt.Errorf("no normalizer registered for sport: %s", raw.SportKey))
+	}
+
+	// Non-US feeds quote PriceValue in PriceFormat; fill the American Price from it
+	if err := oddsmath.ResolvePrice(&raw); err != nil {
+		return nil, deadletter.NewError(deadletter.ClassNormalization, fmt.Errorf("price error: %w", err))
 	}
 
 	// Get market context (all odds for this event+market)
-	marketOdds := p.getMarketOdds(msg.RawOdds)
+	marketOdds := p.getMarketOdds(raw)
 
 	// Normalize the odds
-	normalized, err := normalizer.Normalize(ctx, msg.RawOdds, marketOdds)
+	normalized, err := normalizer.Normalize(ctx, raw, marketOdds)
 	if err != nil {
-		return fmt.Errorf("normalization error: %w", err)
+		return nil, deadletter.NewError(deadletter.ClassNormalization, fmt.Errorf("normalization error: %w", err))
 	}
 
 	// Update market cache with this odds
-	p.updateMarketCache(msg.RawOdds)
+	p.updateMarketCache(raw)
+
+	// Record this book's hold once it prices the whole market
+	if p.holds != nil {
+		p.holds.Observe(raw, models.MarketType(normalized.MarketType), marketOdds,
+			outcomeCount(normalizer, raw.MarketKey), normalizer.IsSharpBook(raw.BookKey))
+	}
 
 	// Publish normalized odds
 	if err := p.publisher.Publish(ctx, normalized); err != nil {
-		return fmt.Errorf("publish error: %w", err)
+		return nil, deadletter.NewError(deadletter.ClassPublish, fmt.Errorf("publish error: %w", err))
 	}
 
-	return nil
-}
-
-// getMarketOdds retrieves all odds for the same event+market from cache
-func (p *Processor) getMarketOdds(odds models.RawOdds) []models.RawOdds {
-	cacheKey := p.buildMarketKey(odds)
-	
-	if value, ok := p.marketCache.Load(cacheKey); ok {
-		if cached, ok := value.([]models.RawOdds); ok {
-			return cached
+	// Movement events are best effort; the normalized odds are already published
+	if p.movements != nil {
+		if err := p.movements.Track(ctx, raw, marketOdds, normalizer.IsSharpBook); err != nil {
+			fmt.Printf("⚠️  Failed to publish movement for %s: %v\n", raw.EventID, err)
 		}
 	}
-	
-	return []models.RawOdds{}
-}
 
-// updateMarketCache adds or updates odds in the market cache
-func (p *Processor) updateMarketCache(odds models.RawOdds) {
-	cacheKey := p.buildMarketKey(odds)
-	
-	// Load existing odds
-	var marketOdds []models.RawOdds
-	if value, ok := p.marketCache.Load(cacheKey); ok {
-		if cached, ok := value.([]models.RawOdds); ok {
-			marketOdds = cached
-		}
-	}
-	
-	// Update or append this odds
-	found := false
-	for i, existingOdds := range marketOdds {
-		if existingOdds.BookKey == odds.BookKey && existingOdds.OutcomeName == odds.OutcomeName {
-			// Update existing
-			marketOdds[i] = odds
-			found = true
-			break
+	// Cross-market signals are best effort too; only sports with a margin model are checked
+	if p.crossMarket != nil && p.crossMarket.Handles(raw.MarketKey) {
+		if model, ok := normalizer.(crossmarket.MarginModel); ok {
+			if err := p.crossMarket.Check(ctx, raw, p.marketStore.Get, model.GetMarginDistribution(), normalizer.IsSharpBook(raw.BookKey)); err != nil {
+				fmt.Printf("⚠️  Failed to publish cross-market signal for %s: %v\n", raw.EventID, err)
+			}
 		}
 	}
-	
-	if !found {
-		// Append new
-		marketOdds = append(marketOdds, odds)
+
+	return normalized, nil
+}
+
+// outcomeCount returns how many outcomes a complete market has for sports that know (0 = any)
+func outcomeCount(normalizer contracts.SportNormalizer, marketKey string) int {
+	if counter, ok := normalizer.(pricing.OutcomeCounter); ok {
+		return counter.GetOutcomeCount(marketKey)
 	}
-	
-	// Store back in cache
-	p.marketCache.Store(cacheKey, marketOdds)
-	
-	// Schedule cache cleanup (remove stale entries after 5 minutes)
-	go func(key string) {
-		time.Sleep(5 * time.Minute)
-		p.marketCache.Delete(key)
-	}(cacheKey)
+	return 0
+}
+
+// getMarketOdds retrieves all odds for the same event+market from the market store
+func (p *Processor) getMarketOdds(odds models.RawOdds) []models.RawOdds {
+	return p.marketStore.Get(odds)
 }
 
-// buildMarketKey creates a cache key for event+market
-func (p *Processor) buildMarketKey(odds models.RawOdds) string {
-	return fmt.Sprintf("%s:%s", odds.EventID, odds.MarketKey)
+// updateMarketCache adds or updates odds in the market store
+func (p *Processor) updateMarketCache(odds models.RawOdds) {
+	p.marketStore.Upsert(odds)
 }
 
 // incrementProcessedCount increments the processed message counter
@@ -209,3 +320,51 @@ func (p *Processor) GetMetrics() (processed, errors int64) {
 	return p.processedCount, p.errorCount
 }
 
+// GetPendingStats returns backlog and recovery counts for every registered sport's stream
+func (p *Processor) GetPendingStats(ctx context.Context) ([]consumer.PendingStats, error) {
+	var stats []consumer.PendingStats
+	for _, norm := range p.registry.GetAll() {
+		streamStats, err := p.consumer.PendingStats(ctx, fmt.Sprintf("odds.raw.%s", norm.GetSportKey()))
+		if err != nil {
+			return stats, err
+		}
+		stats = append(stats, streamStats)
+	}
+	return stats, nil
+}
+
+// GetMovementStats returns line movement tracker metrics (zero when disabled)
+func (p *Processor) GetMovementStats() movement.Stats {
+	if p.movements == nil {
+		return movement.Stats{}
+	}
+	return p.movements.Stats()
+}
+
+// GetHoldStats returns book hold tracker metrics (zero when disabled)
+func (p *Processor) GetHoldStats() hold.Stats {
+	if p.holds == nil {
+		return hold.Stats{}
+	}
+	return p.holds.Stats()
+}
+
+// GetCrossMarketStats returns cross-market analyzer metrics (zero when disabled)
+func (p *Processor) GetCrossMarketStats() crossmarket.Stats {
+	if p.crossMarket == nil {
+		return crossmarket.Stats{}
+	}
+	return p.crossMarket.Stats()
+}
+
+// GetDeadLetterCount returns how many messages were dead-lettered
+func (p *Processor) GetDeadLetterCount() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.deadLetterCount
+}
+
+// GetMarketStoreStats returns market state store metrics
+func (p *Processor) GetMarketStoreStats() marketstate.Stats {
+	return p.marketStore.Stats()
+}
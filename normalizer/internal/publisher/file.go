@@ -0,0 +1,52 @@
+package publisher
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+)
+
+// FilePublisher writes normalized odds as JSON lines (one message per line)
+// Used by replay to produce before/after files that can be diffed offline
+type FilePublisher struct {
+	mu     sync.Mutex
+	writer *bufio.Writer
+}
+
+// NewFilePublisher creates a publisher that writes to w
+// Call Flush before closing the underlying writer
+func NewFilePublisher(w io.Writer) *FilePublisher {
+	return &FilePublisher{
+		writer: bufio.NewWriter(w),
+	}
+}
+
+// Publish writes one normalized odds message as a JSON line
+func (p *FilePublisher) Publish(ctx context.Context, normalized *models.NormalizedOdds) error {
+	data, err := json.Marshal(normalized)
+	if err != nil {
+		return fmt.Errorf("error marshaling normalized odds: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, err := p.writer.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("error writing normalized odds: %w", err)
+	}
+
+	return nil
+}
+
+// Flush writes any buffered messages
+func (p *FilePublisher) Flush() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.writer.Flush()
+}
@@ -11,13 +11,21 @@ import (
 
 // StreamPublisher publishes normalized odds to Redis Streams
 type StreamPublisher struct {
-	redis *redis.Client
+	redis        *redis.Client
+	streamPrefix string
 }
 
 // NewStreamPublisher creates a new stream publisher
 func NewStreamPublisher(redisClient *redis.Client) *StreamPublisher {
+	return NewStreamPublisherWithPrefix(redisClient, "odds.normalized")
+}
+
+// NewStreamPublisherWithPrefix creates a stream publisher writing to {prefix}.{sport_key}
+// Replay uses "odds.normalized.replay" so it never touches the live streams
+func NewStreamPublisherWithPrefix(redisClient *redis.Client, streamPrefix string) *StreamPublisher {
 	return &StreamPublisher{
-		redis: redisClient,
+		redis:        redisClient,
+		streamPrefix: streamPrefix,
 	}
 }
 
@@ -25,7 +33,7 @@ func NewStreamPublisher(redisClient *redis.Client) *StreamPublisher {
 // Stream key format: odds.normalized.{sport_key}
 func (p *StreamPublisher) Publish(ctx context.Context, normalized *models.NormalizedOdds) error {
 	// Build stream key from sport
-	streamKey := fmt.Sprintf("odds.normalized.%s", normalized.SportKey)
+	streamKey := fmt.Sprintf("%s.%s", p.streamPrefix, normalized.SportKey)
 
 	// Marshal to JSON
 	data, err := json.Marshal(normalized)
@@ -64,7 +72,7 @@ func (p *StreamPublisher) PublishBatch(ctx context.Context, normalized []*models
 	pipe := p.redis.Pipeline()
 
 	for sportKey, oddsGroup := range bySport {
-		streamKey := fmt.Sprintf("odds.normalized.%s", sportKey)
+		streamKey := fmt.Sprintf("%s.%s", p.streamPrefix, sportKey)
 
 		for _, odds := range oddsGroup {
 			data, err := json.Marshal(odds)
@@ -2,9 +2,12 @@ package registry
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"sync"
 
 	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/contracts"
+	"github.com/XavierBriggs/fortuna/services/normalizer/sports/generic"
 )
 
 // NormalizerRegistry manages registered sport normalizers
@@ -63,7 +66,37 @@ func (r *NormalizerRegistry) Count() int {
 	return len(r.normalizers)
 }
 
+// LoadDirectory registers a generic normalizer for every sport config in dir
+// (*.json, *.yaml or *.yml; other files are ignored). Files are loaded in name order.
+// Any invalid file or duplicate sport key aborts the load so a bad config fails at
+// startup rather than silently skipping a sport.
+// Returns the sport keys that were registered.
+func (r *NormalizerRegistry) LoadDirectory(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sport configs in %s: %w", dir, err)
+	}
 
+	// ReadDir returns entries sorted by name
+	paths := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() && generic.IsConfigFile(entry.Name()) {
+			paths = append(paths, filepath.Join(dir, entry.Name()))
+		}
+	}
 
+	loaded := make([]string, 0, len(paths))
+	for _, path := range paths {
+		config, err := generic.LoadConfig(path)
+		if err != nil {
+			return loaded, err
+		}
+
+		if err := r.Register(generic.NewNormalizer(config)); err != nil {
+			return loaded, fmt.Errorf("%s: %w", path, err)
+		}
+		loaded = append(loaded, config.SportKey)
+	}
 
-
+	return loaded, nil
+}
@@ -0,0 +1,165 @@
+package replay
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+)
+
+// Filter selects historical odds to replay (an event, a time range, or both)
+type Filter struct {
+	EventID  string
+	SportKey string
+	Since    *time.Time // vendor_last_update >= Since
+	Until    *time.Time // vendor_last_update <= Until
+}
+
+// Validate requires an event or a time range so a replay never scans all history
+func (f Filter) Validate() error {
+	if f.EventID == "" && f.Since == nil && f.Until == nil {
+		return fmt.Errorf("replay needs an event ID or a time range")
+	}
+	if f.Since != nil && f.Until != nil && f.Until.Before(*f.Since) {
+		return fmt.Errorf("until must not be before since")
+	}
+	return nil
+}
+
+// Source streams historical raw odds in vendor timestamp order
+type Source interface {
+	Stream(ctx context.Context, filter Filter, fn func(models.RawOdds) error) error
+}
+
+// AlexandriaSource reads odds_raw rows from Alexandria
+type AlexandriaSource struct {
+	db *sql.DB
+}
+
+// NewAlexandriaSource creates a replay source backed by Alexandria
+func NewAlexandriaSource(db *sql.DB) *AlexandriaSource {
+	return &AlexandriaSource{db: db}
+}
+
+// Stream calls fn for each matching row, ordered by vendor_last_update
+// Ties are broken by received_at then id so replays are deterministic.
+// odds_raw has no description or price format columns, so those are left empty.
+func (s *AlexandriaSource) Stream(ctx context.Context, filter Filter, fn func(models.RawOdds) error) error {
+	if err := filter.Validate(); err != nil {
+		return err
+	}
+
+	query := `
+		SELECT event_id, sport_key, market_key, book_key, outcome_name,
+		       price, point, vendor_last_update, received_at
+		FROM odds_raw
+		WHERE 1=1
+	`
+	args := []interface{}{}
+	argIdx := 1
+
+	if filter.EventID != "" {
+		query += fmt.Sprintf(" AND event_id = $%d", argIdx)
+		args = append(args, filter.EventID)
+		argIdx++
+	}
+
+	if filter.SportKey != "" {
+		query += fmt.Sprintf(" AND sport_key = $%d", argIdx)
+		args = append(args, filter.SportKey)
+		argIdx++
+	}
+
+	if filter.Since != nil {
+		query += fmt.Sprintf(" AND vendor_last_update >= $%d", argIdx)
+		args = append(args, *filter.Since)
+		argIdx++
+	}
+
+	if filter.Until != nil {
+		query += fmt.Sprintf(" AND vendor_last_update <= $%d", argIdx)
+		args = append(args, *filter.Until)
+		argIdx++
+	}
+
+	query += " ORDER BY vendor_last_update ASC, received_at ASC, id ASC"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("query odds_raw: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var odds models.RawOdds
+		var point sql.NullFloat64
+		if err := rows.Scan(
+			&odds.EventID, &odds.SportKey, &odds.MarketKey, &odds.BookKey, &odds.OutcomeName,
+			&odds.Price, &point, &odds.VendorLastUpdate, &odds.ReceivedAt,
+		); err != nil {
+			return fmt.Errorf("scan odds_raw: %w", err)
+		}
+		if point.Valid {
+			odds.Point = &point.Float64
+		}
+
+		if err := fn(odds); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate odds_raw: %w", err)
+	}
+
+	return nil
+}
+
+// SliceSource replays an in-memory set of odds (tests, files exported from Alexandria)
+type SliceSource struct {
+	odds []models.RawOdds
+}
+
+// NewSliceSource creates a source that sorts the given odds by vendor timestamp
+func NewSliceSource(odds []models.RawOdds) *SliceSource {
+	sorted := make([]models.RawOdds, len(odds))
+	copy(sorted, odds)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].VendorLastUpdate.Equal(sorted[j].VendorLastUpdate) {
+			return sorted[i].VendorLastUpdate.Before(sorted[j].VendorLastUpdate)
+		}
+		return sorted[i].ReceivedAt.Before(sorted[j].ReceivedAt)
+	})
+	return &SliceSource{odds: sorted}
+}
+
+// Stream calls fn for each odds matching the filter
+func (s *SliceSource) Stream(ctx context.Context, filter Filter, fn func(models.RawOdds) error) error {
+	if err := filter.Validate(); err != nil {
+		return err
+	}
+
+	for _, odds := range s.odds {
+		if filter.EventID != "" && odds.EventID != filter.EventID {
+			continue
+		}
+		if filter.SportKey != "" && odds.SportKey != filter.SportKey {
+			continue
+		}
+		if filter.Since != nil && odds.VendorLastUpdate.Before(*filter.Since) {
+			continue
+		}
+		if filter.Until != nil && odds.VendorLastUpdate.After(*filter.Until) {
+			continue
+		}
+
+		if err := fn(odds); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// CrossMarketSignalType classifies a cross-market inconsistency
+type CrossMarketSignalType string
+
+const (
+	CrossMarketWarning     CrossMarketSignalType = "warning"     // A book's moneyline and spread disagree
+	CrossMarketOpportunity CrossMarketSignalType = "opportunity" // The stale market is +EV against the fresher one
+)
+
+// CrossMarketSignal is published to odds.crossmarket.{sport_key} when a book's moneyline
+// implies a materially different spread than the book is dealing
+// Probabilities are no-vig and from Team's perspective (the moneyline favorite).
+type CrossMarketSignal struct {
+	Type     CrossMarketSignalType `json:"type"`
+	EventID  string                `json:"event_id"`
+	SportKey string                `json:"sport_key"`
+	BookKey  string                `json:"book_key"`
+	IsSharp  bool                  `json:"is_sharp"`
+	Team     string                `json:"team"`
+
+	MoneylinePrice int     `json:"moneyline_price"`
+	MoneylineProb  float64 `json:"moneyline_prob"` // Win probability with the draw removed
+	ImpliedSpread  float64 `json:"implied_spread"` // Spread the moneyline implies (e.g. -6.5)
+
+	SpreadPoint       float64 `json:"spread_point"`
+	SpreadPrice       int     `json:"spread_price"`
+	SpreadProb        float64 `json:"spread_prob"`         // Book's cover probability at SpreadPoint
+	ImpliedSpreadProb float64 `json:"implied_spread_prob"` // Cover probability at SpreadPoint implied by the moneyline
+	ProbDiff          float64 `json:"prob_diff"`           // ImpliedSpreadProb - SpreadProb
+
+	StaleMarket string          `json:"stale_market"`  // Market key updated least recently
+	Bet         *CrossMarketBet `json:"bet,omitempty"` // Set for opportunities
+
+	DetectedAt time.Time `json:"detected_at"` // Vendor time of the quote that triggered the check
+}
+
+// CrossMarketBet is the stale market's +EV side, priced against the fresher market
+type CrossMarketBet struct {
+	MarketKey   string   `json:"market_key"`
+	OutcomeName string   `json:"outcome_name"`
+	Point       *float64 `json:"point,omitempty"`
+	Price       int      `json:"price"`
+	FairProb    float64  `json:"fair_prob"`
+	EdgePct     float64  `json:"edge_pct"` // Expected value per unit staked, in percent
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// BookHoldSummary is a book's rolling hold across its complete markets for one sport and market type
+// Published to the books.hold.{sport_key} hash, field {market_type}:{book_key}
+type BookHoldSummary struct {
+	SportKey   string `json:"sport_key"`
+	MarketType string `json:"market_type"`
+	BookKey    string `json:"book_key"`
+	IsSharp    bool   `json:"is_sharp"`
+	Markets    int    `json:"markets"` // Complete event+market lines currently tracked
+
+	AvgHoldPct      float64 `json:"avg_hold_pct"` // Bettor loss per unit staked across all outcomes
+	MinHoldPct      float64 `json:"min_hold_pct"`
+	MaxHoldPct      float64 `json:"max_hold_pct"`
+	AvgOverroundPct float64 `json:"avg_overround_pct"` // Sum of implied probabilities above 100%
+
+	UpdatedAt time.Time `json:"updated_at"` // Vendor time of the latest quote included
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// MovementType classifies a sharp line movement event
+type MovementType string
+
+const (
+	MovementTypeLineMove MovementType = "line_move" // One sharp book moved past the cents threshold
+	MovementTypeSteam    MovementType = "steam"     // Several sharp books moved the same way
+)
+
+// MovementDirection is the direction a price moved
+type MovementDirection string
+
+const (
+	MovementShorten  MovementDirection = "shorten"  // Price got shorter (outcome more likely)
+	MovementLengthen MovementDirection = "lengthen" // Price got longer (outcome less likely)
+)
+
+// LineMovement is published to odds.movements.{sport_key} when sharp prices move
+type LineMovement struct {
+	Type        MovementType      `json:"type"`
+	Direction   MovementDirection `json:"direction"`
+	EventID     string            `json:"event_id"`
+	SportKey    string            `json:"sport_key"`
+	MarketKey   string            `json:"market_key"`
+	OutcomeName string            `json:"outcome_name"`
+	Description string            `json:"description,omitempty"` // Player name for props
+	Point       *float64          `json:"point,omitempty"`
+
+	Moves      []BookMove  `json:"moves"`       // Sharp books that moved
+	StaleBooks []BookQuote `json:"stale_books"` // Soft books not updated since the move started
+
+	WindowSeconds int       `json:"window_seconds"`
+	DetectedAt    time.Time `json:"detected_at"` // Vendor time of the quote that triggered the event
+}
+
+// BookMove is one book's price change within the movement window
+// A book that moved its line to another point sets FromPoint and ToPoint; its
+// prices are quoted at different points, so Cents is 0.
+type BookMove struct {
+	BookKey   string    `json:"book_key"`
+	FromPrice int       `json:"from_price"`
+	ToPrice   int       `json:"to_price"`
+	FromPoint *float64  `json:"from_point,omitempty"`
+	ToPoint   *float64  `json:"to_point,omitempty"`
+	Cents     int       `json:"cents"` // Absolute size of the move
+	FromTime  time.Time `json:"from_time"`
+	ToTime    time.Time `json:"to_time"`
+}
+
+// BookQuote is a book's latest price for the moved outcome
+type BookQuote struct {
+	BookKey    string    `json:"book_key"`
+	Price      int       `json:"price"`
+	LastUpdate time.Time `json:"last_update"`
+}
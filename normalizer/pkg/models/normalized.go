@@ -1,18 +1,25 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/shared/odds"
+)
 
 // RawOdds represents raw odds from Mercury (matches Mercury's model)
 type RawOdds struct {
-	EventID          string    `json:"event_id"`
-	SportKey         string    `json:"sport_key"`
-	MarketKey        string    `json:"market_key"`
-	BookKey          string    `json:"book_key"`
-	OutcomeName      string    `json:"outcome_name"`
-	Price            int       `json:"price"`              // American odds
-	Point            *float64  `json:"point,omitempty"`    // For spreads/totals
-	VendorLastUpdate time.Time `json:"vendor_last_update"`
-	ReceivedAt       time.Time `json:"received_at"`
+	EventID          string      `json:"event_id"`
+	SportKey         string      `json:"sport_key"`
+	MarketKey        string      `json:"market_key"`
+	BookKey          string      `json:"book_key"`
+	OutcomeName      string      `json:"outcome_name"`
+	Description      string      `json:"description,omitempty"`  // Player name for props
+	Price            int         `json:"price"`                  // American odds
+	Point            *float64    `json:"point,omitempty"`        // For spreads/totals
+	PriceFormat      PriceFormat `json:"price_format,omitempty"` // Format of PriceValue (empty = American Price only)
+	PriceValue       string      `json:"price_value,omitempty"`  // Price as quoted by a non-US feed ("2.10", "11/10", "-0.87")
+	VendorLastUpdate time.Time   `json:"vendor_last_update"`
+	ReceivedAt       time.Time   `json:"received_at"`
 }
 
 // NormalizedOdds represents odds after normalization with fair prices and edges
@@ -28,10 +35,12 @@ type NormalizedOdds struct {
 	Edge               *float64  `json:"edge"`                 // Percentage edge vs fair price
 	
 	// Sharp consensus (for soft books)
-	SharpConsensus     *float64  `json:"sharp_consensus"`      // Average sharp book probability
+	SharpConsensus     *float64  `json:"sharp_consensus"`      // Weighted, devigged sharp book probability
+	SharpDispersion    *float64  `json:"sharp_dispersion,omitempty"` // Weighted std dev across sharp books
+	SharpBookCount     int       `json:"sharp_book_count,omitempty"` // Sharp books in the consensus
 	
 	// Market classification
-	MarketType         string    `json:"market_type"`          // two_way, three_way, props
+	MarketType         string    `json:"market_type"`          // two_way, three_way, props, double_chance
 	VigMethod          string    `json:"vig_method"`           // multiplicative, additive
 	
 	// Metadata
@@ -39,13 +48,26 @@ type NormalizedOdds struct {
 	ProcessingLatency  int64     `json:"processing_latency_ms"` // Milliseconds
 }
 
+// PriceFormat is the odds format a book quotes in
+type PriceFormat = odds.Format
+
+const (
+	PriceFormatAmerican   = odds.American   // +150, -110
+	PriceFormatDecimal    = odds.Decimal    // 2.50, 1.91
+	PriceFormatFractional = odds.Fractional // 3/2, 10/11
+	PriceFormatHongKong   = odds.HongKong   // 1.50, 0.91 (profit per unit staked)
+	PriceFormatMalay      = odds.Malay      // 0.91, -0.67 (negative for underdogs)
+	PriceFormatIndonesian = odds.Indonesian // 1.50, -1.10 (negative for favorites)
+)
+
 // MarketType defines the type of betting market
 type MarketType string
 
 const (
 	MarketTypeTwoWay   MarketType = "two_way"   // spreads, totals
 	MarketTypeThreeWay MarketType = "three_way" // moneyline (home, away, draw)
-	MarketTypeProps    MarketType = "props"     // player props
+	MarketTypeProps    MarketType = "props"     // player props (Over/Under per player and line)
+	MarketTypeDoubleChance MarketType = "double_chance" // 1X, 12, X2 (each outcome covers two results)
 )
 
 // VigMethod defines how to remove vig
@@ -53,8 +75,11 @@ type VigMethod string
 
 const (
 	VigMethodMultiplicative VigMethod = "multiplicative" // Two-way markets
-	VigMethodAdditive       VigMethod = "additive"       // Three-way markets
-	VigMethodNone           VigMethod = "none"           // Props (book-vs-book comparison)
+	VigMethodAdditive       VigMethod = "additive"       // Equal share of the margin per outcome
+	VigMethodShin           VigMethod = "shin"           // Insider-adjusted, corrects favorite-longshot bias
+	VigMethodPower          VigMethod = "power"          // Common exponent, never negative
+	VigMethodOddsRatio      VigMethod = "odds_ratio"     // Common odds ratio, never negative
+	VigMethodNone           VigMethod = "none"           // No vig removal (book-vs-book comparison)
 )
 
 // BookType classifies sportsbook
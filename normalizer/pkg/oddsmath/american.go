@@ -2,7 +2,8 @@ package oddsmath
 
 import (
 	"fmt"
-	"math"
+
+	"github.com/XavierBriggs/fortuna/services/shared/odds"
 )
 
 // AmericanToDecimal converts American odds to decimal odds
@@ -13,13 +14,7 @@ func AmericanToDecimal(american int) (float64, error) {
 		return 0, fmt.Errorf("invalid American odds: cannot be 0")
 	}
 
-	if american > 0 {
-		// Positive odds: (american / 100) + 1
-		return (float64(american) / 100.0) + 1.0, nil
-	}
-
-	// Negative odds: (100 / abs(american)) + 1
-	return (100.0 / float64(-american)) + 1.0, nil
+	return odds.AmericanToDecimal(american), nil
 }
 
 // DecimalToAmerican converts decimal odds to American odds
@@ -30,13 +25,7 @@ func DecimalToAmerican(decimal float64) (int, error) {
 		return 0, fmt.Errorf("invalid decimal odds: must be >= 1.0")
 	}
 
-	if decimal >= 2.0 {
-		// Positive American odds: (decimal - 1) * 100
-		return int(math.Round((decimal - 1.0) * 100.0)), nil
-	}
-
-	// Negative American odds: -100 / (decimal - 1)
-	return int(math.Round(-100.0 / (decimal - 1.0))), nil
+	return odds.DecimalToAmerican(decimal), nil
 }
 
 // DecimalToImpliedProbability converts decimal odds to implied probability
@@ -82,4 +71,3 @@ func ProbabilityToAmerican(probability float64) (int, error) {
 
 	return DecimalToAmerican(decimal)
 }
-
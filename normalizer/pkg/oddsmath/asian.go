@@ -0,0 +1,157 @@
+package oddsmath
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+)
+
+// maxGoals bounds the Poisson goal grid (P(11+ goals for one side) is negligible)
+const maxGoals = 10
+
+// SplitAsianLine splits an Asian handicap line into the lines its stake is placed on
+// Quarter lines are half the stake on each neighbouring line; whole and half
+// lines are a single bet.
+//
+// Examples:
+// -0.25 → [-0.5, 0]   (half on -0.5, half on level)
+// -0.75 → [-1, -0.5]
+// +0.5  → [+0.5]
+func SplitAsianLine(line float64) []float64 {
+	quarters := math.Round(line * 4)
+	if math.Mod(quarters, 2) == 0 {
+		return []float64{line}
+	}
+	return []float64{line - 0.25, line + 0.25}
+}
+
+// GoalMargins returns P(team goals - opponent goals = m) for independent Poisson scoring
+func GoalMargins(teamExpected, opponentExpected float64) map[int]float64 {
+	team := poisson(teamExpected)
+	opponent := poisson(opponentExpected)
+
+	margins := make(map[int]float64)
+	for i, pi := range team {
+		for j, pj := range opponent {
+			margins[i-j] += pi * pj
+		}
+	}
+	return margins
+}
+
+// AsianHandicapProbability returns the effective fair probability (1 / fair decimal)
+// of backing a team on an Asian handicap line
+//
+// Each split stake wins when margin + line > 0, is refunded on 0 and loses
+// otherwise. With a common price D the bet breaks even when:
+//
+//	(D - 1) × Σ P(win) = Σ P(lose)   over the split lines
+//
+// so D = 1 + ΣP(lose) / ΣP(win). A devigged two-way Asian market already prices
+// this effective probability, since fair sides satisfy 1/D_team + 1/D_opp = 1.
+func AsianHandicapProbability(line float64, margins map[int]float64) (float64, error) {
+	win, lose := 0.0, 0.0
+	for _, split := range SplitAsianLine(line) {
+		for margin, prob := range margins {
+			result := float64(margin) + split
+			if result > 0 {
+				win += prob
+			} else if result < 0 {
+				lose += prob
+			}
+		}
+	}
+
+	if win <= 0 || lose <= 0 {
+		return 0, fmt.Errorf("handicap %.2f has no two-sided outcome", line)
+	}
+
+	// 1 / (1 + lose/win)
+	return win / (win + lose), nil
+}
+
+// AdjustAsianHandicapProbability translates a fair effective probability from one
+// Asian handicap line to another for the same team
+//
+// The team's goal supremacy is solved so a Poisson model with expectedGoals
+// total reproduces fairProb at fromLine, then the target line is priced with
+// split stakes. Moving -0.5 to -0.25 gives back half the stake on a draw.
+func AdjustAsianHandicapProbability(fairProb, fromLine, toLine, expectedGoals float64) (float64, error) {
+	if fairProb <= 0 || fairProb >= 1 {
+		return 0, fmt.Errorf("fair probability must be between 0 and 1")
+	}
+	if expectedGoals <= 0 {
+		return 0, fmt.Errorf("expected goals must be positive")
+	}
+
+	if fromLine == toLine {
+		return fairProb, nil
+	}
+
+	priceAt := func(line, supremacy float64) float64 {
+		margins := GoalMargins((expectedGoals+supremacy)/2, (expectedGoals-supremacy)/2)
+		prob, err := AsianHandicapProbability(line, margins)
+		if err != nil {
+			return 0
+		}
+		return prob
+	}
+
+	// Effective probability rises with supremacy; keep both rates positive
+	limit := expectedGoals * 0.999
+	supremacy, err := bisect(func(s float64) float64 {
+		return fairProb - priceAt(fromLine, s)
+	}, -limit, limit)
+	if err != nil {
+		return 0, fmt.Errorf("asian handicap: %w", err)
+	}
+
+	adjusted := priceAt(toLine, supremacy)
+	if adjusted <= 0 || adjusted >= 1 {
+		return 0, fmt.Errorf("adjusted probability out of range moving %.2f to %.2f", fromLine, toLine)
+	}
+
+	return adjusted, nil
+}
+
+// RemoveVigDoubleChance removes vig from a double chance market (1X, 12, X2)
+// Each outcome covers two of the three results, so the implied probabilities sum
+// to ~2. The single result each outcome excludes is recovered, devigged as a
+// normal three-way market, and recombined:
+//
+//	excluded_i = Σd / 2 - d_i
+//	fair_i     = 1 - devig(excluded)_i
+func RemoveVigDoubleChance(method models.VigMethod, probabilities []float64) ([]float64, error) {
+	if len(probabilities) != 3 {
+		return nil, fmt.Errorf("double chance needs exactly 3 outcomes")
+	}
+
+	half := sum(probabilities) / 2
+	excluded := make([]float64, 3)
+	for i, prob := range probabilities {
+		excluded[i] = half - prob
+	}
+
+	fairExcluded, err := RemoveVig(method, excluded)
+	if err != nil {
+		return nil, fmt.Errorf("double chance: %w", err)
+	}
+
+	fairProbs := make([]float64, 3)
+	for i, prob := range fairExcluded {
+		fairProbs[i] = 1 - prob
+	}
+
+	return fairProbs, nil
+}
+
+// poisson returns P(k goals) for k = 0..maxGoals
+func poisson(lambda float64) []float64 {
+	probs := make([]float64, maxGoals+1)
+	probs[0] = math.Exp(-lambda)
+	for k := 1; k <= maxGoals; k++ {
+		probs[k] = probs[k-1] * lambda / float64(k)
+	}
+	return probs
+}
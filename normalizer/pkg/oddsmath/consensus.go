@@ -0,0 +1,102 @@
+package oddsmath
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// SharpQuote is one sharp book's fair (no-vig) probability for an outcome
+type SharpQuote struct {
+	BookKey         string
+	FairProbability float64       // Already devigged against the book's own market
+	Reliability     float64       // Configured book weight (e.g. Pinnacle 1.0, Circa 0.8)
+	Age             time.Duration // How old the quote is relative to the freshest quote
+}
+
+// ConsensusResult is a weighted sharp consensus with its spread across books
+type ConsensusResult struct {
+	Probability float64 // Weighted average fair probability
+	Dispersion  float64 // Weighted standard deviation across books
+	BookCount   int     // Books that contributed a non-zero weight
+	TotalWeight float64 // Sum of effective weights (reliability × decay)
+}
+
+// CalculateWeightedConsensus combines devigged sharp quotes into one fair probability
+//
+// Each quote's effective weight is:
+//
+//	weight = reliability × 0.5^(age / halfLife)
+//
+// so a quote halfLife old counts half as much as a fresh one from the same book.
+// A zero halfLife disables decay. Quotes older than maxAge (if > 0) are dropped, as
+// are quotes without a probability strictly between 0 and 1; an error is returned
+// only when no usable quote remains.
+//
+// Example:
+// Pinnacle 50.0% (w=1.0, fresh) | Circa 52.0% (w=0.8, one half-life old)
+// Effective weights: 1.0, 0.4 → consensus = (0.50 + 0.208) / 1.4 = 50.57%
+func CalculateWeightedConsensus(quotes []SharpQuote, halfLife, maxAge time.Duration) (*ConsensusResult, error) {
+	if len(quotes) == 0 {
+		return nil, fmt.Errorf("no sharp quotes provided")
+	}
+
+	weights := make([]float64, len(quotes))
+	totalWeight := 0.0
+	weightedSum := 0.0
+	bookCount := 0
+
+	for i, quote := range quotes {
+		// A bad quote from one book shouldn't void the others
+		if quote.FairProbability <= 0 || quote.FairProbability >= 1 || math.IsNaN(quote.FairProbability) {
+			continue
+		}
+
+		if maxAge > 0 && quote.Age > maxAge {
+			continue
+		}
+
+		weight := quote.Reliability * RecencyDecay(quote.Age, halfLife)
+		if weight <= 0 {
+			continue
+		}
+
+		weights[i] = weight
+		totalWeight += weight
+		weightedSum += weight * quote.FairProbability
+		bookCount++
+	}
+
+	if totalWeight == 0 {
+		return nil, fmt.Errorf("no valid sharp quotes with positive weight")
+	}
+
+	mean := weightedSum / totalWeight
+
+	// Weighted variance around the consensus
+	variance := 0.0
+	for i, quote := range quotes {
+		if weights[i] == 0 {
+			continue
+		}
+		diff := quote.FairProbability - mean
+		variance += weights[i] * diff * diff
+	}
+	variance /= totalWeight
+
+	return &ConsensusResult{
+		Probability: mean,
+		Dispersion:  math.Sqrt(variance),
+		BookCount:   bookCount,
+		TotalWeight: totalWeight,
+	}, nil
+}
+
+// RecencyDecay returns the exponential decay factor for a quote of the given age
+// 1.0 for a fresh quote, 0.5 at one half-life, 0.25 at two
+func RecencyDecay(age, halfLife time.Duration) float64 {
+	if halfLife <= 0 || age <= 0 {
+		return 1.0
+	}
+	return math.Pow(0.5, float64(age)/float64(halfLife))
+}
@@ -0,0 +1,229 @@
+package oddsmath
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+)
+
+// Solver settings shared by the iterative devig methods
+const (
+	devigTolerance     = 1e-12
+	devigMaxIterations = 200
+)
+
+// RemoveVig removes vig from an N-outcome market using the given method
+// This is the single entry point sport normalizers use so the method can be
+// chosen per market through config
+//
+// Probabilities are the implied probabilities of every outcome in the market
+// (one per outcome, same book). The returned fair probabilities sum to 1.0.
+func RemoveVig(method models.VigMethod, probabilities []float64) ([]float64, error) {
+	switch method {
+	case models.VigMethodMultiplicative:
+		return RemoveVigMultiplicativeN(probabilities)
+	case models.VigMethodAdditive:
+		return RemoveVigAdditive(probabilities)
+	case models.VigMethodShin:
+		return RemoveVigShin(probabilities)
+	case models.VigMethodPower:
+		return RemoveVigPower(probabilities)
+	case models.VigMethodOddsRatio:
+		return RemoveVigOddsRatio(probabilities)
+	default:
+		return nil, fmt.Errorf("unsupported vig method: %s", method)
+	}
+}
+
+// RemoveVigMultiplicativeN is the N-outcome form of RemoveVigMultiplicative
+// Each probability is divided by the total overround
+func RemoveVigMultiplicativeN(probabilities []float64) ([]float64, error) {
+	total, err := validateOverround(probabilities)
+	if err != nil {
+		return nil, err
+	}
+
+	fairProbs := make([]float64, len(probabilities))
+	for i, prob := range probabilities {
+		fairProbs[i] = prob / total
+	}
+
+	return fairProbs, nil
+}
+
+// RemoveVigShin removes vig using Shin's insider-trading model
+// Shin assumes a fraction z of money comes from insiders, which makes books
+// shade longshots more than favorites. This corrects the favorite-longshot
+// bias that the multiplicative method leaves in place.
+//
+// Formula (π = implied probabilities, Π = Σπ):
+//
+//	p_i = (sqrt(z² + 4(1 - z)·π_i²/Π) - z) / (2(1 - z))
+//
+// z is solved numerically so that Σp_i = 1.
+func RemoveVigShin(probabilities []float64) ([]float64, error) {
+	total, err := validateOverround(probabilities)
+	if err != nil {
+		return nil, err
+	}
+
+	shin := func(z float64) []float64 {
+		fairProbs := make([]float64, len(probabilities))
+		for i, prob := range probabilities {
+			fairProbs[i] = (math.Sqrt(z*z+4*(1-z)*prob*prob/total) - z) / (2 * (1 - z))
+		}
+		return fairProbs
+	}
+
+	// Σp_i is decreasing in z: z=0 gives sqrt(Π) > 1; insider share never nears 1
+	z, err := bisect(func(z float64) float64 {
+		return sum(shin(z)) - 1.0
+	}, 0, 0.99)
+	if err != nil {
+		return nil, fmt.Errorf("shin: %w", err)
+	}
+
+	return normalize(shin(z)), nil
+}
+
+// RemoveVigPower removes vig by raising each implied probability to a common power
+//
+// Formula: p_i = π_i^k, with k > 1 solved so that Σp_i = 1
+//
+// Longshots (small π) shrink proportionally more than favorites, and fair
+// probabilities can never go negative.
+func RemoveVigPower(probabilities []float64) ([]float64, error) {
+	if _, err := validateOverround(probabilities); err != nil {
+		return nil, err
+	}
+
+	power := func(k float64) []float64 {
+		fairProbs := make([]float64, len(probabilities))
+		for i, prob := range probabilities {
+			fairProbs[i] = math.Pow(prob, k)
+		}
+		return fairProbs
+	}
+
+	// Σπ_i^k is decreasing in k; k=1 is the raw overround (> 1)
+	upper := 2.0
+	for sum(power(upper)) > 1.0 {
+		upper *= 2
+		if upper > 1e6 {
+			return nil, fmt.Errorf("power: failed to bracket exponent")
+		}
+	}
+
+	k, err := bisect(func(k float64) float64 {
+		return sum(power(k)) - 1.0
+	}, 1, upper)
+	if err != nil {
+		return nil, fmt.Errorf("power: %w", err)
+	}
+
+	return normalize(power(k)), nil
+}
+
+// RemoveVigOddsRatio removes vig by scaling the odds (p / (1 - p)) of every outcome
+// by a common ratio
+//
+// Formula: p_i = π_i / (c + π_i - c·π_i), with c > 1 solved so that Σp_i = 1
+//
+// Equivalent to odds(p_i) = odds(π_i) / c. Like the power method it keeps
+// fair probabilities inside (0, 1) on lopsided markets.
+func RemoveVigOddsRatio(probabilities []float64) ([]float64, error) {
+	if _, err := validateOverround(probabilities); err != nil {
+		return nil, err
+	}
+
+	oddsRatio := func(c float64) []float64 {
+		fairProbs := make([]float64, len(probabilities))
+		for i, prob := range probabilities {
+			fairProbs[i] = prob / (c + prob - c*prob)
+		}
+		return fairProbs
+	}
+
+	// Σp_i is decreasing in c; c=1 is the raw overround (> 1)
+	upper := 2.0
+	for sum(oddsRatio(upper)) > 1.0 {
+		upper *= 2
+		if upper > 1e9 {
+			return nil, fmt.Errorf("odds ratio: failed to bracket ratio")
+		}
+	}
+
+	c, err := bisect(func(c float64) float64 {
+		return sum(oddsRatio(c)) - 1.0
+	}, 1, upper)
+	if err != nil {
+		return nil, fmt.Errorf("odds ratio: %w", err)
+	}
+
+	return normalize(oddsRatio(c)), nil
+}
+
+// validateOverround checks the inputs shared by every devig method
+// Returns the total implied probability (overround)
+func validateOverround(probabilities []float64) (float64, error) {
+	if len(probabilities) < 2 {
+		return 0, fmt.Errorf("need at least 2 outcomes")
+	}
+
+	total := 0.0
+	for _, prob := range probabilities {
+		if prob <= 0 || prob >= 1 {
+			return 0, fmt.Errorf("all probabilities must be between 0 and 1")
+		}
+		total += prob
+	}
+
+	if total <= 1.0 {
+		return 0, fmt.Errorf("no vig detected: probabilities sum to <= 1.0")
+	}
+
+	return total, nil
+}
+
+// bisect finds the root of a monotonically decreasing function on [lo, hi]
+// f(lo) must be > 0 and f(hi) must be <= 0
+func bisect(f func(float64) float64, lo, hi float64) (float64, error) {
+	if f(lo) <= 0 || f(hi) > 0 {
+		return 0, fmt.Errorf("root not bracketed in [%g, %g]", lo, hi)
+	}
+
+	for i := 0; i < devigMaxIterations; i++ {
+		mid := (lo + hi) / 2
+		if f(mid) > 0 {
+			lo = mid
+		} else {
+			hi = mid
+		}
+
+		if hi-lo < devigTolerance {
+			break
+		}
+	}
+
+	return (lo + hi) / 2, nil
+}
+
+// normalize rescales probabilities to sum to exactly 1.0
+// Removes the residual solver error so callers can rely on the sum
+func normalize(probabilities []float64) []float64 {
+	total := sum(probabilities)
+	for i := range probabilities {
+		probabilities[i] /= total
+	}
+	return probabilities
+}
+
+// sum adds a slice of float64
+func sum(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
@@ -0,0 +1,66 @@
+package oddsmath
+
+import (
+	"fmt"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/shared/odds"
+)
+
+// Raw quotes carry either an American Price or a PriceValue in PriceFormat.
+// Parsing and formatting live in the shared odds package; these helpers pick the
+// exact price off a quote.
+
+// QuotedOdds returns the exact price a raw quote was made at
+// PriceValue (in PriceFormat) wins over the American Price when both are set.
+func QuotedOdds(raw models.RawOdds) (odds.Odds, error) {
+	return odds.Quoted(raw.Price, raw.PriceValue, raw.PriceFormat)
+}
+
+// ResolvePrice validates a raw quote's price and fills Price from PriceValue
+// Non-US feeds may send only PriceValue; Price is set to the nearest American
+// odds so consumers that only read Price keep working. Pricing uses the exact
+// quoted value via RawDecimal and RawImpliedProbability.
+func ResolvePrice(raw *models.RawOdds) error {
+	if raw.PriceValue == "" {
+		if raw.Price == 0 {
+			return fmt.Errorf("invalid American odds: cannot be 0")
+		}
+		return nil
+	}
+
+	price, err := odds.Parse(raw.PriceValue, raw.PriceFormat)
+	if err != nil {
+		return err
+	}
+	if raw.Price == 0 {
+		raw.Price = price.American()
+	}
+	return nil
+}
+
+// RawDecimal returns decimal odds for a raw quote, exact from PriceValue when set
+func RawDecimal(raw models.RawOdds) (float64, error) {
+	if raw.PriceValue == "" {
+		return AmericanToDecimal(raw.Price)
+	}
+
+	price, err := odds.Parse(raw.PriceValue, raw.PriceFormat)
+	if err != nil {
+		return 0, err
+	}
+	return price.Decimal(), nil
+}
+
+// RawImpliedProbability returns the implied probability of a raw quote, exact from PriceValue when set
+func RawImpliedProbability(raw models.RawOdds) (float64, error) {
+	if raw.PriceValue == "" {
+		return AmericanToImpliedProbability(raw.Price)
+	}
+
+	price, err := odds.Parse(raw.PriceValue, raw.PriceFormat)
+	if err != nil {
+		return 0, err
+	}
+	return price.ImpliedProbability(), nil
+}
@@ -0,0 +1,120 @@
+package oddsmath
+
+import (
+	"fmt"
+	"math"
+)
+
+// MarginDistribution is the probability a game lands on each exact final margin
+// Landing is keyed by absolute margin and is the per-side probability for games
+// priced near that margin (e.g. NFL 3 ≈ 9.5%: a -3 favorite wins by exactly 3).
+type MarginDistribution struct {
+	Landing map[int]float64
+	Default float64 // Margins missing from Landing
+}
+
+// Probability returns the landing probability for an exact margin
+func (d MarginDistribution) Probability(margin int) float64 {
+	if margin < 0 {
+		margin = -margin
+	}
+	if prob, ok := d.Landing[margin]; ok {
+		return prob
+	}
+	return d.Default
+}
+
+// PushProbability returns the chance a spread pushes (zero for half-point lines)
+func (d MarginDistribution) PushProbability(point float64) float64 {
+	if point != math.Trunc(point) {
+		return 0
+	}
+	return d.Probability(int(point))
+}
+
+// AdjustSpreadProbability translates a fair (no-push) cover probability from one
+// spread point to another of the same team
+//
+// Points are from the team's perspective (favorite -3, underdog +3). The team
+// covers when margin + point > 0 and pushes when it is exactly 0. Moving the
+// line adds or removes the landing probability of every margin crossed:
+//
+//	P(win @ to) = P(win @ from) ± Σ P(margin = m), m crossed
+//	fair(to)    = P(win @ to) / (1 - P(push @ to))
+//
+// Example (NFL): fair 50% at -3 with P(3) = 9.5%
+// P(win @ -3) = 0.50 × 0.905 = 45.25% → P(win @ -2.5) = 45.25% + 9.5% = 54.75%
+// Crossing 3 is worth far more than crossing a dead number like 5.
+func AdjustSpreadProbability(fairProb, fromPoint, toPoint float64, dist MarginDistribution) (float64, error) {
+	if fairProb <= 0 || fairProb >= 1 {
+		return 0, fmt.Errorf("fair probability must be between 0 and 1")
+	}
+
+	if fromPoint == toPoint {
+		return fairProb, nil
+	}
+
+	winProb := fairProb * (1 - dist.PushProbability(fromPoint))
+
+	// Team wins when margin > -point, so margins in (-hi, -lo] flip from lose/push to win
+	lo, hi := math.Min(fromPoint, toPoint), math.Max(fromPoint, toPoint)
+	crossed := 0.0
+	for m := int(math.Floor(-hi)) + 1; float64(m) <= -lo; m++ {
+		crossed += dist.Probability(m)
+	}
+
+	if toPoint > fromPoint {
+		winProb += crossed
+	} else {
+		winProb -= crossed
+	}
+
+	adjusted := winProb / (1 - dist.PushProbability(toPoint))
+	if adjusted <= 0 || adjusted >= 1 {
+		return 0, fmt.Errorf("adjusted probability out of range moving %.1f to %.1f", fromPoint, toPoint)
+	}
+
+	return adjusted, nil
+}
+
+// MoneylineToSpreadProbability returns the fair cover probability at a spread point
+// implied by a fair moneyline win probability
+//
+// A moneyline with ties refunded is a spread at 0, so the win probability (with the
+// draw removed) is moved from point 0 to the spread point across the margin distribution.
+func MoneylineToSpreadProbability(winProb, point float64, dist MarginDistribution) (float64, error) {
+	return AdjustSpreadProbability(winProb, 0, point, dist)
+}
+
+// maxImpliedSpread bounds the implied spread search (points from pick'em)
+const maxImpliedSpread = 40.0
+
+// ImpliedSpread returns the half-point spread a fair moneyline win probability implies
+// It is the point (from the team's perspective) whose implied cover probability is
+// closest to 50%, e.g. a 72% NFL favorite ≈ -6.5.
+func ImpliedSpread(winProb float64, dist MarginDistribution) (float64, error) {
+	if winProb <= 0 || winProb >= 1 {
+		return 0, fmt.Errorf("win probability must be between 0 and 1")
+	}
+
+	// Favorites give points, underdogs get them
+	step := -0.5
+	if winProb < 0.5 {
+		step = 0.5
+	}
+
+	best, bestDiff := 0.0, math.Abs(winProb-0.5)
+	for point := step; math.Abs(point) <= maxImpliedSpread; point += step {
+		prob, err := MoneylineToSpreadProbability(winProb, point, dist)
+		if err != nil {
+			break
+		}
+		diff := math.Abs(prob - 0.5)
+		if diff > bestDiff {
+			break
+		}
+		best, bestDiff = point, diff
+	}
+
+	return best, nil
+}
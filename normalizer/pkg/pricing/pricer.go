@@ -0,0 +1,465 @@
+package pricing
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/oddsmath"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/props"
+)
+
+// Rules supplies the sport-specific settings the pricer needs
+// Sport configs (e.g. basketball_nba.Config) implement this directly
+type Rules interface {
+	GetMarketType(marketKey string) models.MarketType
+	GetMarketVigMethod(marketKey string) models.VigMethod
+	IsSharpBook(bookKey string) bool
+	GetSharpBookWeight(bookKey string) float64
+	GetConsensusHalfLife() time.Duration
+	GetMaxSharpQuoteAge() time.Duration
+}
+
+// OutcomeCounter is optionally implemented by Rules that know how many outcomes
+// a complete market has (soccer h2h must price Home, Draw and Away)
+type OutcomeCounter interface {
+	GetOutcomeCount(marketKey string) int
+}
+
+// PointAdjuster translates a fair probability quoted at one point to another point
+// of the same outcome. Returns false when the market can't be translated.
+//
+// Sports with key numbers (NFL spreads) use this so a sharp -3 can price a soft -2.5.
+type PointAdjuster func(marketKey string, fairProb, fromPoint, toPoint float64) (float64, bool)
+
+// Pricer computes fair prices, sharp consensus and edges for one sport
+// Shared by every sport module so fair-value math stays identical across sports
+type Pricer struct {
+	rules         Rules
+	pointAdjuster PointAdjuster
+}
+
+// NewPricer creates a pricer that only compares quotes at the same point
+func NewPricer(rules Rules) *Pricer {
+	return NewPricerWithAdjuster(rules, nil)
+}
+
+// NewPricerWithAdjuster creates a pricer that can compare quotes across points
+func NewPricerWithAdjuster(rules Rules, adjuster PointAdjuster) *Pricer {
+	return &Pricer{
+		rules:         rules,
+		pointAdjuster: adjuster,
+	}
+}
+
+// Normalize processes raw odds and returns normalized odds with fair prices and edges
+func (p *Pricer) Normalize(raw models.RawOdds, marketOdds []models.RawOdds) (*models.NormalizedOdds, error) {
+	startTime := time.Now()
+
+	// Convert the quoted price to decimal and implied probability (exact for non-American feeds)
+	decimal, err := oddsmath.RawDecimal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("error converting odds: %w", err)
+	}
+
+	impliedProb, err := oddsmath.DecimalToImpliedProbability(decimal)
+	if err != nil {
+		return nil, fmt.Errorf("error calculating implied probability: %w", err)
+	}
+
+	// Initialize normalized odds
+	normalized := &models.NormalizedOdds{
+		RawOdds:            raw,
+		DecimalOdds:        decimal,
+		ImpliedProbability: impliedProb,
+		NormalizedAt:       time.Now(),
+	}
+
+	// Get market type and vig method
+	marketType := p.rules.GetMarketType(raw.MarketKey)
+	vigMethod := p.rules.GetMarketVigMethod(raw.MarketKey)
+
+	normalized.MarketType = string(marketType)
+	normalized.VigMethod = string(vigMethod)
+
+	// Process based on market type
+	switch marketType {
+	case models.MarketTypeTwoWay:
+		// Two-way markets (spreads, totals) - remove vig and calculate fair price
+		if err := p.normalizeTwoWayMarket(normalized, raw, marketOdds, vigMethod); err != nil {
+			return nil, fmt.Errorf("error normalizing two-way market: %w", err)
+		}
+
+	case models.MarketTypeThreeWay:
+		// Three-way markets (moneyline) - compare to sharp consensus
+		if err := p.normalizeThreeWayMarket(normalized, raw, marketOdds, vigMethod); err != nil {
+			return nil, fmt.Errorf("error normalizing three-way market: %w", err)
+		}
+
+	case models.MarketTypeDoubleChance:
+		// Double chance - devig the three results each outcome excludes
+		if err := p.normalizeDoubleChanceMarket(normalized, raw, marketOdds, vigMethod); err != nil {
+			return nil, fmt.Errorf("error normalizing double chance market: %w", err)
+		}
+
+	case models.MarketTypeProps:
+		// Props - devig Over/Under per player and line
+		if err := p.normalizePropsMarket(normalized, raw, marketOdds, vigMethod); err != nil {
+			return nil, fmt.Errorf("error normalizing props market: %w", err)
+		}
+	}
+
+	// Calculate processing latency
+	normalized.ProcessingLatency = time.Since(startTime).Milliseconds()
+
+	return normalized, nil
+}
+
+// normalizeTwoWayMarket handles spreads and totals
+func (p *Pricer) normalizeTwoWayMarket(normalized *models.NormalizedOdds, raw models.RawOdds, marketOdds []models.RawOdds, vigMethod models.VigMethod) error {
+	// Remove vig against this book's opposite side (if it is priced)
+	if err := p.applyOwnFairPrice(normalized, raw, marketOdds, vigMethod); err != nil {
+		return err
+	}
+
+	// Calculate sharp consensus if this is a soft book
+	// (a soft book's edge vs sharp doesn't need its own opposite side)
+	p.ApplySharpConsensus(normalized, raw, marketOdds, vigMethod)
+
+	return nil
+}
+
+// applyOwnFairPrice sets no-vig probability, fair price and edge from the book's own
+// two-way market
+func (p *Pricer) applyOwnFairPrice(normalized *models.NormalizedOdds, raw models.RawOdds, marketOdds []models.RawOdds, vigMethod models.VigMethod) error {
+	// Find the opposite side of this market
+	oppositeSide := FindOppositeSide(raw, marketOdds)
+	if oppositeSide == nil {
+		// Can't calculate fair price without opposite side
+		return nil
+	}
+
+	// Convert opposite side to probability
+	oppositeDecimal, err := oddsmath.RawDecimal(*oppositeSide)
+	if err != nil {
+		return err
+	}
+
+	oppositeProb, err := oddsmath.DecimalToImpliedProbability(oppositeDecimal)
+	if err != nil {
+		return err
+	}
+
+	// Remove vig using the configured method
+	fairProbs, err := oddsmath.RemoveVig(vigMethod, []float64{normalized.ImpliedProbability, oppositeProb})
+	if err != nil {
+		// If vig removal fails, just use implied probability
+		return nil
+	}
+
+	ApplyFairProbability(normalized, fairProbs[0])
+	return nil
+}
+
+// normalizeThreeWayMarket handles moneylines
+func (p *Pricer) normalizeThreeWayMarket(normalized *models.NormalizedOdds, raw models.RawOdds, marketOdds []models.RawOdds, vigMethod models.VigMethod) error {
+	// Remove vig across every outcome this book prices (2 for NBA, 3 with a draw)
+	probs := CollectBookOutcomes(normalized.ImpliedProbability, raw, marketOdds)
+	if p.isComplete(raw.MarketKey, probs) && vigMethod != models.VigMethodNone {
+		if fairProbs, err := oddsmath.RemoveVig(vigMethod, probs); err == nil {
+			ApplyFairProbability(normalized, fairProbs[0])
+		}
+	}
+
+	// Compare soft books to sharp consensus
+	p.ApplySharpConsensus(normalized, raw, marketOdds, vigMethod)
+
+	return nil
+}
+
+// normalizeDoubleChanceMarket handles 1X / 12 / X2
+func (p *Pricer) normalizeDoubleChanceMarket(normalized *models.NormalizedOdds, raw models.RawOdds, marketOdds []models.RawOdds, vigMethod models.VigMethod) error {
+	probs := CollectBookOutcomes(normalized.ImpliedProbability, raw, marketOdds)
+	if len(probs) == 3 && vigMethod != models.VigMethodNone {
+		if fairProbs, err := oddsmath.RemoveVigDoubleChance(vigMethod, probs); err == nil {
+			ApplyFairProbability(normalized, fairProbs[0])
+		}
+	}
+
+	// Compare soft books to sharp consensus
+	p.ApplySharpConsensus(normalized, raw, marketOdds, vigMethod)
+
+	return nil
+}
+
+// isComplete checks a book priced every outcome the market needs before devigging
+// Without an OutcomeCounter any market with two or more outcomes is complete
+func (p *Pricer) isComplete(marketKey string, probs []float64) bool {
+	if counter, ok := p.rules.(OutcomeCounter); ok {
+		if want := counter.GetOutcomeCount(marketKey); want > 0 {
+			return len(probs) == want
+		}
+	}
+	return len(probs) >= 2
+}
+
+// normalizePropsMarket handles player props
+// Over/Under pairs are matched by player and line, then devigged like totals.
+// When no sharp book prices both sides of the prop, the fair price falls back
+// to this book's own two-sided market.
+func (p *Pricer) normalizePropsMarket(normalized *models.NormalizedOdds, raw models.RawOdds, marketOdds []models.RawOdds, vigMethod models.VigMethod) error {
+	if vigMethod != models.VigMethodNone {
+		if err := p.applyOwnFairPrice(normalized, raw, marketOdds, vigMethod); err != nil {
+			return err
+		}
+	}
+
+	// Sharp two-sided props override the book's own fair price
+	p.ApplySharpConsensus(normalized, raw, marketOdds, vigMethod)
+
+	return nil
+}
+
+// ApplyFairProbability sets no-vig probability, fair price and edge from a fair probability
+func ApplyFairProbability(normalized *models.NormalizedOdds, fairProb float64) {
+	normalized.NoVigProbability = &fairProb
+
+	// Convert fair probability to American odds
+	if fairPrice, err := oddsmath.ProbabilityToAmerican(fairProb); err == nil {
+		normalized.FairPrice = &fairPrice
+	}
+
+	// Calculate edge vs fair price
+	if edge, err := oddsmath.CalculateEdge(fairProb, normalized.ImpliedProbability); err == nil {
+		normalized.Edge = &edge
+	}
+}
+
+// FindOppositeSide finds the opposite outcome in a two-way market
+func FindOppositeSide(raw models.RawOdds, marketOdds []models.RawOdds) *models.RawOdds {
+	selection := props.Parse(raw.OutcomeName, raw.Description)
+
+	for _, odds := range marketOdds {
+		// Must be same event, market, and book but different outcome
+		if odds.EventID != raw.EventID ||
+			odds.MarketKey != raw.MarketKey ||
+			odds.BookKey != raw.BookKey ||
+			(odds.OutcomeName == raw.OutcomeName && odds.Description == raw.Description) {
+			continue
+		}
+
+		// Over/Under (totals, props) must be the other side for the same player
+		if selection.IsOverUnder() && !selection.IsOpposite(props.Parse(odds.OutcomeName, odds.Description)) {
+			continue
+		}
+
+		// Over/Under share the line; spreads mirror it
+		if raw.Point != nil && odds.Point != nil {
+			if selection.IsOverUnder() && *raw.Point == *odds.Point {
+				return &odds
+			}
+			if !selection.IsOverUnder() && *raw.Point == -*odds.Point {
+				return &odds
+			}
+		} else if raw.Point == nil && odds.Point == nil {
+			return &odds
+		}
+	}
+	return nil
+}
+
+// CollectBookOutcomes returns implied probabilities for every outcome the same book
+// prices in this market, with the current outcome first
+func CollectBookOutcomes(impliedProb float64, raw models.RawOdds, marketOdds []models.RawOdds) []float64 {
+	probs := []float64{impliedProb}
+
+	for _, odds := range marketOdds {
+		if odds.EventID != raw.EventID ||
+			odds.MarketKey != raw.MarketKey ||
+			odds.BookKey != raw.BookKey ||
+			odds.OutcomeName == raw.OutcomeName {
+			continue
+		}
+
+		prob, err := oddsmath.RawImpliedProbability(odds)
+		if err != nil {
+			continue
+		}
+		probs = append(probs, prob)
+	}
+
+	return probs
+}
+
+// ApplySharpConsensus sets the sharp consensus and edge vs consensus for soft books
+func (p *Pricer) ApplySharpConsensus(normalized *models.NormalizedOdds, raw models.RawOdds, marketOdds []models.RawOdds, vigMethod models.VigMethod) {
+	if p.rules.IsSharpBook(raw.BookKey) {
+		return
+	}
+
+	consensus := p.CalculateSharpConsensus(raw, marketOdds, vigMethod)
+	if consensus == nil {
+		return
+	}
+
+	normalized.SharpConsensus = &consensus.Probability
+	normalized.SharpDispersion = &consensus.Dispersion
+	normalized.SharpBookCount = consensus.BookCount
+
+	// Edge vs sharp consensus replaces edge vs the book's own fair price
+	edge, err := oddsmath.CalculateEdge(consensus.Probability, normalized.ImpliedProbability)
+	if err == nil {
+		normalized.Edge = &edge
+	}
+}
+
+// CalculateSharpConsensus calculates the weighted, recency-decayed fair probability
+// across sharp books
+//
+// Each sharp book is devigged against its own market first, then weighted by
+// configured reliability and decayed by VendorLastUpdate age relative to the
+// freshest quote. Sharp books that only price one side are skipped since their
+// price still carries vig. Sharp quotes at a different point are only used when
+// the pricer has a PointAdjuster that can translate them.
+func (p *Pricer) CalculateSharpConsensus(raw models.RawOdds, marketOdds []models.RawOdds, vigMethod models.VigMethod) *oddsmath.ConsensusResult {
+	// Props and unclassified markets still need a fair consensus
+	if vigMethod == models.VigMethodNone {
+		vigMethod = models.VigMethodMultiplicative
+	}
+
+	var sharpOdds []models.RawOdds
+	var fairProbs []float64
+	byBook := make(map[string]int) // book -> position in sharpOdds
+	reference := raw.VendorLastUpdate
+
+	for _, odds := range marketOdds {
+		// Must be same event, market and outcome but from a sharp book
+		if odds.EventID != raw.EventID ||
+			odds.MarketKey != raw.MarketKey ||
+			odds.OutcomeName != raw.OutcomeName ||
+			odds.Description != raw.Description ||
+			!p.rules.IsSharpBook(odds.BookKey) {
+			continue
+		}
+
+		fairProb, ok := p.devigSharpQuote(odds, marketOdds, vigMethod)
+		if !ok {
+			continue
+		}
+
+		// Same line, or a line the sport knows how to translate
+		if !SamePoint(odds.Point, raw.Point) {
+			if p.pointAdjuster == nil || odds.Point == nil || raw.Point == nil {
+				continue
+			}
+			fairProb, ok = p.pointAdjuster(raw.MarketKey, fairProb, *odds.Point, *raw.Point)
+			if !ok {
+				continue
+			}
+		}
+
+		// One quote per book: its quote at this point, else its nearest translated line
+		if i, seen := byBook[odds.BookKey]; seen {
+			if !closerPoint(odds.Point, sharpOdds[i].Point, raw.Point) {
+				continue
+			}
+			sharpOdds[i] = odds
+			fairProbs[i] = fairProb
+		} else {
+			byBook[odds.BookKey] = len(sharpOdds)
+			sharpOdds = append(sharpOdds, odds)
+			fairProbs = append(fairProbs, fairProb)
+		}
+		if odds.VendorLastUpdate.After(reference) {
+			reference = odds.VendorLastUpdate
+		}
+	}
+
+	if len(sharpOdds) == 0 {
+		return nil
+	}
+
+	quotes := make([]oddsmath.SharpQuote, len(sharpOdds))
+	for i, odds := range sharpOdds {
+		var age time.Duration
+		if !odds.VendorLastUpdate.IsZero() {
+			age = reference.Sub(odds.VendorLastUpdate)
+		}
+
+		quotes[i] = oddsmath.SharpQuote{
+			BookKey:         odds.BookKey,
+			FairProbability: fairProbs[i],
+			Reliability:     p.rules.GetSharpBookWeight(odds.BookKey),
+			Age:             age,
+		}
+	}
+
+	consensus, err := oddsmath.CalculateWeightedConsensus(quotes, p.rules.GetConsensusHalfLife(), p.rules.GetMaxSharpQuoteAge())
+	if err != nil {
+		return nil
+	}
+
+	return consensus
+}
+
+// devigSharpQuote removes vig from a sharp book's quote using that book's own market
+func (p *Pricer) devigSharpQuote(odds models.RawOdds, marketOdds []models.RawOdds, vigMethod models.VigMethod) (float64, bool) {
+	impliedProb, err := oddsmath.RawImpliedProbability(odds)
+	if err != nil {
+		return 0, false
+	}
+
+	var probs []float64
+	switch p.rules.GetMarketType(odds.MarketKey) {
+	case models.MarketTypeThreeWay:
+		probs = CollectBookOutcomes(impliedProb, odds, marketOdds)
+		if !p.isComplete(odds.MarketKey, probs) {
+			return 0, false
+		}
+	case models.MarketTypeDoubleChance:
+		fairProbs, err := oddsmath.RemoveVigDoubleChance(vigMethod, CollectBookOutcomes(impliedProb, odds, marketOdds))
+		if err != nil {
+			return 0, false
+		}
+		return fairProbs[0], true
+	default:
+		opposite := FindOppositeSide(odds, marketOdds)
+		if opposite == nil {
+			return 0, false
+		}
+
+		oppositeProb, err := oddsmath.RawImpliedProbability(*opposite)
+		if err != nil {
+			return 0, false
+		}
+		probs = []float64{impliedProb, oppositeProb}
+	}
+
+	if len(probs) < 2 {
+		return 0, false
+	}
+
+	fairProbs, err := oddsmath.RemoveVig(vigMethod, probs)
+	if err != nil {
+		return 0, false
+	}
+
+	return fairProbs[0], true
+}
+
+// SamePoint reports whether two optional point values are equal
+func SamePoint(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// closerPoint reports whether candidate is strictly nearer target than current
+func closerPoint(candidate, current, target *float64) bool {
+	if candidate == nil || current == nil || target == nil {
+		return false
+	}
+	return math.Abs(*candidate-*target) < math.Abs(*current-*target)
+}
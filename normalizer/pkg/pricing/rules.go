@@ -0,0 +1,164 @@
+package pricing
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+)
+
+// MarketRules is the market classification and sharp consensus settings shared by
+// the built-in sport configs. Sport configs embed it to implement Rules.
+type MarketRules struct {
+	// Sharp books for consensus calculation (ordered by reliability)
+	SharpBooks []string
+
+	// Sharp consensus weighting
+	SharpBookWeights  map[string]float64 // Reliability weight per sharp book (default 1.0)
+	ConsensusHalfLife time.Duration      // Quote weight halves every half-life of staleness
+	MaxSharpQuoteAge  time.Duration      // Sharp quotes older than this are ignored
+
+	// Market type classification (unlisted markets are props)
+	TwoWayMarkets       []string
+	ThreeWayMarkets     []string
+	DoubleChanceMarkets []string
+	PropsMarkets        []string
+
+	// Vig removal method by market type, with optional per-market overrides
+	// (e.g. shin on h2h to correct favorite-longshot bias)
+	VigMethods       map[models.MarketType]models.VigMethod
+	MarketVigMethods map[string]models.VigMethod
+}
+
+// DefaultVigMethods returns the vig removal methods sports start from
+//
+// Moneylines default to shin: books shade longshots more than favorites, and shin
+// models that bias where additive ignores it and goes negative on lopsided prices.
+func DefaultVigMethods() map[models.MarketType]models.VigMethod {
+	return map[models.MarketType]models.VigMethod{
+		models.MarketTypeTwoWay:   models.VigMethodMultiplicative,
+		models.MarketTypeThreeWay: models.VigMethodShin,
+		models.MarketTypeProps:    models.VigMethodMultiplicative,
+	}
+}
+
+// GetMarketType returns the market type for a given market key
+func (r *MarketRules) GetMarketType(marketKey string) models.MarketType {
+	switch {
+	case containsMarket(r.TwoWayMarkets, marketKey):
+		return models.MarketTypeTwoWay
+	case containsMarket(r.ThreeWayMarkets, marketKey):
+		return models.MarketTypeThreeWay
+	case containsMarket(r.DoubleChanceMarkets, marketKey):
+		return models.MarketTypeDoubleChance
+	default:
+		// Listed props and unknown markets alike
+		return models.MarketTypeProps
+	}
+}
+
+// GetVigMethod returns the vig removal method for a market type
+func (r *MarketRules) GetVigMethod(marketType models.MarketType) models.VigMethod {
+	return VigMethodFor(r.VigMethods, marketType)
+}
+
+// GetMarketVigMethod returns the vig removal method for a specific market
+// Per-market overrides take precedence over the market type default
+func (r *MarketRules) GetMarketVigMethod(marketKey string) models.VigMethod {
+	if method, ok := r.MarketVigMethods[marketKey]; ok {
+		return method
+	}
+	return r.GetVigMethod(r.GetMarketType(marketKey))
+}
+
+// SetMarketVigMethods adds per-market vig method overrides, replacing existing ones
+func (r *MarketRules) SetMarketVigMethods(methods map[string]models.VigMethod) {
+	if len(methods) == 0 {
+		return
+	}
+	if r.MarketVigMethods == nil {
+		r.MarketVigMethods = make(map[string]models.VigMethod, len(methods))
+	}
+	for marketKey, method := range methods {
+		r.MarketVigMethods[marketKey] = method
+	}
+}
+
+// GetSharpBookWeight returns the reliability weight for a sharp book
+func (r *MarketRules) GetSharpBookWeight(bookKey string) float64 {
+	if weight, ok := r.SharpBookWeights[bookKey]; ok {
+		return weight
+	}
+	return 1.0
+}
+
+// GetConsensusHalfLife returns the sharp quote recency half-life
+func (r *MarketRules) GetConsensusHalfLife() time.Duration {
+	return r.ConsensusHalfLife
+}
+
+// GetMaxSharpQuoteAge returns the age beyond which sharp quotes are ignored
+func (r *MarketRules) GetMaxSharpQuoteAge() time.Duration {
+	return r.MaxSharpQuoteAge
+}
+
+// IsSharpBook checks if a book is in the sharp list
+func (r *MarketRules) IsSharpBook(bookKey string) bool {
+	return containsMarket(r.SharpBooks, bookKey)
+}
+
+// VigMethodFor looks up a market type's vig method (none when unset)
+func VigMethodFor(methods map[models.MarketType]models.VigMethod, marketType models.MarketType) models.VigMethod {
+	if method, ok := methods[marketType]; ok {
+		return method
+	}
+	return models.VigMethodNone
+}
+
+// ValidateVigMethod checks a vig method is supported
+func ValidateVigMethod(method models.VigMethod) error {
+	switch method {
+	case models.VigMethodNone, models.VigMethodMultiplicative, models.VigMethodAdditive,
+		models.VigMethodShin, models.VigMethodPower, models.VigMethodOddsRatio:
+		return nil
+	default:
+		return fmt.Errorf("unknown vig method %q", method)
+	}
+}
+
+// ParseMarketVigMethods parses per-market vig method overrides, keyed by sport then market
+// Format: comma-separated sport_key:market_key=method (e.g. "basketball_nba:h2h=power")
+func ParseMarketVigMethods(spec string) (map[string]map[string]models.VigMethod, error) {
+	overrides := make(map[string]map[string]models.VigMethod)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		target, method, ok := strings.Cut(entry, "=")
+		sportKey, marketKey, hasMarket := strings.Cut(target, ":")
+		if !ok || !hasMarket || sportKey == "" || marketKey == "" {
+			return nil, fmt.Errorf("invalid vig method override %q (want sport_key:market_key=method)", entry)
+		}
+		if err := ValidateVigMethod(models.VigMethod(method)); err != nil {
+			return nil, fmt.Errorf("override %q: %w", entry, err)
+		}
+
+		if overrides[sportKey] == nil {
+			overrides[sportKey] = make(map[string]models.VigMethod)
+		}
+		overrides[sportKey][marketKey] = models.VigMethod(method)
+	}
+	return overrides, nil
+}
+
+func containsMarket(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package props
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Sides recognised on Over/Under and Yes/No props
+var opposites = map[string]string{
+	"Over":  "Under",
+	"Under": "Over",
+	"Yes":   "No",
+	"No":    "Yes",
+}
+
+// Selection identifies one side of a player prop (or a total)
+type Selection struct {
+	Player string // "LeBron James" (empty for game totals)
+	Side   string // "Over", "Under", "Yes", "No" (empty for team outcomes)
+}
+
+// Parse extracts the player and side from a raw outcome
+// Feeds either put the player in description with outcome "Over",
+// or fold it into the outcome name ("LeBron James Over").
+func Parse(outcomeName, description string) Selection {
+	if description != "" {
+		return Selection{Player: description, Side: outcomeName}
+	}
+
+	for side := range opposites {
+		if outcomeName == side {
+			return Selection{Side: side}
+		}
+		if strings.HasSuffix(outcomeName, " "+side) {
+			return Selection{
+				Player: strings.TrimSuffix(outcomeName, " "+side),
+				Side:   side,
+			}
+		}
+	}
+
+	return Selection{}
+}
+
+// IsOverUnder returns whether the selection has a recognised two-way side
+func (s Selection) IsOverUnder() bool {
+	_, ok := opposites[s.Side]
+	return ok
+}
+
+// IsOpposite returns whether other is the other side of the same player's prop
+func (s Selection) IsOpposite(other Selection) bool {
+	return s.IsOverUnder() && s.Player == other.Player && opposites[s.Side] == other.Side
+}
+
+// PairKey groups both sides of a player's prop at a given line
+// e.g. "LeBron James@25.5"
+func PairKey(player string, point *float64) string {
+	if point == nil {
+		return player
+	}
+	return fmt.Sprintf("%s@%g", player, *point)
+}
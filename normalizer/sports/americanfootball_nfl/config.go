@@ -0,0 +1,162 @@
+package americanfootball_nfl
+
+import (
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/oddsmath"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/pricing"
+)
+
+// Config contains NFL-specific normalization configuration
+type Config struct {
+	SportKey    string
+	DisplayName string
+
+	// Sharp books, consensus weighting, market classification and vig methods
+	// (two-way: spreads, totals; three-way: h2h, ties are rare but possible)
+	pricing.MarketRules
+
+	// Key numbers: spread markets whose sharp prices can be moved across points
+	// using the final margin distribution (3, 7 and 10 carry most of the weight)
+	KeyNumberMarkets   []string
+	MarginDistribution oddsmath.MarginDistribution
+
+	// Edge thresholds
+	MinEdgeForAlert float64 // Minimum edge to generate alert (e.g., 0.02 = 2%)
+	SignificantEdge float64 // Edge considered significant (e.g., 0.05 = 5%)
+}
+
+// DefaultConfig returns the standard NFL normalization configuration
+func DefaultConfig() *Config {
+	return &Config{
+		SportKey:    "americanfootball_nfl",
+		DisplayName: "NFL Football",
+
+		MarketRules: pricing.MarketRules{
+			// Sharp books (in order of reliability)
+			// Pinnacle: Lowest margins, fastest to move
+			// Circa: Takes the largest NFL limits in Vegas and often moves first on sides
+			// Bookmaker: Sharp offshore book
+			SharpBooks: []string{
+				"pinnacle",
+				"circa",
+				"bookmaker",
+			},
+
+			// Circa is close to Pinnacle on NFL sides and totals
+			SharpBookWeights: map[string]float64{
+				"pinnacle":  1.0,
+				"circa":     0.9,
+				"bookmaker": 0.7,
+			},
+			// NFL lines settle over days, not minutes
+			ConsensusHalfLife: 2 * time.Minute,
+			MaxSharpQuoteAge:  15 * time.Minute,
+
+			// Two-way markets
+			TwoWayMarkets: []string{
+				"spreads",
+				"totals",
+				"alternate_spreads",
+				"alternate_totals",
+				"team_totals",
+			},
+
+			// Three-way markets
+			ThreeWayMarkets: []string{
+				"h2h", // moneyline
+			},
+
+			// Player props (Over/Under devigged per player and line)
+			PropsMarkets: []string{
+				"player_pass_yds",
+				"player_pass_tds",
+				"player_pass_attempts",
+				"player_pass_completions",
+				"player_pass_interceptions",
+				"player_rush_yds",
+				"player_rush_attempts",
+				"player_receptions",
+				"player_reception_yds",
+				"player_anytime_td",
+				"player_kicking_points",
+			},
+
+			// Vig removal methods: shin on the moneyline, as for the NBA (see
+			// pricing.DefaultVigMethods)
+			VigMethods: pricing.DefaultVigMethods(),
+			MarketVigMethods: map[string]models.VigMethod{
+				"h2h": models.VigMethodShin,
+			},
+		},
+
+		// Key numbers
+		KeyNumberMarkets: []string{
+			"spreads",
+			"alternate_spreads",
+		},
+
+		// Per-side probability of landing on each final margin for games priced
+		// near it (approximate, from historical NFL results)
+		MarginDistribution: oddsmath.MarginDistribution{
+			Landing: map[int]float64{
+				0:  0.002, // tie
+				1:  0.030,
+				2:  0.025,
+				3:  0.095, // key
+				4:  0.035,
+				5:  0.025,
+				6:  0.035,
+				7:  0.060, // key
+				8:  0.025,
+				9:  0.015,
+				10: 0.040, // key
+				11: 0.020,
+				12: 0.012,
+				13: 0.015,
+				14: 0.030,
+				15: 0.010,
+				16: 0.015,
+				17: 0.020,
+				18: 0.010,
+				20: 0.012,
+				21: 0.015,
+			},
+			Default: 0.008,
+		},
+
+		// Edge thresholds
+		MinEdgeForAlert: 0.015, // 1.5% minimum for alerts
+		SignificantEdge: 0.03,  // 3%+ is significant
+	}
+}
+
+// GetMarginDistribution returns the final margin distribution
+func (c *Config) GetMarginDistribution() oddsmath.MarginDistribution {
+	return c.MarginDistribution
+}
+
+// IsKeyNumberMarket checks if a market's points can be translated across key numbers
+func (c *Config) IsKeyNumberMarket(marketKey string) bool {
+	for _, m := range c.KeyNumberMarkets {
+		if m == marketKey {
+			return true
+		}
+	}
+	return false
+}
+
+// AdjustSpreadProbability moves a fair spread probability from one point to another
+// Implements pricing.PointAdjuster; totals and other markets are not translated
+func (c *Config) AdjustSpreadProbability(marketKey string, fairProb, fromPoint, toPoint float64) (float64, bool) {
+	if !c.IsKeyNumberMarket(marketKey) {
+		return 0, false
+	}
+
+	adjusted, err := oddsmath.AdjustSpreadProbability(fairProb, fromPoint, toPoint, c.MarginDistribution)
+	if err != nil {
+		return 0, false
+	}
+	return adjusted, true
+}
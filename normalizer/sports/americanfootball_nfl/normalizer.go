@@ -0,0 +1,70 @@
+package americanfootball_nfl
+
+import (
+	"context"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/oddsmath"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/pricing"
+)
+
+// Normalizer implements SportNormalizer for NFL Football
+type Normalizer struct {
+	config *Config
+	pricer *pricing.Pricer
+}
+
+// NewNormalizer creates a new NFL normalizer
+func NewNormalizer() *Normalizer {
+	return NewNormalizerWithConfig(DefaultConfig())
+}
+
+// NewNormalizerWithConfig creates an NFL normalizer with a custom configuration
+// Sharp spreads at a different point are translated across key numbers before
+// they enter the consensus
+func NewNormalizerWithConfig(config *Config) *Normalizer {
+	return &Normalizer{
+		config: config,
+		pricer: pricing.NewPricerWithAdjuster(config, config.AdjustSpreadProbability),
+	}
+}
+
+// GetSportKey returns the sport identifier
+func (n *Normalizer) GetSportKey() string {
+	return n.config.SportKey
+}
+
+// GetDisplayName returns the human-readable name
+func (n *Normalizer) GetDisplayName() string {
+	return n.config.DisplayName
+}
+
+// GetMarketType classifies the market
+func (n *Normalizer) GetMarketType(marketKey string) models.MarketType {
+	return n.config.GetMarketType(marketKey)
+}
+
+// GetMarginDistribution returns the final margin distribution used for cross-market checks
+func (n *Normalizer) GetMarginDistribution() oddsmath.MarginDistribution {
+	return n.config.GetMarginDistribution()
+}
+
+// GetVigMethod returns the vig removal method for this market type
+func (n *Normalizer) GetVigMethod(marketType models.MarketType) models.VigMethod {
+	return n.config.GetVigMethod(marketType)
+}
+
+// GetSharpBooks returns the sharp book list
+func (n *Normalizer) GetSharpBooks() []string {
+	return n.config.SharpBooks
+}
+
+// IsSharpBook checks if a book is sharp
+func (n *Normalizer) IsSharpBook(bookKey string) bool {
+	return n.config.IsSharpBook(bookKey)
+}
+
+// Normalize processes raw odds and returns normalized odds with fair prices and edges
+func (n *Normalizer) Normalize(ctx context.Context, raw models.RawOdds, marketOdds []models.RawOdds) (*models.NormalizedOdds, error) {
+	return n.pricer.Normalize(raw, marketOdds)
+}
@@ -1,19 +1,25 @@
 package basketball_nba
 
-import "github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+import (
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/oddsmath"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/pricing"
+)
 
 // Config contains NBA-specific normalization configuration
 type Config struct {
 	SportKey    string
 	DisplayName string
 
-	// Sharp books for consensus calculation (ordered by reliability)
-	SharpBooks []string
+	// Sharp books, consensus weighting, market classification and vig methods
+	// (two-way: spreads, totals; three-way: h2h, though NBA rarely has draws)
+	pricing.MarketRules
 
-	// Market type classification
-	TwoWayMarkets   []string // spreads, totals
-	ThreeWayMarkets []string // h2h (though NBA rarely has draws)
-	PropsMarkets    []string // player props
+	// Final margin distribution, used to convert the moneyline into an implied spread
+	// (NBA margins are spread out with no dominant key numbers)
+	MarginDistribution oddsmath.MarginDistribution
 
 	// Edge thresholds
 	MinEdgeForAlert float64 // Minimum edge to generate alert (e.g., 0.02 = 2%)
@@ -26,43 +32,78 @@ func DefaultConfig() *Config {
 		SportKey:    "basketball_nba",
 		DisplayName: "NBA Basketball",
 
-		// Sharp books (in order of reliability)
-		// Pinnacle: Lowest margins, fastest to move, accepts high limits
-		// Circa: Vegas sharp book, accepts large bets
-		// Bookmaker: Sharp offshore book
-		SharpBooks: []string{
-			"pinnacle",
-			"circa",
-			"bookmaker",
-		},
-
-		// Two-way markets (use multiplicative vig removal)
-		TwoWayMarkets: []string{
-			"spreads",
-			"totals",
-		},
-
-		// Three-way markets (use additive vig removal if needed)
-		// NBA rarely has draws, but h2h is technically three-way in some books
-		ThreeWayMarkets: []string{
-			"h2h", // moneyline
+		MarketRules: pricing.MarketRules{
+			// Sharp books (in order of reliability)
+			// Pinnacle: Lowest margins, fastest to move, accepts high limits
+			// Circa: Vegas sharp book, accepts large bets
+			// Bookmaker: Sharp offshore book
+			SharpBooks: []string{
+				"pinnacle",
+				"circa",
+				"bookmaker",
+			},
+
+			// Pinnacle sets the market; Circa and Bookmaker lag and carry more noise
+			SharpBookWeights: map[string]float64{
+				"pinnacle":  1.0,
+				"circa":     0.8,
+				"bookmaker": 0.7,
+			},
+			ConsensusHalfLife: 60 * time.Second,
+			MaxSharpQuoteAge:  10 * time.Minute,
+
+			// Two-way markets (use multiplicative vig removal)
+			TwoWayMarkets: []string{
+				"spreads",
+				"totals",
+			},
+
+			// Three-way markets
+			// NBA rarely has draws, but h2h is technically three-way in some books
+			ThreeWayMarkets: []string{
+				"h2h", // moneyline
+			},
+
+			// Player props (Over/Under devigged per player and line)
+			PropsMarkets: []string{
+				"player_points",
+				"player_rebounds",
+				"player_assists",
+				"player_threes",
+				"player_points_rebounds_assists",
+				"player_points_rebounds",
+				"player_points_assists",
+				"player_rebounds_assists",
+				"player_steals",
+				"player_blocks",
+				"player_turnovers",
+				"player_double_double",
+				"player_triple_double",
+			},
+
+			// Vig removal methods: shin on the moneyline, whose favorites and
+			// longshots multiplicative and additive devig would both misprice
+			VigMethods: pricing.DefaultVigMethods(),
+			MarketVigMethods: map[string]models.VigMethod{
+				"h2h": models.VigMethodShin,
+			},
 		},
 
-		// Player props (compare across books, no vig removal needed)
-		PropsMarkets: []string{
-			"player_points",
-			"player_rebounds",
-			"player_assists",
-			"player_threes",
-			"player_points_rebounds_assists",
-			"player_points_rebounds",
-			"player_points_assists",
-			"player_rebounds_assists",
-			"player_steals",
-			"player_blocks",
-			"player_turnovers",
-			"player_double_double",
-			"player_triple_double",
+		// Per-side probability of landing on each final margin for games priced
+		// near it (approximate, from historical NBA results; overtime means no ties)
+		MarginDistribution: oddsmath.MarginDistribution{
+			Landing: map[int]float64{
+				0: 0,
+				1: 0.030,
+				2: 0.035,
+				3: 0.040,
+				4: 0.035,
+				5: 0.040,
+				6: 0.038,
+				7: 0.040,
+				8: 0.035,
+			},
+			Default: 0.030,
 		},
 
 		// Edge thresholds
@@ -71,40 +112,7 @@ func DefaultConfig() *Config {
 	}
 }
 
-// GetMarketType returns the market type for a given market key
-func (c *Config) GetMarketType(marketKey string) models.MarketType {
-	// Check two-way markets
-	for _, m := range c.TwoWayMarkets {
-		if m == marketKey {
-			return models.MarketTypeTwoWay
-		}
-	}
-
-	// Check three-way markets
-	for _, m := range c.ThreeWayMarkets {
-		if m == marketKey {
-			return models.MarketTypeThreeWay
-		}
-	}
-
-	// Check props
-	for _, m := range c.PropsMarkets {
-		if m == marketKey {
-			return models.MarketTypeProps
-		}
-	}
-
-	// Default to props if unknown
-	return models.MarketTypeProps
+// GetMarginDistribution returns the final margin distribution
+func (c *Config) GetMarginDistribution() oddsmath.MarginDistribution {
+	return c.MarginDistribution
 }
-
-// IsSharpBook checks if a book is in the sharp list
-func (c *Config) IsSharpBook(bookKey string) bool {
-	for _, sharp := range c.SharpBooks {
-		if sharp == bookKey {
-			return true
-		}
-	}
-	return false
-}
-
@@ -0,0 +1,298 @@
+package generic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/pricing"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is a sport normalization configuration loaded from a JSON or YAML file
+// The file layout is described by fileConfig; market rules end up in the same
+// pricing.MarketRules the built-in sports embed.
+type Config struct {
+	SportKey    string
+	DisplayName string
+
+	// Sharp books, consensus weighting, market classification and vig methods
+	pricing.MarketRules
+
+	// Edge thresholds
+	MinEdgeForAlert float64
+	SignificantEdge float64
+}
+
+// fileConfig is the layout of a sport config file
+//
+// Example (icehockey_nhl.json):
+//
+//	{
+//	  "sport_key": "icehockey_nhl",
+//	  "display_name": "NHL Hockey",
+//	  "sharp_books": [{"key": "pinnacle", "weight": 1.0}, {"key": "circa", "weight": 0.8}],
+//	  "consensus_half_life": "60s",
+//	  "max_sharp_quote_age": "10m",
+//	  "vig_methods": {"two_way": "multiplicative", "three_way": "shin"},
+//	  "markets": {
+//	    "h2h": {"type": "three_way"},
+//	    "spreads": {"type": "two_way"},
+//	    "player_points": {"type": "props", "vig_method": "power"}
+//	  },
+//	  "min_edge_for_alert": 0.015,
+//	  "significant_edge": 0.03
+//	}
+type fileConfig struct {
+	SportKey    string `json:"sport_key"`
+	DisplayName string `json:"display_name"`
+
+	// Sharp books for consensus calculation with reliability weights
+	SharpBooks        []SharpBook `json:"sharp_books"`
+	ConsensusHalfLife Duration    `json:"consensus_half_life"`
+	MaxSharpQuoteAge  Duration    `json:"max_sharp_quote_age"`
+
+	// Vig removal method by market type, overridden per market in Markets
+	VigMethods map[models.MarketType]models.VigMethod `json:"vig_methods"`
+
+	// Market key → type (and optional vig method); unknown markets are props
+	Markets map[string]Market `json:"markets"`
+
+	// Edge thresholds
+	MinEdgeForAlert float64 `json:"min_edge_for_alert"`
+	SignificantEdge float64 `json:"significant_edge"`
+}
+
+// SharpBook is a sharp book and its consensus weight
+type SharpBook struct {
+	Key    string  `json:"key"`
+	Weight float64 `json:"weight"` // Defaults to 1.0 when omitted
+}
+
+// Market classifies a single market key
+type Market struct {
+	Type      models.MarketType `json:"type"`
+	VigMethod models.VigMethod  `json:"vig_method,omitempty"` // Overrides VigMethods for this market
+}
+
+// Duration accepts "90s"-style strings or a number of seconds in JSON
+type Duration struct {
+	time.Duration
+}
+
+// UnmarshalJSON parses a duration string or seconds
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		parsed, err := time.ParseDuration(text)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q: %w", text, err)
+		}
+		d.Duration = parsed
+		return nil
+	}
+
+	seconds, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return fmt.Errorf("invalid duration %s", data)
+	}
+	d.Duration = time.Duration(seconds * float64(time.Second))
+	return nil
+}
+
+// MarshalJSON writes the duration as a string
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// LoadConfig reads and validates a sport configuration file (.json, .yaml or .yml)
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var config *Config
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		config, err = ParseConfig(data)
+	case ".yaml", ".yml":
+		config, err = ParseYAMLConfig(data)
+	default:
+		return nil, fmt.Errorf("%s: unsupported sport config extension %q (want .json, .yaml or .yml)", path, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return config, nil
+}
+
+// IsConfigFile reports whether path has a sport config extension
+func IsConfigFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseConfig decodes and validates a JSON sport configuration
+// Unknown fields are rejected so typos don't silently fall back to defaults
+func ParseConfig(data []byte) (*Config, error) {
+	file := &fileConfig{
+		ConsensusHalfLife: Duration{60 * time.Second},
+		MaxSharpQuoteAge:  Duration{10 * time.Minute},
+		VigMethods:        pricing.DefaultVigMethods(),
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(file); err != nil {
+		return nil, fmt.Errorf("invalid sport config: %w", err)
+	}
+
+	if err := file.validate(); err != nil {
+		return nil, err
+	}
+
+	return file.config(), nil
+}
+
+// ParseYAMLConfig decodes and validates a YAML sport configuration
+// The document is re-encoded as JSON so both formats share one schema and its checks.
+func ParseYAMLConfig(data []byte) (*Config, error) {
+	var document interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("invalid sport config: %w", err)
+	}
+
+	encoded, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sport config: %w", err)
+	}
+
+	return ParseConfig(encoded)
+}
+
+// validate checks the configuration is usable
+func (c *fileConfig) validate() error {
+	if c.SportKey == "" {
+		return fmt.Errorf("sport_key is required")
+	}
+	if c.DisplayName == "" {
+		return fmt.Errorf("display_name is required")
+	}
+	if len(c.SharpBooks) == 0 {
+		return fmt.Errorf("at least one sharp book is required")
+	}
+
+	seen := make(map[string]bool)
+	for _, book := range c.SharpBooks {
+		if book.Key == "" {
+			return fmt.Errorf("sharp book key is required")
+		}
+		if seen[book.Key] {
+			return fmt.Errorf("duplicate sharp book %s", book.Key)
+		}
+		if book.Weight < 0 {
+			return fmt.Errorf("sharp book %s weight must not be negative", book.Key)
+		}
+		seen[book.Key] = true
+	}
+
+	for marketType, method := range c.VigMethods {
+		if err := validateMarketType(marketType); err != nil {
+			return err
+		}
+		if err := pricing.ValidateVigMethod(method); err != nil {
+			return fmt.Errorf("vig_methods[%s]: %w", marketType, err)
+		}
+	}
+
+	for marketKey, market := range c.Markets {
+		if err := validateMarketType(market.Type); err != nil {
+			return fmt.Errorf("markets[%s]: %w", marketKey, err)
+		}
+		if market.VigMethod != "" {
+			if err := pricing.ValidateVigMethod(market.VigMethod); err != nil {
+				return fmt.Errorf("markets[%s]: %w", marketKey, err)
+			}
+		}
+	}
+
+	if c.ConsensusHalfLife.Duration < 0 || c.MaxSharpQuoteAge.Duration < 0 {
+		return fmt.Errorf("consensus durations must not be negative")
+	}
+
+	return nil
+}
+
+// config converts a validated file into market rules
+func (c *fileConfig) config() *Config {
+	rules := pricing.MarketRules{
+		SharpBooks:        make([]string, 0, len(c.SharpBooks)),
+		SharpBookWeights:  make(map[string]float64),
+		ConsensusHalfLife: c.ConsensusHalfLife.Duration,
+		MaxSharpQuoteAge:  c.MaxSharpQuoteAge.Duration,
+		VigMethods:        c.VigMethods,
+		MarketVigMethods:  make(map[string]models.VigMethod),
+	}
+
+	for _, book := range c.SharpBooks {
+		rules.SharpBooks = append(rules.SharpBooks, book.Key)
+		if book.Weight > 0 {
+			rules.SharpBookWeights[book.Key] = book.Weight
+		}
+	}
+
+	// Sorted so the market lists don't depend on map order
+	marketKeys := make([]string, 0, len(c.Markets))
+	for marketKey := range c.Markets {
+		marketKeys = append(marketKeys, marketKey)
+	}
+	sort.Strings(marketKeys)
+
+	for _, marketKey := range marketKeys {
+		market := c.Markets[marketKey]
+		switch market.Type {
+		case models.MarketTypeTwoWay:
+			rules.TwoWayMarkets = append(rules.TwoWayMarkets, marketKey)
+		case models.MarketTypeThreeWay:
+			rules.ThreeWayMarkets = append(rules.ThreeWayMarkets, marketKey)
+		case models.MarketTypeDoubleChance:
+			rules.DoubleChanceMarkets = append(rules.DoubleChanceMarkets, marketKey)
+		case models.MarketTypeProps:
+			rules.PropsMarkets = append(rules.PropsMarkets, marketKey)
+		}
+		if market.VigMethod != "" {
+			rules.MarketVigMethods[marketKey] = market.VigMethod
+		}
+	}
+
+	return &Config{
+		SportKey:        c.SportKey,
+		DisplayName:     c.DisplayName,
+		MarketRules:     rules,
+		MinEdgeForAlert: c.MinEdgeForAlert,
+		SignificantEdge: c.SignificantEdge,
+	}
+}
+
+// validateMarketType checks a market type is one the pricer understands
+func validateMarketType(marketType models.MarketType) error {
+	switch marketType {
+	case models.MarketTypeTwoWay, models.MarketTypeThreeWay, models.MarketTypeDoubleChance, models.MarketTypeProps:
+		return nil
+	default:
+		return fmt.Errorf("unknown market type %q", marketType)
+	}
+}
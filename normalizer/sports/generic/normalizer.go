@@ -0,0 +1,59 @@
+package generic
+
+import (
+	"context"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/pricing"
+)
+
+// Normalizer implements SportNormalizer from a file-based configuration
+// Used for sports that need no custom math (NHL, NCAAB, ...); sports with key
+// numbers or handicaps keep their own package
+type Normalizer struct {
+	config *Config
+	pricer *pricing.Pricer
+}
+
+// NewNormalizer creates a generic normalizer from a validated configuration
+func NewNormalizer(config *Config) *Normalizer {
+	return &Normalizer{
+		config: config,
+		pricer: pricing.NewPricer(config),
+	}
+}
+
+// GetSportKey returns the sport identifier
+func (n *Normalizer) GetSportKey() string {
+	return n.config.SportKey
+}
+
+// GetDisplayName returns the human-readable name
+func (n *Normalizer) GetDisplayName() string {
+	return n.config.DisplayName
+}
+
+// GetMarketType classifies the market
+func (n *Normalizer) GetMarketType(marketKey string) models.MarketType {
+	return n.config.GetMarketType(marketKey)
+}
+
+// GetVigMethod returns the vig removal method for this market type
+func (n *Normalizer) GetVigMethod(marketType models.MarketType) models.VigMethod {
+	return n.config.GetVigMethod(marketType)
+}
+
+// GetSharpBooks returns the sharp book list
+func (n *Normalizer) GetSharpBooks() []string {
+	return n.config.SharpBooks
+}
+
+// IsSharpBook checks if a book is sharp
+func (n *Normalizer) IsSharpBook(bookKey string) bool {
+	return n.config.IsSharpBook(bookKey)
+}
+
+// Normalize processes raw odds and returns normalized odds with fair prices and edges
+func (n *Normalizer) Normalize(ctx context.Context, raw models.RawOdds, marketOdds []models.RawOdds) (*models.NormalizedOdds, error) {
+	return n.pricer.Normalize(raw, marketOdds)
+}
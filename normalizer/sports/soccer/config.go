@@ -0,0 +1,159 @@
+package soccer
+
+import (
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/oddsmath"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/pricing"
+)
+
+// Config contains soccer normalization configuration for one league
+type Config struct {
+	SportKey    string
+	DisplayName string
+
+	// Sharp books, consensus weighting, market classification and vig methods
+	// (two-way: Asian handicap, totals, draw no bet, btts; three-way: h2h Home /
+	// Draw / Away; double chance: 1X / 12 / X2)
+	pricing.MarketRules
+
+	// Asian handicap: markets whose lines (including quarter lines) can be
+	// translated, and the league's expected goals per match for the model
+	AsianHandicapMarkets []string
+	ExpectedGoals        float64
+
+	// Edge thresholds
+	MinEdgeForAlert float64 // Minimum edge to generate alert (e.g., 0.02 = 2%)
+	SignificantEdge float64 // Edge considered significant (e.g., 0.05 = 5%)
+}
+
+// EPLConfig returns the English Premier League normalization configuration
+func EPLConfig() *Config {
+	config := defaultConfig("soccer_epl", "English Premier League")
+
+	// Betfair exchange is the deepest EPL market after Pinnacle
+	config.SharpBooks = []string{"pinnacle", "betfair_ex_uk", "matchbook"}
+	config.SharpBookWeights = map[string]float64{
+		"pinnacle":      1.0,
+		"betfair_ex_uk": 0.9,
+		"matchbook":     0.7,
+	}
+	config.ExpectedGoals = 2.8
+
+	return config
+}
+
+// MLSConfig returns the Major League Soccer normalization configuration
+func MLSConfig() *Config {
+	config := defaultConfig("soccer_usa_mls", "Major League Soccer")
+
+	// Thinner exchange liquidity; Pinnacle leads and Circa follows
+	config.SharpBooks = []string{"pinnacle", "circa", "betfair_ex_uk"}
+	config.SharpBookWeights = map[string]float64{
+		"pinnacle":      1.0,
+		"circa":         0.7,
+		"betfair_ex_uk": 0.6,
+	}
+	config.ExpectedGoals = 3.0
+
+	return config
+}
+
+// defaultConfig returns the settings shared by every soccer league
+func defaultConfig(sportKey, displayName string) *Config {
+	return &Config{
+		SportKey:    sportKey,
+		DisplayName: displayName,
+
+		MarketRules: pricing.MarketRules{
+			ConsensusHalfLife: 90 * time.Second,
+			MaxSharpQuoteAge:  10 * time.Minute,
+
+			// Two-way markets
+			TwoWayMarkets: []string{
+				"spreads", // Asian handicap
+				"alternate_spreads",
+				"totals",
+				"alternate_totals",
+				"draw_no_bet",
+				"btts",
+			},
+
+			// Three-way markets (Home / Draw / Away)
+			ThreeWayMarkets: []string{
+				"h2h",
+			},
+
+			// Double chance
+			DoubleChanceMarkets: []string{
+				"double_chance",
+			},
+
+			// Player props
+			PropsMarkets: []string{
+				"player_goal_scorer_anytime",
+				"player_shots_on_target",
+				"player_shots",
+				"player_assists",
+			},
+
+			// Vig removal methods
+			// Shin on three-way: draws and away longshots carry most of the margin,
+			// and additive can push a big longshot negative
+			VigMethods: map[models.MarketType]models.VigMethod{
+				models.MarketTypeTwoWay:       models.VigMethodMultiplicative,
+				models.MarketTypeThreeWay:     models.VigMethodShin,
+				models.MarketTypeDoubleChance: models.VigMethodShin,
+				models.MarketTypeProps:        models.VigMethodMultiplicative,
+			},
+			MarketVigMethods: map[string]models.VigMethod{},
+		},
+
+		// Asian handicap
+		AsianHandicapMarkets: []string{
+			"spreads",
+			"alternate_spreads",
+		},
+		ExpectedGoals: 2.7,
+
+		// Edge thresholds
+		MinEdgeForAlert: 0.015, // 1.5% minimum for alerts
+		SignificantEdge: 0.03,  // 3%+ is significant
+	}
+}
+
+// GetOutcomeCount returns how many outcomes a complete market has
+// Soccer h2h is only devigged when Home, Draw and Away are all priced
+func (c *Config) GetOutcomeCount(marketKey string) int {
+	switch c.GetMarketType(marketKey) {
+	case models.MarketTypeThreeWay, models.MarketTypeDoubleChance:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// IsAsianHandicapMarket checks if a market's lines can be translated
+func (c *Config) IsAsianHandicapMarket(marketKey string) bool {
+	for _, m := range c.AsianHandicapMarkets {
+		if m == marketKey {
+			return true
+		}
+	}
+	return false
+}
+
+// AdjustHandicapProbability moves a fair Asian handicap probability between lines
+// Implements pricing.PointAdjuster; quarter lines are priced as split stakes
+func (c *Config) AdjustHandicapProbability(marketKey string, fairProb, fromPoint, toPoint float64) (float64, bool) {
+	if !c.IsAsianHandicapMarket(marketKey) {
+		return 0, false
+	}
+
+	adjusted, err := oddsmath.AdjustAsianHandicapProbability(fairProb, fromPoint, toPoint, c.ExpectedGoals)
+	if err != nil {
+		return 0, false
+	}
+	return adjusted, true
+}
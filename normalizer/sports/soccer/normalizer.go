@@ -0,0 +1,75 @@
+package soccer
+
+import (
+	"context"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/pricing"
+)
+
+// Normalizer implements SportNormalizer for a soccer league
+// One instance is registered per league (EPL, MLS) since each has its own sport key
+type Normalizer struct {
+	config *Config
+	pricer *pricing.Pricer
+}
+
+// NewEPLNormalizer creates a new English Premier League normalizer
+func NewEPLNormalizer() *Normalizer {
+	return NewNormalizerWithConfig(EPLConfig())
+}
+
+// NewMLSNormalizer creates a new Major League Soccer normalizer
+func NewMLSNormalizer() *Normalizer {
+	return NewNormalizerWithConfig(MLSConfig())
+}
+
+// NewNormalizerWithConfig creates a soccer normalizer with a custom configuration
+// Sharp Asian handicaps at a different line are translated before they enter
+// the consensus
+func NewNormalizerWithConfig(config *Config) *Normalizer {
+	return &Normalizer{
+		config: config,
+		pricer: pricing.NewPricerWithAdjuster(config, config.AdjustHandicapProbability),
+	}
+}
+
+// GetSportKey returns the sport identifier
+func (n *Normalizer) GetSportKey() string {
+	return n.config.SportKey
+}
+
+// GetDisplayName returns the human-readable name
+func (n *Normalizer) GetDisplayName() string {
+	return n.config.DisplayName
+}
+
+// GetMarketType classifies the market
+func (n *Normalizer) GetMarketType(marketKey string) models.MarketType {
+	return n.config.GetMarketType(marketKey)
+}
+
+// GetOutcomeCount returns how many outcomes a complete market has (0 = any)
+func (n *Normalizer) GetOutcomeCount(marketKey string) int {
+	return n.config.GetOutcomeCount(marketKey)
+}
+
+// GetVigMethod returns the vig removal method for this market type
+func (n *Normalizer) GetVigMethod(marketType models.MarketType) models.VigMethod {
+	return n.config.GetVigMethod(marketType)
+}
+
+// GetSharpBooks returns the sharp book list
+func (n *Normalizer) GetSharpBooks() []string {
+	return n.config.SharpBooks
+}
+
+// IsSharpBook checks if a book is sharp
+func (n *Normalizer) IsSharpBook(bookKey string) bool {
+	return n.config.IsSharpBook(bookKey)
+}
+
+// Normalize processes raw odds and returns normalized odds with fair prices and edges
+func (n *Normalizer) Normalize(ctx context.Context, raw models.RawOdds, marketOdds []models.RawOdds) (*models.NormalizedOdds, error) {
+	return n.pricer.Normalize(raw, marketOdds)
+}
@@ -0,0 +1,67 @@
+// +build integration
+
+package integration_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/deadletter"
+)
+
+func TestDeadLetterRedrive_ExplicitIDsBeyondLimit(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	redisClient := getTestRedisClient(t)
+	queue := deadletter.NewQueue(redisClient, 0)
+
+	for i := 0; i < 5; i++ {
+		if err := queue.Write(ctx, deadletter.Entry{
+			SportKey:     "basketball_nba",
+			SourceStream: "odds.raw.basketball_nba",
+			SourceID:     fmt.Sprintf("1700000000000-%d", i),
+			ErrorClass:   deadletter.ClassNormalization,
+			Payload:      fmt.Sprintf(`{"event_id":"event-%d"}`, i),
+			FailedAt:     time.Now(),
+		}); err != nil {
+			t.Fatalf("failed to write dead letter: %v", err)
+		}
+	}
+
+	entries, err := queue.List(ctx, "basketball_nba", 10)
+	if err != nil {
+		t.Fatalf("failed to list dead letters: %v", err)
+	}
+	last := entries[len(entries)-1].ID
+
+	// The last entry sits outside a limit of 1 but is still re-driven by ID
+	redriven, err := queue.Redrive(ctx, "basketball_nba", 1, last)
+	if err != nil {
+		t.Fatalf("Redrive() error = %v", err)
+	}
+	if redriven != 1 {
+		t.Errorf("re-drove %d entries, want 1", redriven)
+	}
+
+	if count, _ := queue.Count(ctx, "basketball_nba"); count != 4 {
+		t.Errorf("dead letters left = %d, want 4", count)
+	}
+	if length, _ := redisClient.XLen(ctx, "odds.raw.basketball_nba").Result(); length != 1 {
+		t.Errorf("source stream length = %d, want 1", length)
+	}
+
+	// An unknown ID fails without re-driving the known one
+	if _, err := queue.Redrive(ctx, "basketball_nba", 1, entries[0].ID, "1-0"); err == nil {
+		t.Error("expected error for missing dead letter ID")
+	}
+	if count, _ := queue.Count(ctx, "basketball_nba"); count != 4 {
+		t.Errorf("dead letters left = %d after failed re-drive, want 4", count)
+	}
+}
@@ -0,0 +1,154 @@
+// +build integration
+
+package integration_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/consumer"
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/deadletter"
+	"github.com/XavierBriggs/fortuna/services/normalizer/tests/testutil"
+	"github.com/redis/go-redis/v9"
+)
+
+// deliverWithoutAck reads every new entry as a consumer that "crashes" before acking
+func deliverWithoutAck(t *testing.T, ctx context.Context, client *redis.Client, streamKey, group, consumerID string) {
+	t.Helper()
+
+	if err := client.XGroupCreateMkStream(ctx, streamKey, group, "0").Err(); err != nil {
+		t.Fatalf("failed to create group: %v", err)
+	}
+	if err := client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    group,
+		Consumer: consumerID,
+		Streams:  []string{streamKey, ">"},
+		Count:    100,
+	}).Err(); err != nil {
+		t.Fatalf("failed to read as crashed consumer: %v", err)
+	}
+}
+
+func TestPendingRecovery_ClaimsCrashedConsumerEntries(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	redisClient := getTestRedisClient(t)
+	streamKey := "odds.raw.basketball_nba"
+
+	oddsJSON, _ := json.Marshal(testutil.SpreadOdds("fanduel", "Los Angeles Lakers", -110, -7.5))
+	if err := redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: streamKey,
+		Values: map[string]interface{}{"data": string(oddsJSON)},
+	}).Err(); err != nil {
+		t.Fatalf("failed to add message: %v", err)
+	}
+	deliverWithoutAck(t, ctx, redisClient, streamKey, "recovery-group", "crashed-consumer")
+
+	recovery := consumer.RecoveryConfig{
+		ClaimInterval: time.Hour, // Only the startup scan
+		MinIdle:       10 * time.Millisecond,
+		MaxDeliveries: 5,
+		BatchSize:     100,
+	}
+	time.Sleep(2 * recovery.MinIdle)
+
+	streamConsumer := consumer.NewStreamConsumerWithRecovery(redisClient, "recovering-consumer", "recovery-group",
+		recovery, deadletter.NewQueue(redisClient, 0))
+	messageCh, _ := streamConsumer.ConsumeStream(ctx, streamKey)
+
+	select {
+	case msg := <-messageCh:
+		if msg.RawOdds.BookKey != "fanduel" {
+			t.Errorf("expected reclaimed fanduel odds, got %s", msg.RawOdds.BookKey)
+		}
+		if err := streamConsumer.AckMessage(ctx, streamKey, msg.ID); err != nil {
+			t.Fatalf("failed to ack: %v", err)
+		}
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for reclaimed message")
+	}
+
+	stats, err := streamConsumer.PendingStats(ctx, streamKey)
+	if err != nil {
+		t.Fatalf("failed to read pending stats: %v", err)
+	}
+	if stats.Claimed != 1 {
+		t.Errorf("expected 1 claimed entry, got %d", stats.Claimed)
+	}
+	if stats.Pending != 0 {
+		t.Errorf("expected no pending entries after ack, got %d", stats.Pending)
+	}
+}
+
+func TestPendingRecovery_DeadLettersPoisonEntries(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	redisClient := getTestRedisClient(t)
+	streamKey := "odds.raw.basketball_nba"
+
+	if err := redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: streamKey,
+		Values: map[string]interface{}{"data": `{"event_id":"poison"}`},
+	}).Err(); err != nil {
+		t.Fatalf("failed to add message: %v", err)
+	}
+	deliverWithoutAck(t, ctx, redisClient, streamKey, "poison-group", "crashed-consumer")
+
+	// One delivery already happened; the claim makes it two, over the cap of one
+	recovery := consumer.RecoveryConfig{
+		ClaimInterval: time.Hour,
+		MinIdle:       10 * time.Millisecond,
+		MaxDeliveries: 1,
+		BatchSize:     100,
+	}
+	time.Sleep(2 * recovery.MinIdle)
+
+	queue := deadletter.NewQueue(redisClient, 0)
+	streamConsumer := consumer.NewStreamConsumerWithRecovery(redisClient, "recovering-consumer", "poison-group", recovery, queue)
+	consumeCtx, stop := context.WithCancel(ctx)
+	streamConsumer.ConsumeStream(consumeCtx, streamKey)
+
+	deadline := time.Now().Add(5 * time.Second)
+	var entries []deadletter.Entry
+	for time.Now().Before(deadline) {
+		var err error
+		if entries, err = queue.List(ctx, "basketball_nba", 10); err != nil {
+			t.Fatalf("failed to list dead letters: %v", err)
+		}
+		if len(entries) > 0 {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	stop()
+
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 dead letter, got %d", len(entries))
+	}
+	if entries[0].ErrorClass != deadletter.ClassMaxDeliveries {
+		t.Errorf("expected class %s, got %s", deadletter.ClassMaxDeliveries, entries[0].ErrorClass)
+	}
+	if entries[0].Payload != `{"event_id":"poison"}` {
+		t.Errorf("payload not preserved: %s", entries[0].Payload)
+	}
+
+	stats, err := streamConsumer.PendingStats(ctx, streamKey)
+	if err != nil {
+		t.Fatalf("failed to read pending stats: %v", err)
+	}
+	if stats.DeadLettered != 1 || stats.Pending != 0 {
+		t.Errorf("expected dead_lettered=1 pending=0, got %+v", stats)
+	}
+}
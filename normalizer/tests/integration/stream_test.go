@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/XavierBriggs/fortuna/services/normalizer/internal/consumer"
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/marketstate"
 	"github.com/XavierBriggs/fortuna/services/normalizer/internal/processor"
 	"github.com/XavierBriggs/fortuna/services/normalizer/internal/publisher"
 	"github.com/XavierBriggs/fortuna/services/normalizer/internal/registry"
@@ -66,7 +67,7 @@ func TestStreamProcessing_EndToEnd(t *testing.T) {
 	// Setup components
 	streamConsumer := consumer.NewStreamConsumer(redisClient, "test-consumer", "test-group")
 	streamPublisher := publisher.NewStreamPublisher(redisClient)
-	proc := processor.NewProcessor(streamConsumer, streamPublisher, normalizerRegistry)
+	proc := processor.NewProcessor(streamConsumer, streamPublisher, normalizerRegistry, marketstate.NewStore(marketstate.DefaultConfig()), processor.Options{})
 
 	// Start processor in background
 	go func() {
@@ -184,7 +185,7 @@ func TestStreamProcessing_LatencySLO(t *testing.T) {
 
 	streamConsumer := consumer.NewStreamConsumer(redisClient, "latency-test", "latency-group")
 	streamPublisher := publisher.NewStreamPublisher(redisClient)
-	proc := processor.NewProcessor(streamConsumer, streamPublisher, normalizerRegistry)
+	proc := processor.NewProcessor(streamConsumer, streamPublisher, normalizerRegistry, marketstate.NewStore(marketstate.DefaultConfig()), processor.Options{})
 
 	// Start processor
 	go proc.Start(ctx)
@@ -256,7 +257,7 @@ func TestStreamProcessing_SharpConsensus(t *testing.T) {
 
 	streamConsumer := consumer.NewStreamConsumer(redisClient, "consensus-test", "consensus-group")
 	streamPublisher := publisher.NewStreamPublisher(redisClient)
-	proc := processor.NewProcessor(streamConsumer, streamPublisher, normalizerRegistry)
+	proc := processor.NewProcessor(streamConsumer, streamPublisher, normalizerRegistry, marketstate.NewStore(marketstate.DefaultConfig()), processor.Options{})
 
 	go proc.Start(ctx)
 	time.Sleep(500 * time.Millisecond)
@@ -90,6 +90,18 @@ func PropOdds(bookKey string, outcome string, price int, point float64) models.R
 	})
 }
 
+// PlayerPropOdds creates one side of a player prop with the player in description
+func PlayerPropOdds(bookKey string, player string, side string, price int, point float64) models.RawOdds {
+	return RawOddsFixture(func(o *models.RawOdds) {
+		o.MarketKey = "player_points"
+		o.BookKey = bookKey
+		o.OutcomeName = side
+		o.Description = player
+		o.Price = price
+		o.Point = &point
+	})
+}
+
 // StandardVigMarket creates a standard two-way market with -110/-110 pricing
 func StandardVigMarket() (side1, side2 models.RawOdds) {
 	point1 := -7.5
@@ -0,0 +1,200 @@
+package crossmarket_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/crossmarket"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/normalizer/sports/americanfootball_nfl"
+	"github.com/XavierBriggs/fortuna/services/normalizer/tests/testutil"
+)
+
+var base = time.Date(2025, 1, 15, 20, 0, 0, 0, time.UTC)
+
+// recordingPublisher captures published signals
+type recordingPublisher struct {
+	signals []*models.CrossMarketSignal
+}
+
+func (p *recordingPublisher) PublishCrossMarket(ctx context.Context, signal *models.CrossMarketSignal) error {
+	p.signals = append(p.signals, signal)
+	return nil
+}
+
+func nflQuote(odds models.RawOdds, at time.Time) models.RawOdds {
+	odds.SportKey = "americanfootball_nfl"
+	odds.VendorLastUpdate = at
+	return odds
+}
+
+func moneyline(book string, chiefs, bills int, at time.Time) []models.RawOdds {
+	return []models.RawOdds{
+		nflQuote(testutil.MoneylineOdds(book, "Kansas City Chiefs", chiefs), at),
+		nflQuote(testutil.MoneylineOdds(book, "Buffalo Bills", bills), at),
+	}
+}
+
+func spreads(book string, point float64, chiefs, bills int, at time.Time) []models.RawOdds {
+	return []models.RawOdds{
+		nflQuote(testutil.SpreadOdds(book, "Kansas City Chiefs", chiefs, point), at),
+		nflQuote(testutil.SpreadOdds(book, "Buffalo Bills", bills, -point), at),
+	}
+}
+
+func TestAnalyze_ConsistentMarkets(t *testing.T) {
+	analyzer := crossmarket.NewAnalyzer(crossmarket.DefaultConfig(), nil)
+	dist := americanfootball_nfl.DefaultConfig().MarginDistribution
+
+	// -300 ≈ 72% to win, which the NFL distribution puts right around -6.5
+	ml := moneyline("fanduel", -300, 250, base)
+	sp := spreads("fanduel", -6.5, -110, -110, base)
+
+	if signal := analyzer.Analyze(ml[0], ml, sp, dist, false); signal != nil {
+		t.Errorf("expected no signal, got prob diff %.3f", signal.ProbDiff)
+	}
+	if stats := analyzer.Stats(); stats.Checks != 1 {
+		t.Errorf("checks = %d, want 1", stats.Checks)
+	}
+}
+
+func TestAnalyze_StaleSpreadOpportunity(t *testing.T) {
+	analyzer := crossmarket.NewAnalyzer(crossmarket.DefaultConfig(), nil)
+	dist := americanfootball_nfl.DefaultConfig().MarginDistribution
+
+	// The moneyline moved to -500 but the spread is still -6.5
+	ml := moneyline("fanduel", -500, 380, base.Add(time.Minute))
+	sp := spreads("fanduel", -6.5, -110, -110, base)
+
+	signal := analyzer.Analyze(ml[0], ml, sp, dist, false)
+	if signal == nil {
+		t.Fatal("expected signal")
+	}
+	if signal.Type != models.CrossMarketOpportunity {
+		t.Errorf("type = %s, want opportunity", signal.Type)
+	}
+	if signal.Team != "Kansas City Chiefs" || signal.StaleMarket != "spreads" {
+		t.Errorf("team/stale = %s/%s, want Kansas City Chiefs/spreads", signal.Team, signal.StaleMarket)
+	}
+	if signal.ProbDiff < 0.04 {
+		t.Errorf("prob diff = %.3f, want >= 0.04", signal.ProbDiff)
+	}
+	if signal.ImpliedSpread > -7 {
+		t.Errorf("implied spread = %.1f, want -7 or more", signal.ImpliedSpread)
+	}
+
+	bet := signal.Bet
+	if bet == nil || bet.MarketKey != "spreads" || bet.OutcomeName != "Kansas City Chiefs" {
+		t.Fatalf("bet = %+v, want Chiefs spread", bet)
+	}
+	if math.Abs(bet.FairProb-signal.ImpliedSpreadProb) > 1e-9 || bet.EdgePct <= 1 {
+		t.Errorf("bet fair/edge = %.3f/%.2f%%", bet.FairProb, bet.EdgePct)
+	}
+}
+
+func TestAnalyze_StaleMoneylineOpportunity(t *testing.T) {
+	analyzer := crossmarket.NewAnalyzer(crossmarket.DefaultConfig(), nil)
+	dist := americanfootball_nfl.DefaultConfig().MarginDistribution
+
+	// The spread moved through the 7 to -9.5 while the moneyline sat at -300
+	ml := moneyline("fanduel", -300, 250, base)
+	sp := spreads("fanduel", -9.5, -110, -110, base.Add(time.Minute))
+
+	signal := analyzer.Analyze(sp[0], ml, sp, dist, false)
+	if signal == nil {
+		t.Fatal("expected signal")
+	}
+	if signal.StaleMarket != "h2h" || signal.ProbDiff > -0.04 {
+		t.Errorf("stale/diff = %s/%.3f, want h2h and a negative gap", signal.StaleMarket, signal.ProbDiff)
+	}
+	if signal.Bet == nil || signal.Bet.MarketKey != "h2h" || signal.Bet.OutcomeName != "Kansas City Chiefs" {
+		t.Fatalf("bet = %+v, want Chiefs moneyline", signal.Bet)
+	}
+}
+
+func TestAnalyze_WarningWithoutEdge(t *testing.T) {
+	config := crossmarket.DefaultConfig()
+	config.MinEdge = 0.50
+	analyzer := crossmarket.NewAnalyzer(config, nil)
+	dist := americanfootball_nfl.DefaultConfig().MarginDistribution
+
+	ml := moneyline("fanduel", -500, 380, base.Add(time.Minute))
+	sp := spreads("fanduel", -6.5, -110, -110, base)
+
+	signal := analyzer.Analyze(ml[0], ml, sp, dist, false)
+	if signal == nil || signal.Type != models.CrossMarketWarning || signal.Bet != nil {
+		t.Fatalf("signal = %+v, want warning without a bet", signal)
+	}
+	if stats := analyzer.Stats(); stats.Warnings != 1 || stats.Opportunities != 0 {
+		t.Errorf("stats = %+v, want 1 warning", stats)
+	}
+}
+
+func TestAnalyze_ReportsEachInconsistencyOnce(t *testing.T) {
+	analyzer := crossmarket.NewAnalyzer(crossmarket.DefaultConfig(), nil)
+	dist := americanfootball_nfl.DefaultConfig().MarginDistribution
+
+	ml := moneyline("fanduel", -500, 380, base.Add(time.Minute))
+	sp := spreads("fanduel", -6.5, -110, -110, base)
+
+	if analyzer.Analyze(ml[0], ml, sp, dist, false) == nil {
+		t.Fatal("expected first signal")
+	}
+	if analyzer.Analyze(ml[1], ml, sp, dist, false) != nil {
+		t.Error("same prices should not be reported twice")
+	}
+
+	// A new moneyline price is a new inconsistency
+	ml = moneyline("fanduel", -550, 400, base.Add(2*time.Minute))
+	if analyzer.Analyze(ml[0], ml, sp, dist, false) == nil {
+		t.Error("expected signal after the price changed")
+	}
+
+	if removed := analyzer.Sweep(base.Add(time.Hour)); removed != 1 {
+		t.Errorf("Sweep removed %d, want 1", removed)
+	}
+}
+
+func TestAnalyze_IncompleteMarkets(t *testing.T) {
+	analyzer := crossmarket.NewAnalyzer(crossmarket.DefaultConfig(), nil)
+	dist := americanfootball_nfl.DefaultConfig().MarginDistribution
+
+	ml := moneyline("fanduel", -500, 380, base)
+	otherBook := spreads("draftkings", -6.5, -110, -110, base)
+	if analyzer.Analyze(ml[0], ml, otherBook, dist, false) != nil {
+		t.Error("another book's spread should not be compared")
+	}
+
+	sp := spreads("fanduel", -6.5, -110, -110, base)
+	if analyzer.Analyze(ml[0], ml[:1], sp, dist, false) != nil {
+		t.Error("one-sided moneyline should not be compared")
+	}
+}
+
+func TestCheck_PublishesFromLookup(t *testing.T) {
+	pub := &recordingPublisher{}
+	analyzer := crossmarket.NewAnalyzer(crossmarket.DefaultConfig(), pub)
+	dist := americanfootball_nfl.DefaultConfig().MarginDistribution
+
+	markets := map[string][]models.RawOdds{
+		"h2h":     moneyline("fanduel", -500, 380, base.Add(time.Minute)),
+		"spreads": spreads("fanduel", -6.5, -110, -110, base),
+	}
+	lookup := func(odds models.RawOdds) []models.RawOdds {
+		return markets[odds.MarketKey]
+	}
+
+	if err := analyzer.Check(context.Background(), markets["h2h"][0], lookup, dist, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pub.signals) != 1 || pub.signals[0].SportKey != "americanfootball_nfl" {
+		t.Fatalf("published %d signals, want 1 for americanfootball_nfl", len(pub.signals))
+	}
+
+	totals := nflQuote(testutil.TotalOdds("fanduel", "Over", -110, 47.5), base)
+	if err := analyzer.Check(context.Background(), totals, lookup, dist, false); err != nil || len(pub.signals) != 1 {
+		t.Error("totals quotes should not trigger a check")
+	}
+}
@@ -0,0 +1,104 @@
+package deadletter_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/deadletter"
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/marketstate"
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/processor"
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/publisher"
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/registry"
+	"github.com/XavierBriggs/fortuna/services/normalizer/sports/basketball_nba"
+	"github.com/XavierBriggs/fortuna/services/normalizer/tests/testutil"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestClassify(t *testing.T) {
+	base := errors.New("boom")
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"tagged", deadletter.NewError(deadletter.ClassPublish, base), deadletter.ClassPublish},
+		{"wrapped", fmt.Errorf("outer: %w", deadletter.NewError(deadletter.ClassDecode, base)), deadletter.ClassDecode},
+		{"untagged", base, deadletter.ClassUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := deadletter.Classify(tt.err); got != tt.want {
+				t.Errorf("Classify() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if !errors.Is(deadletter.NewError(deadletter.ClassNormalization, base), base) {
+		t.Error("dead-letter error should unwrap to the original error")
+	}
+}
+
+func TestEntry_RoundTrip(t *testing.T) {
+	entry := deadletter.Entry{
+		SportKey:     "basketball_nba",
+		SourceStream: "odds.raw.basketball_nba",
+		SourceID:     "1700000000000-0",
+		ErrorClass:   deadletter.ClassNormalization,
+		ErrorMessage: "normalization error: invalid price",
+		Attempts:     2,
+		Payload:      `{"event_id":"abc"}`,
+		FailedAt:     time.Date(2025, 1, 10, 19, 0, 0, 123, time.UTC),
+	}
+
+	// Stream values come back from Redis as strings
+	values := make(map[string]interface{})
+	for key, value := range entry.Values() {
+		values[key] = fmt.Sprint(value)
+	}
+
+	got := deadletter.EntryFromMessage(redis.XMessage{ID: "1700000000001-0", Values: values})
+
+	entry.ID = "1700000000001-0"
+	if got != entry {
+		t.Errorf("round trip mismatch:\n got  %+v\n want %+v", got, entry)
+	}
+}
+
+func TestSportFromStream(t *testing.T) {
+	if got := deadletter.SportFromStream("odds.raw.basketball_nba"); got != "basketball_nba" {
+		t.Errorf("SportFromStream() = %s, want basketball_nba", got)
+	}
+	if got := deadletter.StreamKey("soccer_epl"); got != "odds.deadletter.soccer_epl" {
+		t.Errorf("StreamKey() = %s, want odds.deadletter.soccer_epl", got)
+	}
+}
+
+func TestProcessRawOdds_ErrorClasses(t *testing.T) {
+	reg := registry.NewNormalizerRegistry()
+	if err := reg.Register(basketball_nba.NewNormalizer()); err != nil {
+		t.Fatalf("failed to register normalizer: %v", err)
+	}
+
+	var buf bytes.Buffer
+	proc := processor.NewProcessor(nil, publisher.NewFilePublisher(&buf), reg,
+		marketstate.NewStore(marketstate.DefaultConfig()), processor.Options{})
+
+	unknownSport := testutil.SpreadOdds("fanduel", "Los Angeles Lakers", -110, -7.5)
+	unknownSport.SportKey = "cricket_ipl"
+	err := proc.ProcessRawOdds(context.Background(), unknownSport)
+	if got := deadletter.Classify(err); got != deadletter.ClassNoNormalizer {
+		t.Errorf("unknown sport class = %s, want %s (err: %v)", got, deadletter.ClassNoNormalizer, err)
+	}
+
+	badPrice := testutil.SpreadOdds("fanduel", "Los Angeles Lakers", 0, -7.5)
+	err = proc.ProcessRawOdds(context.Background(), badPrice)
+	if got := deadletter.Classify(err); got != deadletter.ClassNormalization {
+		t.Errorf("invalid price class = %s, want %s (err: %v)", got, deadletter.ClassNormalization, err)
+	}
+}
@@ -0,0 +1,128 @@
+package hold_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/hold"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/normalizer/tests/testutil"
+)
+
+// recordingPublisher captures published summaries per sport
+type recordingPublisher struct {
+	published map[string][]models.BookHoldSummary
+}
+
+func (p *recordingPublisher) PublishHold(ctx context.Context, sportKey string, summaries []models.BookHoldSummary) error {
+	p.published[sportKey] = summaries
+	return nil
+}
+
+func TestCalculateBookHold_TwoWay(t *testing.T) {
+	lakers := testutil.SpreadOdds("fanduel", "Los Angeles Lakers", -110, -7.5)
+	celtics := testutil.SpreadOdds("fanduel", "Boston Celtics", -110, 7.5)
+
+	overround, holdPct, ok := hold.CalculateBookHold(lakers, models.MarketTypeTwoWay, []models.RawOdds{celtics}, 0)
+	if !ok {
+		t.Fatal("expected complete market")
+	}
+	if math.Abs(overround-4.762) > 0.01 {
+		t.Errorf("overround = %.3f, want 4.762", overround)
+	}
+	if math.Abs(holdPct-4.545) > 0.01 {
+		t.Errorf("hold = %.3f, want 4.545", holdPct)
+	}
+}
+
+func TestCalculateBookHold_IncompleteMarkets(t *testing.T) {
+	lakers := testutil.SpreadOdds("fanduel", "Los Angeles Lakers", -110, -7.5)
+	otherBook := testutil.SpreadOdds("draftkings", "Boston Celtics", -110, 7.5)
+	if _, _, ok := hold.CalculateBookHold(lakers, models.MarketTypeTwoWay, []models.RawOdds{otherBook}, 0); ok {
+		t.Error("opposite side from another book should not complete the market")
+	}
+
+	home := testutil.MoneylineOdds("bet365", "Arsenal", 150)
+	away := testutil.MoneylineOdds("bet365", "Chelsea", 180)
+	if _, _, ok := hold.CalculateBookHold(home, models.MarketTypeThreeWay, []models.RawOdds{away}, 3); ok {
+		t.Error("three-way market without the draw should be incomplete")
+	}
+
+	draw := testutil.MoneylineOdds("bet365", "Draw", 240)
+	if _, _, ok := hold.CalculateBookHold(home, models.MarketTypeThreeWay, []models.RawOdds{away, draw}, 3); !ok {
+		t.Error("expected complete three-way market")
+	}
+}
+
+func TestTracker_SummaryByBook(t *testing.T) {
+	tracker := hold.NewTracker(hold.DefaultConfig(), nil)
+
+	// FanDuel -110/-110 on two games, Pinnacle -105/-105 on one
+	for _, eventID := range []string{"event-1", "event-2"} {
+		lakers := testutil.SpreadOdds("fanduel", "Los Angeles Lakers", -110, -7.5)
+		celtics := testutil.SpreadOdds("fanduel", "Boston Celtics", -110, 7.5)
+		lakers.EventID, celtics.EventID = eventID, eventID
+		tracker.Observe(lakers, models.MarketTypeTwoWay, []models.RawOdds{celtics}, 0, false)
+		// The other side of the same line replaces, not adds
+		tracker.Observe(celtics, models.MarketTypeTwoWay, []models.RawOdds{lakers}, 0, false)
+	}
+	pinnacleOver := testutil.TotalOdds("pinnacle", "Over", -105, 220.5)
+	pinnacleUnder := testutil.TotalOdds("pinnacle", "Under", -105, 220.5)
+	tracker.Observe(pinnacleOver, models.MarketTypeTwoWay, []models.RawOdds{pinnacleUnder}, 0, true)
+
+	summaries := tracker.Summary("basketball_nba")
+	if len(summaries) != 2 {
+		t.Fatalf("got %d summaries, want 2", len(summaries))
+	}
+
+	// Lowest hold first
+	pinnacle, fanduel := summaries[0], summaries[1]
+	if pinnacle.BookKey != "pinnacle" || !pinnacle.IsSharp || pinnacle.Markets != 1 {
+		t.Errorf("unexpected first summary: %+v", pinnacle)
+	}
+	if fanduel.BookKey != "fanduel" || fanduel.IsSharp || fanduel.Markets != 2 {
+		t.Errorf("unexpected second summary: %+v", fanduel)
+	}
+	if fanduel.MarketType != string(models.MarketTypeTwoWay) {
+		t.Errorf("market type = %s, want two_way", fanduel.MarketType)
+	}
+	if math.Abs(fanduel.AvgHoldPct-4.545) > 0.01 {
+		t.Errorf("avg hold = %.3f, want 4.545", fanduel.AvgHoldPct)
+	}
+
+	if got := tracker.Summary("americanfootball_nfl"); len(got) != 0 {
+		t.Errorf("got %d NFL summaries, want 0", len(got))
+	}
+}
+
+func TestTracker_SweepAndPublish(t *testing.T) {
+	publisher := &recordingPublisher{published: make(map[string][]models.BookHoldSummary)}
+	tracker := hold.NewTracker(hold.DefaultConfig(), publisher)
+
+	now := time.Now()
+	fresh := testutil.SpreadOdds("fanduel", "Los Angeles Lakers", -110, -7.5)
+	freshOpposite := testutil.SpreadOdds("fanduel", "Boston Celtics", -110, 7.5)
+	stale := testutil.SpreadOdds("betmgm", "Los Angeles Lakers", -115, -7.5)
+	staleOpposite := testutil.SpreadOdds("betmgm", "Boston Celtics", -105, 7.5)
+	stale.VendorLastUpdate = now.Add(-time.Hour)
+
+	tracker.Observe(fresh, models.MarketTypeTwoWay, []models.RawOdds{freshOpposite}, 0, false)
+	tracker.Observe(stale, models.MarketTypeTwoWay, []models.RawOdds{staleOpposite}, 0, false)
+
+	if removed := tracker.Sweep(now); removed != 1 {
+		t.Errorf("removed = %d, want 1", removed)
+	}
+	if err := tracker.Publish(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	published := publisher.published["basketball_nba"]
+	if len(published) != 1 || published[0].BookKey != "fanduel" {
+		t.Errorf("published = %+v, want fanduel only", published)
+	}
+	if stats := tracker.Stats(); stats.Markets != 1 || stats.Expired != 1 || stats.Published != 1 {
+		t.Errorf("stats = %+v", stats)
+	}
+}
@@ -0,0 +1,162 @@
+package marketstate_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/marketstate"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/normalizer/tests/testutil"
+)
+
+// fakeClock is a manually advanced clock for TTL tests
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func TestStore_UpsertReplacesBookOutcome(t *testing.T) {
+	store := marketstate.NewStore(marketstate.DefaultConfig())
+
+	store.Upsert(testutil.SpreadOdds("fanduel", "Los Angeles Lakers", -110, -7.5))
+	store.Upsert(testutil.SpreadOdds("fanduel", "Boston Celtics", -110, 7.5))
+	store.Upsert(testutil.SpreadOdds("fanduel", "Los Angeles Lakers", -120, -7.5))
+
+	quotes := store.Get(testutil.SpreadOdds("fanduel", "Los Angeles Lakers", -110, -7.5))
+	if len(quotes) != 2 {
+		t.Fatalf("got %d quotes, want 2", len(quotes))
+	}
+
+	if quotes[0].Price != -120 {
+		t.Errorf("Lakers price = %d, want -120 (latest)", quotes[0].Price)
+	}
+}
+
+func TestStore_UpsertKeepsAlternateLines(t *testing.T) {
+	store := marketstate.NewStore(marketstate.DefaultConfig())
+
+	alt := func(price int, point float64) models.RawOdds {
+		odds := testutil.SpreadOdds("fanduel", "Los Angeles Lakers", price, point)
+		odds.MarketKey = "alternate_spreads"
+		return odds
+	}
+	store.Upsert(alt(-110, -7.5))
+	store.Upsert(alt(+120, -9.5))
+	store.Upsert(alt(-125, -7.5))
+
+	quotes := store.Get(alt(-110, -7.5))
+	if len(quotes) != 2 {
+		t.Fatalf("got %d quotes, want 2 (one per line)", len(quotes))
+	}
+
+	prices := make(map[float64]int)
+	for _, quote := range quotes {
+		prices[*quote.Point] = quote.Price
+	}
+	if prices[-7.5] != -125 || prices[-9.5] != 120 {
+		t.Errorf("prices by line = %v, want -7.5:-125 and -9.5:120", prices)
+	}
+}
+
+func TestStore_UpsertReplacesMovedMainLine(t *testing.T) {
+	store := marketstate.NewStore(marketstate.DefaultConfig())
+
+	store.Upsert(testutil.SpreadOdds("fanduel", "Los Angeles Lakers", -110, -7.5))
+	store.Upsert(testutil.SpreadOdds("fanduel", "Los Angeles Lakers", -105, -8.5))
+
+	quotes := store.Get(testutil.SpreadOdds("fanduel", "Los Angeles Lakers", -110, -7.5))
+	if len(quotes) != 1 {
+		t.Fatalf("got %d quotes, want 1 (the moved line replaces the old one)", len(quotes))
+	}
+	if *quotes[0].Point != -8.5 || quotes[0].Price != -105 {
+		t.Errorf("quote = %d at %g, want -105 at -8.5", quotes[0].Price, *quotes[0].Point)
+	}
+}
+
+func TestStore_GetReturnsCopy(t *testing.T) {
+	store := marketstate.NewStore(marketstate.DefaultConfig())
+	odds := testutil.SpreadOdds("fanduel", "Los Angeles Lakers", -110, -7.5)
+	store.Upsert(odds)
+
+	quotes := store.Get(odds)
+	quotes[0].Price = 999
+
+	if got := store.Get(odds)[0].Price; got != -110 {
+		t.Errorf("stored price = %d, want -110 (mutation leaked into store)", got)
+	}
+}
+
+func TestStore_TTLExpiry(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	config := marketstate.DefaultConfig()
+	config.TTL = 5 * time.Minute
+	store := marketstate.NewStoreWithClock(config, clock.Now)
+
+	spread := testutil.SpreadOdds("fanduel", "Los Angeles Lakers", -110, -7.5)
+	total := testutil.TotalOdds("fanduel", "Over", -110, 220.5)
+	store.Upsert(spread)
+
+	clock.now = clock.now.Add(4 * time.Minute)
+	store.Upsert(total)
+
+	// Spread is 6 minutes old, total 2 minutes
+	clock.now = clock.now.Add(2 * time.Minute)
+
+	if got := len(store.Get(spread)); got != 0 {
+		t.Errorf("expired market returned %d quotes, want 0", got)
+	}
+
+	if removed := store.Sweep(); removed != 1 {
+		t.Errorf("Sweep removed %d markets, want 1", removed)
+	}
+
+	if got := len(store.Get(total)); got != 1 {
+		t.Errorf("live market returned %d quotes, want 1", got)
+	}
+
+	stats := store.Stats()
+	if stats.Markets != 1 || stats.Expired != 1 {
+		t.Errorf("stats = %+v, want 1 market and 1 expired", stats)
+	}
+}
+
+func TestStore_MaxMarketsEvictsLeastRecent(t *testing.T) {
+	config := marketstate.DefaultConfig()
+	config.MaxMarkets = 2
+	store := marketstate.NewStore(config)
+
+	events := []string{"event-1", "event-2", "event-3"}
+	for _, eventID := range events {
+		store.Upsert(testutil.RawOddsFixture(func(o *models.RawOdds) { o.EventID = eventID }))
+	}
+
+	first := testutil.RawOddsFixture(func(o *models.RawOdds) { o.EventID = "event-1" })
+	if got := len(store.Get(first)); got != 0 {
+		t.Errorf("least recent market returned %d quotes, want 0", got)
+	}
+
+	stats := store.Stats()
+	if stats.Markets != 2 || stats.Evicted != 1 {
+		t.Errorf("stats = %+v, want 2 markets and 1 evicted", stats)
+	}
+}
+
+func TestStore_MaxQuotesPerMarket(t *testing.T) {
+	config := marketstate.DefaultConfig()
+	config.MaxQuotesPerMarket = 2
+	store := marketstate.NewStore(config)
+
+	for _, book := range []string{"fanduel", "draftkings", "betmgm"} {
+		store.Upsert(testutil.SpreadOdds(book, "Los Angeles Lakers", -110, -7.5))
+	}
+
+	quotes := store.Get(testutil.SpreadOdds("fanduel", "Los Angeles Lakers", -110, -7.5))
+	if len(quotes) != 2 {
+		t.Errorf("got %d quotes, want 2", len(quotes))
+	}
+
+	if stats := store.Stats(); stats.QuotesRejected != 1 {
+		t.Errorf("QuotesRejected = %d, want 1", stats.QuotesRejected)
+	}
+}
@@ -0,0 +1,113 @@
+package metrics_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/consumer"
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/deadletter"
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/marketstate"
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/metrics"
+)
+
+func ok(ctx context.Context) error   { return nil }
+func down(ctx context.Context) error { return errors.New("connection refused") }
+
+func scrape(t *testing.T, server *metrics.Server) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("/metrics status = %d", rec.Code)
+	}
+	body, _ := io.ReadAll(rec.Body)
+	return string(body)
+}
+
+func TestRecorder_Metrics(t *testing.T) {
+	recorder := metrics.NewRecorder()
+	recorder.WatchMarketCache(func() marketstate.Stats { return marketstate.Stats{Markets: 3, Quotes: 12} })
+	recorder.WatchStreams(func(ctx context.Context) ([]consumer.PendingStats, error) {
+		return []consumer.PendingStats{{Stream: "odds.raw.basketball_nba", Pending: 2, Lag: 7}}, nil
+	})
+
+	received := time.Now()
+	recorder.Processed("odds.raw.basketball_nba", received, received.Add(20*time.Millisecond))
+	recorder.Processed("odds.raw.basketball_nba", received, received.Add(30*time.Millisecond))
+	recorder.Error("odds.raw.basketball_nba", deadletter.NewError(deadletter.ClassPublish, errors.New("redis down")))
+	recorder.DeadLettered("odds.raw.basketball_nba")
+
+	body := scrape(t, metrics.NewServer(":0", "normalizer", recorder))
+
+	for _, want := range []string{
+		`normalizer_messages_processed_total{sport="basketball_nba",stream="odds.raw.basketball_nba"} 2`,
+		`normalizer_messages_errors_total{class="publish",sport="basketball_nba",stream="odds.raw.basketball_nba"} 1`,
+		`normalizer_messages_dead_lettered_total{sport="basketball_nba",stream="odds.raw.basketball_nba"} 1`,
+		`normalizer_normalize_latency_seconds_count{sport="basketball_nba",stream="odds.raw.basketball_nba"} 2`,
+		`normalizer_normalize_latency_seconds_bucket{sport="basketball_nba",stream="odds.raw.basketball_nba",le="0.025"} 1`,
+		`normalizer_market_cache_markets 3`,
+		`normalizer_market_cache_quotes 12`,
+		`normalizer_stream_lag{sport="basketball_nba",stream="odds.raw.basketball_nba"} 7`,
+		`normalizer_stream_pending{sport="basketball_nba",stream="odds.raw.basketball_nba"} 2`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("/metrics missing %q", want)
+		}
+	}
+}
+
+func TestRecorder_NilIsNoop(t *testing.T) {
+	var recorder *metrics.Recorder
+	recorder.Processed("odds.raw.basketball_nba", time.Now(), time.Now())
+	recorder.Error("odds.raw.basketball_nba", errors.New("boom"))
+	recorder.DeadLettered("odds.raw.basketball_nba")
+}
+
+func TestServer_Health(t *testing.T) {
+	tests := []struct {
+		name       string
+		redis      metrics.CheckFunc
+		postgres   metrics.CheckFunc
+		wantStatus string
+		wantCode   int
+	}{
+		{"all up", ok, ok, metrics.StatusHealthy, http.StatusOK},
+		{"optional down", ok, down, metrics.StatusDegraded, http.StatusOK},
+		{"required down", down, ok, metrics.StatusUnhealthy, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := metrics.NewServer(":0", "normalizer", metrics.NewRecorder())
+			server.AddCheck("redis", true, tt.redis)
+			server.AddCheck("alexandria", false, tt.postgres)
+
+			rec := httptest.NewRecorder()
+			server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+			if rec.Code != tt.wantCode {
+				t.Errorf("status code = %d, want %d", rec.Code, tt.wantCode)
+			}
+
+			var health metrics.HealthResponse
+			if err := json.NewDecoder(rec.Body).Decode(&health); err != nil {
+				t.Fatalf("failed to decode /health: %v", err)
+			}
+			if health.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", health.Status, tt.wantStatus)
+			}
+			if len(health.Checks) != 2 {
+				t.Fatalf("checks = %d, want 2", len(health.Checks))
+			}
+			if health.Checks["alexandria"].Required {
+				t.Error("alexandria should be optional")
+			}
+		})
+	}
+}
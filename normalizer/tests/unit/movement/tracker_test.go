@@ -0,0 +1,283 @@
+package movement_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/movement"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/normalizer/tests/testutil"
+)
+
+var start = time.Date(2025, 1, 10, 19, 0, 0, 0, time.UTC)
+
+func isSharp(bookKey string) bool {
+	return bookKey == "pinnacle" || bookKey == "circa"
+}
+
+// quote builds a Lakers spread quote at start+offset
+func quote(book string, price int, point float64, offset time.Duration) models.RawOdds {
+	odds := testutil.SpreadOdds(book, "Los Angeles Lakers", price, point)
+	odds.VendorLastUpdate = start.Add(offset)
+	return odds
+}
+
+// recordingPublisher captures published movements
+type recordingPublisher struct {
+	movements []*models.LineMovement
+}
+
+func (p *recordingPublisher) PublishMovement(ctx context.Context, movement *models.LineMovement) error {
+	p.movements = append(p.movements, movement)
+	return nil
+}
+
+func TestTracker_LineMoveAboveThreshold(t *testing.T) {
+	tracker := movement.NewTracker(movement.DefaultConfig(), nil)
+
+	if got := tracker.Observe(quote("pinnacle", -110, -7.5, 0), nil, isSharp); got != nil {
+		t.Fatalf("first quote should not move, got %d events", len(got))
+	}
+	if got := tracker.Observe(quote("pinnacle", -115, -7.5, time.Minute), nil, isSharp); got != nil {
+		t.Fatalf("5 cent move is under MinCents, got %d events", len(got))
+	}
+
+	got := tracker.Observe(quote("pinnacle", -122, -7.5, 2*time.Minute), nil, isSharp)
+	if len(got) != 1 {
+		t.Fatalf("got %d events, want 1", len(got))
+	}
+	move := got[0]
+	if move.Type != models.MovementTypeLineMove || move.Direction != models.MovementShorten {
+		t.Errorf("got %s/%s, want line_move/shorten", move.Type, move.Direction)
+	}
+	if len(move.Moves) != 1 || move.Moves[0].FromPrice != -110 || move.Moves[0].ToPrice != -122 || move.Moves[0].Cents != 12 {
+		t.Errorf("unexpected moves: %+v", move.Moves)
+	}
+}
+
+func TestTracker_LineMoveNotRepublished(t *testing.T) {
+	tracker := movement.NewTracker(movement.DefaultConfig(), nil)
+
+	tracker.Observe(quote("pinnacle", -110, -7.5, 0), nil, isSharp)
+	if got := tracker.Observe(quote("pinnacle", -120, -7.5, time.Minute), nil, isSharp); len(got) != 1 {
+		t.Fatalf("got %d events, want 1", len(got))
+	}
+	if got := tracker.Observe(quote("pinnacle", -122, -7.5, 2*time.Minute), nil, isSharp); got != nil {
+		t.Errorf("small follow-up move should not re-publish, got %d events", len(got))
+	}
+	if got := tracker.Observe(quote("pinnacle", -130, -7.5, 3*time.Minute), nil, isSharp); len(got) != 1 {
+		t.Errorf("another MinCents should publish again, got %d events", len(got))
+	}
+}
+
+func TestTracker_SteamAcrossSharpBooks(t *testing.T) {
+	publisher := &recordingPublisher{}
+	tracker := movement.NewTracker(movement.DefaultConfig(), publisher)
+	ctx := context.Background()
+
+	tracker.Track(ctx, quote("pinnacle", -110, -7.5, 0), nil, isSharp)
+	tracker.Track(ctx, quote("circa", -110, -7.5, 0), nil, isSharp)
+	tracker.Track(ctx, quote("pinnacle", -116, -7.5, time.Minute), nil, isSharp)
+	if len(publisher.movements) != 0 {
+		t.Fatalf("one book moving 6 cents should not publish, got %d", len(publisher.movements))
+	}
+
+	if err := tracker.Track(ctx, quote("circa", -117, -7.5, 90*time.Second), nil, isSharp); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(publisher.movements) != 1 {
+		t.Fatalf("got %d events, want 1", len(publisher.movements))
+	}
+	steam := publisher.movements[0]
+	if steam.Type != models.MovementTypeSteam {
+		t.Errorf("type = %s, want steam", steam.Type)
+	}
+	if len(steam.Moves) != 2 || steam.Moves[0].BookKey != "circa" || steam.Moves[1].BookKey != "pinnacle" {
+		t.Errorf("unexpected moves: %+v", steam.Moves)
+	}
+
+	// The steamed books start over, so the same move isn't reported twice
+	tracker.Track(ctx, quote("pinnacle", -117, -7.5, 2*time.Minute), nil, isSharp)
+	if len(publisher.movements) != 1 {
+		t.Errorf("steam re-published, got %d events", len(publisher.movements))
+	}
+	if stats := tracker.Stats(); stats.Steams != 1 || stats.LineMoves != 0 {
+		t.Errorf("stats = %+v, want 1 steam", stats)
+	}
+}
+
+func TestTracker_OppositeMovesAreNotSteam(t *testing.T) {
+	tracker := movement.NewTracker(movement.DefaultConfig(), nil)
+
+	tracker.Observe(quote("pinnacle", -110, -7.5, 0), nil, isSharp)
+	tracker.Observe(quote("circa", -110, -7.5, 0), nil, isSharp)
+	tracker.Observe(quote("pinnacle", -117, -7.5, time.Minute), nil, isSharp)
+
+	if got := tracker.Observe(quote("circa", -103, -7.5, time.Minute), nil, isSharp); got != nil {
+		t.Errorf("opposite moves should not steam, got %s", got[0].Type)
+	}
+}
+
+func TestTracker_StaleSoftBooks(t *testing.T) {
+	tracker := movement.NewTracker(movement.DefaultConfig(), nil)
+
+	market := []models.RawOdds{
+		quote("fanduel", -110, -7.5, -time.Minute),      // hasn't moved since before the sharp move
+		quote("draftkings", -125, -7.5, 90*time.Second), // already moved
+		quote("betmgm", -110, -8, -time.Minute),         // different point
+		quote("circa", -110, -7.5, 0),                   // sharp
+	}
+
+	tracker.Observe(quote("pinnacle", -110, -7.5, 0), nil, isSharp)
+	got := tracker.Observe(quote("pinnacle", -125, -7.5, 2*time.Minute), market, isSharp)
+	if len(got) != 1 {
+		t.Fatalf("got %d events, want 1", len(got))
+	}
+	stale := got[0].StaleBooks
+	if len(stale) != 1 || stale[0].BookKey != "fanduel" || stale[0].Price != -110 {
+		t.Errorf("stale books = %+v, want fanduel at -110", stale)
+	}
+}
+
+func TestTracker_PointChangeIsLineMove(t *testing.T) {
+	tracker := movement.NewTracker(movement.DefaultConfig(), nil)
+
+	market := []models.RawOdds{
+		quote("fanduel", -110, -7.5, -time.Minute),  // still on the old line
+		quote("draftkings", -110, -8, -time.Minute), // already on the new line
+	}
+
+	tracker.Observe(quote("pinnacle", -110, -7.5, 0), nil, isSharp)
+	got := tracker.Observe(quote("pinnacle", -105, -8, time.Minute), market, isSharp)
+	if len(got) != 1 {
+		t.Fatalf("got %d events, want 1", len(got))
+	}
+
+	move := got[0]
+	if move.Type != models.MovementTypeLineMove || move.Direction != models.MovementShorten {
+		t.Errorf("got %s/%s, want line_move/shorten (laying more points)", move.Type, move.Direction)
+	}
+	if move.Point == nil || *move.Point != -8 {
+		t.Errorf("point = %v, want -8", move.Point)
+	}
+	if len(move.Moves) != 1 || move.Moves[0].FromPoint == nil || *move.Moves[0].FromPoint != -7.5 ||
+		move.Moves[0].ToPoint == nil || *move.Moves[0].ToPoint != -8 || move.Moves[0].ToPrice != -105 {
+		t.Errorf("unexpected moves: %+v", move.Moves)
+	}
+	if len(move.StaleBooks) != 1 || move.StaleBooks[0].BookKey != "fanduel" {
+		t.Errorf("stale books = %+v, want fanduel on the old line", move.StaleBooks)
+	}
+}
+
+func TestTracker_PointChangeDirection(t *testing.T) {
+	tests := []struct {
+		name     string
+		outcome  string
+		from, to float64
+		want     models.MovementDirection
+	}{
+		{"favorite lays more", "Los Angeles Lakers", -7.5, -8, models.MovementShorten},
+		{"underdog gets more", "Boston Celtics", 7.5, 8, models.MovementLengthen},
+		{"total raised for the over", "Over", 221.5, 222.5, models.MovementShorten},
+		{"total raised for the under", "Under", 221.5, 222.5, models.MovementLengthen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := movement.NewTracker(movement.DefaultConfig(), nil)
+			at := func(point float64, offset time.Duration) models.RawOdds {
+				odds := quote("pinnacle", -110, point, offset)
+				odds.OutcomeName = tt.outcome
+				return odds
+			}
+
+			tracker.Observe(at(tt.from, 0), nil, isSharp)
+			got := tracker.Observe(at(tt.to, time.Minute), nil, isSharp)
+			if len(got) != 1 || got[0].Direction != tt.want {
+				t.Errorf("got %+v, want one %s move", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTracker_PointChangeSteam(t *testing.T) {
+	tracker := movement.NewTracker(movement.DefaultConfig(), nil)
+
+	tracker.Observe(quote("pinnacle", -110, -7.5, 0), nil, isSharp)
+	tracker.Observe(quote("circa", -110, -7.5, 0), nil, isSharp)
+	tracker.Observe(quote("pinnacle", -110, -8, time.Minute), nil, isSharp)
+
+	got := tracker.Observe(quote("circa", -108, -8, 2*time.Minute), nil, isSharp)
+	if len(got) != 1 || got[0].Type != models.MovementTypeSteam {
+		t.Fatalf("got %+v, want one steam", got)
+	}
+	if len(got[0].Moves) != 2 || got[0].Moves[0].BookKey != "circa" || got[0].Moves[1].BookKey != "pinnacle" {
+		t.Errorf("unexpected moves: %+v", got[0].Moves)
+	}
+	if stats := tracker.Stats(); stats.Steams != 1 || stats.LineMoves != 1 {
+		t.Errorf("stats = %+v, want 1 line move then 1 steam", stats)
+	}
+}
+
+func TestTracker_KeepsHistoryPerPoint(t *testing.T) {
+	tracker := movement.NewTracker(movement.DefaultConfig(), nil)
+	alt := func(price int, point float64, offset time.Duration) models.RawOdds {
+		odds := quote("pinnacle", price, point, offset)
+		odds.MarketKey = "alternate_spreads"
+		return odds
+	}
+
+	// Alternate lines interleave without counting as point moves or resetting each other
+	tracker.Observe(alt(-110, -7.5, 0), nil, isSharp)
+	if got := tracker.Observe(alt(130, -9.5, 0), nil, isSharp); got != nil {
+		t.Fatalf("an alternate line is not a move, got %+v", got[0])
+	}
+	tracker.Observe(alt(125, -9.5, time.Minute), nil, isSharp)
+
+	got := tracker.Observe(alt(-122, -7.5, 2*time.Minute), nil, isSharp)
+	if len(got) != 1 {
+		t.Fatalf("got %d events, want the -7.5 price move", len(got))
+	}
+	if got[0].Moves[0].FromPrice != -110 || got[0].Moves[0].Cents != 12 || got[0].Moves[0].FromPoint != nil {
+		t.Errorf("unexpected moves: %+v", got[0].Moves)
+	}
+	if stats := tracker.Stats(); stats.Histories != 2 {
+		t.Errorf("histories = %d, want one per point", stats.Histories)
+	}
+}
+
+func TestTracker_IgnoresSoftBooks(t *testing.T) {
+	tracker := movement.NewTracker(movement.DefaultConfig(), nil)
+
+	tracker.Observe(quote("fanduel", -110, -7.5, 0), nil, isSharp)
+	if got := tracker.Observe(quote("fanduel", -140, -7.5, time.Minute), nil, isSharp); got != nil {
+		t.Errorf("soft book moves should be ignored, got %d events", len(got))
+	}
+	if stats := tracker.Stats(); stats.Histories != 0 {
+		t.Errorf("histories = %d, want 0", stats.Histories)
+	}
+}
+
+func TestTracker_MoveOutsideWindow(t *testing.T) {
+	tracker := movement.NewTracker(movement.DefaultConfig(), nil)
+
+	tracker.Observe(quote("pinnacle", -110, -7.5, 0), nil, isSharp)
+	if got := tracker.Observe(quote("pinnacle", -125, -7.5, 10*time.Minute), nil, isSharp); got != nil {
+		t.Errorf("move across more than the window should not publish, got %d events", len(got))
+	}
+}
+
+func TestTracker_Sweep(t *testing.T) {
+	tracker := movement.NewTracker(movement.DefaultConfig(), nil)
+
+	tracker.Observe(quote("pinnacle", -110, -7.5, 0), nil, isSharp)
+	tracker.Observe(quote("circa", -110, -7.5, 4*time.Minute), nil, isSharp)
+
+	if removed := tracker.Sweep(start.Add(6 * time.Minute)); removed != 1 {
+		t.Errorf("removed = %d, want 1", removed)
+	}
+	if stats := tracker.Stats(); stats.Histories != 1 || stats.Expired != 1 {
+		t.Errorf("stats = %+v, want 1 history and 1 expired", stats)
+	}
+}
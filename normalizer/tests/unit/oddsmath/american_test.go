@@ -186,8 +186,3 @@ func TestInvalidInputs(t *testing.T) {
 		}
 	})
 }
-
-
-
-
-
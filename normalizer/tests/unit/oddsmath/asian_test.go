@@ -0,0 +1,143 @@
+package oddsmath_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/oddsmath"
+)
+
+func TestSplitAsianLine(t *testing.T) {
+	tests := []struct {
+		line float64
+		want []float64
+	}{
+		{-0.25, []float64{-0.5, 0}},
+		{-0.75, []float64{-1, -0.5}},
+		{0.25, []float64{0, 0.5}},
+		{1.75, []float64{1.5, 2}},
+		{-0.5, []float64{-0.5}},
+		{-1, []float64{-1}},
+		{0, []float64{0}},
+	}
+
+	for _, tt := range tests {
+		got := oddsmath.SplitAsianLine(tt.line)
+		if len(got) != len(tt.want) {
+			t.Errorf("SplitAsianLine(%.2f) = %v, want %v", tt.line, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("SplitAsianLine(%.2f) = %v, want %v", tt.line, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestAsianHandicapProbability_SplitStakes(t *testing.T) {
+	// Home 45% / Draw 25% / Away 30%
+	margins := map[int]float64{1: 0.45, 0: 0.25, -1: 0.30}
+
+	tests := []struct {
+		name string
+		line float64
+		want float64
+	}{
+		// Level ball refunds the draw: 0.45 / (0.45 + 0.30)
+		{"Level", 0, 0.45 / 0.75},
+		// Draw loses: plain win probability
+		{"Half", -0.5, 0.45},
+		// Half refunded, half lost on a draw: 0.90 / (0.90 + 0.60 + 0.25)
+		{"Quarter", -0.25, 0.90 / 1.75},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := oddsmath.AsianHandicapProbability(tt.line, margins)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if math.Abs(got-tt.want) > 0.0001 {
+				t.Errorf("AsianHandicapProbability(%.2f) = %f, want %f", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAsianHandicapProbability_FairSidesSumToOne(t *testing.T) {
+	margins := oddsmath.GoalMargins(1.6, 1.1)
+	mirrored := oddsmath.GoalMargins(1.1, 1.6)
+
+	for _, line := range []float64{-0.25, -0.5, -0.75, -1} {
+		home, err := oddsmath.AsianHandicapProbability(line, margins)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		away, err := oddsmath.AsianHandicapProbability(-line, mirrored)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if math.Abs(home+away-1.0) > 0.0001 {
+			t.Errorf("line %.2f: home %f + away %f = %f, want 1.0", line, home, away, home+away)
+		}
+	}
+}
+
+func TestAdjustAsianHandicapProbability(t *testing.T) {
+	// Taking -0.25 instead of -0.5 gets half the stake back on a draw
+	adjusted, err := oddsmath.AdjustAsianHandicapProbability(0.50, -0.5, -0.25, 2.7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if adjusted <= 0.50 {
+		t.Errorf("-0.25 = %f, want > 0.50 (-0.5 fair)", adjusted)
+	}
+
+	// ...and -0.75 is worse than -0.5
+	harder, err := oddsmath.AdjustAsianHandicapProbability(0.50, -0.5, -0.75, 2.7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if harder >= 0.50 {
+		t.Errorf("-0.75 = %f, want < 0.50", harder)
+	}
+
+	// Round trip back to the original line
+	back, err := oddsmath.AdjustAsianHandicapProbability(adjusted, -0.25, -0.5, 2.7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(back-0.50) > 0.0001 {
+		t.Errorf("round trip = %f, want 0.50", back)
+	}
+}
+
+func TestRemoveVigDoubleChance(t *testing.T) {
+	// 1X 1.30 / 12 1.25 / X2 1.80
+	probs := []float64{1 / 1.30, 1 / 1.25, 1 / 1.80}
+
+	fair, err := oddsmath.RemoveVigDoubleChance(models.VigMethodMultiplicative, probs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Each fair result is counted by two outcomes, so the total is exactly 2
+	total := fair[0] + fair[1] + fair[2]
+	if math.Abs(total-2.0) > 0.0001 {
+		t.Errorf("fair double chance sums to %f, want 2.0", total)
+	}
+
+	for i := range fair {
+		if fair[i] >= probs[i] {
+			t.Errorf("fair[%d] = %f, want below implied %f", i, fair[i], probs[i])
+		}
+	}
+
+	if _, err := oddsmath.RemoveVigDoubleChance(models.VigMethodMultiplicative, probs[:2]); err == nil {
+		t.Error("expected error for incomplete market")
+	}
+}
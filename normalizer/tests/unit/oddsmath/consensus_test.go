@@ -0,0 +1,115 @@
+package oddsmath_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/oddsmath"
+)
+
+func TestCalculateWeightedConsensus(t *testing.T) {
+	tests := []struct {
+		name           string
+		quotes         []oddsmath.SharpQuote
+		halfLife       time.Duration
+		maxAge         time.Duration
+		wantProb       float64
+		wantBooks      int
+		wantDispersion float64
+	}{
+		{
+			name: "Equal weights average",
+			quotes: []oddsmath.SharpQuote{
+				{BookKey: "pinnacle", FairProbability: 0.50, Reliability: 1.0},
+				{BookKey: "circa", FairProbability: 0.52, Reliability: 1.0},
+			},
+			wantProb:       0.51,
+			wantBooks:      2,
+			wantDispersion: 0.01,
+		},
+		{
+			name: "Reliability weighting",
+			quotes: []oddsmath.SharpQuote{
+				{BookKey: "pinnacle", FairProbability: 0.50, Reliability: 1.0},
+				{BookKey: "circa", FairProbability: 0.53, Reliability: 0.5},
+			},
+			wantProb:  0.51,
+			wantBooks: 2,
+		},
+		{
+			name: "Stale quote decays by half-life",
+			quotes: []oddsmath.SharpQuote{
+				{BookKey: "pinnacle", FairProbability: 0.50, Reliability: 1.0},
+				{BookKey: "circa", FairProbability: 0.52, Reliability: 0.8, Age: time.Minute},
+			},
+			halfLife:  time.Minute,
+			wantProb:  (0.50 + 0.4*0.52) / 1.4,
+			wantBooks: 2,
+		},
+		{
+			name: "Quote older than max age dropped",
+			quotes: []oddsmath.SharpQuote{
+				{BookKey: "pinnacle", FairProbability: 0.50, Reliability: 1.0},
+				{BookKey: "circa", FairProbability: 0.60, Reliability: 1.0, Age: 5 * time.Minute},
+			},
+			maxAge:    4 * time.Minute,
+			wantProb:  0.50,
+			wantBooks: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := oddsmath.CalculateWeightedConsensus(tt.quotes, tt.halfLife, tt.maxAge)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if math.Abs(got.Probability-tt.wantProb) > 0.0001 {
+				t.Errorf("Probability = %f, want %f", got.Probability, tt.wantProb)
+			}
+
+			if got.BookCount != tt.wantBooks {
+				t.Errorf("BookCount = %d, want %d", got.BookCount, tt.wantBooks)
+			}
+
+			if tt.wantDispersion > 0 && math.Abs(got.Dispersion-tt.wantDispersion) > 0.0001 {
+				t.Errorf("Dispersion = %f, want %f", got.Dispersion, tt.wantDispersion)
+			}
+		})
+	}
+}
+
+func TestCalculateWeightedConsensus_NoQuotes(t *testing.T) {
+	if _, err := oddsmath.CalculateWeightedConsensus(nil, time.Minute, 0); err == nil {
+		t.Error("expected error for empty quotes")
+	}
+
+	// All quotes past max age leaves nothing to weight
+	quotes := []oddsmath.SharpQuote{{BookKey: "pinnacle", FairProbability: 0.5, Reliability: 1.0, Age: time.Hour}}
+	if _, err := oddsmath.CalculateWeightedConsensus(quotes, time.Minute, time.Minute); err == nil {
+		t.Error("expected error when every quote is too old")
+	}
+}
+
+func TestCalculateWeightedConsensus_SkipsInvalidQuotes(t *testing.T) {
+	quotes := []oddsmath.SharpQuote{
+		{BookKey: "pinnacle", FairProbability: 0.52, Reliability: 1.0},
+		{BookKey: "circa", FairProbability: 1.2, Reliability: 1.0},
+		{BookKey: "bookmaker", FairProbability: 0, Reliability: 1.0},
+	}
+
+	got, err := oddsmath.CalculateWeightedConsensus(quotes, time.Minute, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.BookCount != 1 || math.Abs(got.Probability-0.52) > 1e-9 {
+		t.Errorf("consensus = %+v, want pinnacle alone at 0.52", got)
+	}
+
+	// Nothing valid left
+	if _, err := oddsmath.CalculateWeightedConsensus(quotes[1:], time.Minute, 0); err == nil {
+		t.Error("expected error when every quote is invalid")
+	}
+}
@@ -0,0 +1,141 @@
+package oddsmath_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/oddsmath"
+)
+
+func TestRemoveVig_SumsToOne(t *testing.T) {
+	methods := []models.VigMethod{
+		models.VigMethodMultiplicative,
+		models.VigMethodAdditive,
+		models.VigMethodShin,
+		models.VigMethodPower,
+		models.VigMethodOddsRatio,
+	}
+
+	markets := []struct {
+		name  string
+		probs []float64
+	}{
+		{"Standard -110/-110", []float64{0.5238, 0.5238}},
+		{"Heavy favorite -500/+350", []float64{0.8333, 0.2222}},
+		{"Three-way soccer", []float64{0.4762, 0.2857, 0.2941}},
+		{"Five-way futures", []float64{0.35, 0.25, 0.20, 0.15, 0.10}},
+	}
+
+	for _, method := range methods {
+		for _, market := range markets {
+			t.Run(string(method)+"/"+market.name, func(t *testing.T) {
+				fair, err := oddsmath.RemoveVig(method, market.probs)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				if len(fair) != len(market.probs) {
+					t.Fatalf("got %d outcomes, want %d", len(fair), len(market.probs))
+				}
+
+				total := 0.0
+				for _, p := range fair {
+					total += p
+				}
+				if math.Abs(total-1.0) > 0.0001 {
+					t.Errorf("fair probabilities sum to %f, want 1.0", total)
+				}
+			})
+		}
+	}
+}
+
+func TestRemoveVig_SymmetricMarket(t *testing.T) {
+	// Every method must agree on a balanced -110/-110 market
+	for _, method := range []models.VigMethod{models.VigMethodShin, models.VigMethodPower, models.VigMethodOddsRatio} {
+		t.Run(string(method), func(t *testing.T) {
+			fair, err := oddsmath.RemoveVig(method, []float64{0.5238, 0.5238})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if math.Abs(fair[0]-0.50) > 0.0001 || math.Abs(fair[1]-0.50) > 0.0001 {
+				t.Errorf("fair = %v, want [0.5 0.5]", fair)
+			}
+		})
+	}
+}
+
+func TestRemoveVig_FavoriteLongshotBias(t *testing.T) {
+	// -500/+350: multiplicative leaves the most vig on the longshot,
+	// so the bias-correcting methods should all price the favorite higher
+	probs := []float64{0.8333, 0.2222}
+
+	multiplicative, err := oddsmath.RemoveVig(models.VigMethodMultiplicative, probs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, method := range []models.VigMethod{models.VigMethodShin, models.VigMethodPower, models.VigMethodOddsRatio} {
+		t.Run(string(method), func(t *testing.T) {
+			fair, err := oddsmath.RemoveVig(method, probs)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if fair[0] <= multiplicative[0] {
+				t.Errorf("favorite fair = %f, want > multiplicative %f", fair[0], multiplicative[0])
+			}
+		})
+	}
+}
+
+func TestRemoveVig_NeverNegative(t *testing.T) {
+	// Additive devig goes negative here: 0.02 - (0.13 / 3) < 0
+	probs := []float64{0.96, 0.15, 0.02}
+
+	additive, err := oddsmath.RemoveVig(models.VigMethodAdditive, probs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if additive[2] >= 0 {
+		t.Fatalf("expected additive longshot to go negative, got %f", additive[2])
+	}
+
+	for _, method := range []models.VigMethod{models.VigMethodShin, models.VigMethodPower, models.VigMethodOddsRatio} {
+		t.Run(string(method), func(t *testing.T) {
+			fair, err := oddsmath.RemoveVig(method, probs)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for i, p := range fair {
+				if p <= 0 || p >= 1 {
+					t.Errorf("fair[%d] = %f, want between 0 and 1", i, p)
+				}
+			}
+		})
+	}
+}
+
+func TestRemoveVig_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name   string
+		method models.VigMethod
+		probs  []float64
+	}{
+		{"Single outcome", models.VigMethodShin, []float64{0.6}},
+		{"No vig", models.VigMethodPower, []float64{0.5, 0.5}},
+		{"Invalid probability", models.VigMethodOddsRatio, []float64{1.2, 0.3}},
+		{"Unsupported method", models.VigMethodNone, []float64{0.5238, 0.5238}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := oddsmath.RemoveVig(tt.method, tt.probs); err == nil {
+				t.Error("expected error but got none")
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package oddsmath_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/oddsmath"
+)
+
+func TestResolvePrice(t *testing.T) {
+	raw := models.RawOdds{PriceFormat: models.PriceFormatDecimal, PriceValue: "1.95"}
+	if err := oddsmath.ResolvePrice(&raw); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if raw.Price != -105 {
+		t.Errorf("Price = %d, want -105", raw.Price)
+	}
+
+	// The quoted value prices the odds, not the rounded American price
+	raw = models.RawOdds{PriceFormat: models.PriceFormatDecimal, PriceValue: "1.93"}
+	if err := oddsmath.ResolvePrice(&raw); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if raw.Price != -108 {
+		t.Errorf("Price = %d, want -108", raw.Price)
+	}
+	decimal, err := oddsmath.RawDecimal(raw)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(decimal-1.93) > 1e-12 {
+		t.Errorf("RawDecimal() = %v, want 1.93", decimal)
+	}
+
+	// An explicit American price is kept
+	raw = models.RawOdds{Price: -110, PriceFormat: models.PriceFormatFractional, PriceValue: "10/11"}
+	if err := oddsmath.ResolvePrice(&raw); err != nil || raw.Price != -110 {
+		t.Errorf("ResolvePrice() = %d, %v, want -110, nil", raw.Price, err)
+	}
+
+	// American-only quotes are unchanged
+	raw = models.RawOdds{Price: 150}
+	if err := oddsmath.ResolvePrice(&raw); err != nil || raw.Price != 150 {
+		t.Errorf("ResolvePrice() = %d, %v, want 150, nil", raw.Price, err)
+	}
+
+	for _, bad := range []models.RawOdds{
+		{},
+		{PriceFormat: models.PriceFormatMalay, PriceValue: "1.5"},
+	} {
+		if err := oddsmath.ResolvePrice(&bad); err == nil {
+			t.Errorf("ResolvePrice(%+v) expected error", bad)
+		}
+	}
+}
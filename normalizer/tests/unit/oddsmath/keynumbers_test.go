@@ -0,0 +1,144 @@
+package oddsmath_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/oddsmath"
+)
+
+func testMarginDistribution() oddsmath.MarginDistribution {
+	return oddsmath.MarginDistribution{
+		Landing: map[int]float64{3: 0.095, 5: 0.025, 7: 0.060},
+		Default: 0.01,
+	}
+}
+
+func TestAdjustSpreadProbability(t *testing.T) {
+	dist := testMarginDistribution()
+
+	tests := []struct {
+		name      string
+		fairProb  float64
+		fromPoint float64
+		toPoint   float64
+		want      float64
+	}{
+		// 0.50 × (1 - 0.095) + 0.095
+		{"Buy off the 3", 0.50, -3, -2.5, 0.5475},
+		// Losing the push: 0.50 × 0.905
+		{"Move through the 3", 0.50, -3, -3.5, 0.4525},
+		// 0.50 + 0.025 (dead number)
+		{"Half point on a dead number", 0.50, -5.5, -4.5, 0.5250},
+		// Crosses 5 and 6, then lands on a 7 that can now push
+		{"Underdog onto the 7", 0.50, 4.5, 7, (0.50 + 0.025 + 0.01) / (1 - 0.06)},
+		{"Same point", 0.52, -3, -3, 0.52},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := oddsmath.AdjustSpreadProbability(tt.fairProb, tt.fromPoint, tt.toPoint, dist)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if math.Abs(got-tt.want) > 0.0001 {
+				t.Errorf("AdjustSpreadProbability() = %f, want %f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdjustSpreadProbability_RoundTrip(t *testing.T) {
+	dist := testMarginDistribution()
+
+	there, err := oddsmath.AdjustSpreadProbability(0.48, -3, -2.5, dist)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	back, err := oddsmath.AdjustSpreadProbability(there, -2.5, -3, dist)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if math.Abs(back-0.48) > 0.0001 {
+		t.Errorf("round trip = %f, want 0.48", back)
+	}
+}
+
+func TestAdjustSpreadProbability_KeyNumberWorthMore(t *testing.T) {
+	dist := testMarginDistribution()
+
+	key, _ := oddsmath.AdjustSpreadProbability(0.50, -3.5, -2.5, dist)
+	dead, _ := oddsmath.AdjustSpreadProbability(0.50, -5.5, -4.5, dist)
+
+	if key-0.50 <= dead-0.50 {
+		t.Errorf("crossing 3 moved %f, crossing 5 moved %f; want key number worth more", key-0.50, dead-0.50)
+	}
+}
+
+func TestAdjustSpreadProbability_InvalidInput(t *testing.T) {
+	dist := testMarginDistribution()
+
+	if _, err := oddsmath.AdjustSpreadProbability(1.2, -3, -2.5, dist); err == nil {
+		t.Error("expected error for invalid probability")
+	}
+
+	// Laying 40 more points than a 10% cover leaves nothing to win
+	if _, err := oddsmath.AdjustSpreadProbability(0.10, 20, -20, dist); err == nil {
+		t.Error("expected error for out-of-range adjustment")
+	}
+}
+
+func TestMoneylineToSpreadProbability(t *testing.T) {
+	dist := testMarginDistribution()
+
+	// At pick'em the only difference is the tie refund: 0.60 × (1 - 0.01)
+	got, err := oddsmath.MoneylineToSpreadProbability(0.60, -0.5, dist)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(got-0.594) > 0.0001 {
+		t.Errorf("MoneylineToSpreadProbability(-0.5) = %f, want 0.594", got)
+	}
+
+	// Giving 3.5 crosses 1, 2 and the 3: 0.594 - 0.01 - 0.01 - 0.095
+	got, err = oddsmath.MoneylineToSpreadProbability(0.60, -3.5, dist)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(got-0.479) > 0.0001 {
+		t.Errorf("MoneylineToSpreadProbability(-3.5) = %f, want 0.479", got)
+	}
+}
+
+func TestImpliedSpread(t *testing.T) {
+	dist := testMarginDistribution()
+
+	tests := []struct {
+		name    string
+		winProb float64
+		want    float64
+	}{
+		{"Pick'em", 0.50, 0},
+		{"Favorite", 0.60, -3.5},
+		{"Underdog", 0.40, 3.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := oddsmath.ImpliedSpread(tt.winProb, dist)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ImpliedSpread(%.2f) = %.1f, want %.1f", tt.winProb, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := oddsmath.ImpliedSpread(1, dist); err == nil {
+		t.Error("expected error for certain win probability")
+	}
+}
@@ -0,0 +1,75 @@
+package pricing_test
+
+import (
+	"testing"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/pricing"
+)
+
+func TestParseMarketVigMethods(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    map[string]map[string]models.VigMethod
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			spec: "",
+			want: map[string]map[string]models.VigMethod{},
+		},
+		{
+			name: "several sports and markets",
+			spec: "basketball_nba:h2h=power, basketball_nba:player_points=odds_ratio,americanfootball_nfl:h2h=additive",
+			want: map[string]map[string]models.VigMethod{
+				"basketball_nba":       {"h2h": models.VigMethodPower, "player_points": models.VigMethodOddsRatio},
+				"americanfootball_nfl": {"h2h": models.VigMethodAdditive},
+			},
+		},
+		{name: "unknown method", spec: "basketball_nba:h2h=fancy", wantErr: true},
+		{name: "missing sport", spec: "h2h=shin", wantErr: true},
+		{name: "missing method", spec: "basketball_nba:h2h", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pricing.ParseMarketVigMethods(tt.spec)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseMarketVigMethods(%q) = %v, want error", tt.spec, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseMarketVigMethods(%q) error: %v", tt.spec, err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseMarketVigMethods(%q) = %v, want %v", tt.spec, got, tt.want)
+			}
+			for sportKey, markets := range tt.want {
+				for marketKey, method := range markets {
+					if got[sportKey][marketKey] != method {
+						t.Errorf("%s %s = %q, want %q", sportKey, marketKey, got[sportKey][marketKey], method)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestMarketRules_SetMarketVigMethods(t *testing.T) {
+	rules := pricing.MarketRules{
+		ThreeWayMarkets: []string{"h2h"},
+		VigMethods:      pricing.DefaultVigMethods(),
+	}
+
+	if got := rules.GetMarketVigMethod("h2h"); got != models.VigMethodShin {
+		t.Errorf("default h2h method = %s, want shin", got)
+	}
+
+	rules.SetMarketVigMethods(map[string]models.VigMethod{"h2h": models.VigMethodPower})
+	if got := rules.GetMarketVigMethod("h2h"); got != models.VigMethodPower {
+		t.Errorf("overridden h2h method = %s, want power", got)
+	}
+}
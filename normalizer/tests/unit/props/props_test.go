@@ -0,0 +1,47 @@
+package props_test
+
+import (
+	"testing"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/props"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		outcomeName string
+		description string
+		want        props.Selection
+	}{
+		{"Player in description", "Over", "LeBron James", props.Selection{Player: "LeBron James", Side: "Over"}},
+		{"Player in outcome name", "LeBron James Under", "", props.Selection{Player: "LeBron James", Side: "Under"}},
+		{"Yes/No prop", "Nikola Jokic Yes", "", props.Selection{Player: "Nikola Jokic", Side: "Yes"}},
+		{"Game total", "Over", "", props.Selection{Side: "Over"}},
+		{"Team outcome", "Los Angeles Lakers", "", props.Selection{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := props.Parse(tt.outcomeName, tt.description)
+			if got != tt.want {
+				t.Errorf("Parse(%q, %q) = %+v, want %+v", tt.outcomeName, tt.description, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelection_IsOpposite(t *testing.T) {
+	over := props.Selection{Player: "LeBron James", Side: "Over"}
+
+	if !over.IsOpposite(props.Selection{Player: "LeBron James", Side: "Under"}) {
+		t.Error("LeBron Over should be opposite LeBron Under")
+	}
+
+	if over.IsOpposite(props.Selection{Player: "Stephen Curry", Side: "Under"}) {
+		t.Error("LeBron Over should not be opposite Curry Under")
+	}
+
+	if over.IsOpposite(props.Selection{Player: "LeBron James", Side: "Over"}) {
+		t.Error("Over should not be opposite itself")
+	}
+}
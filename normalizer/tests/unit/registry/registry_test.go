@@ -0,0 +1,91 @@
+package registry_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/registry"
+)
+
+func writeConfig(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+}
+
+func TestLoadDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, dir, "icehockey_nhl.json", `{"sport_key": "icehockey_nhl", "display_name": "NHL Hockey", "sharp_books": [{"key": "pinnacle"}]}`)
+	writeConfig(t, dir, "basketball_ncaab.json", `{"sport_key": "basketball_ncaab", "display_name": "NCAA Basketball", "sharp_books": [{"key": "pinnacle"}]}`)
+	writeConfig(t, dir, "baseball_mlb.yaml", "sport_key: baseball_mlb\ndisplay_name: MLB Baseball\nsharp_books:\n  - key: pinnacle\n")
+	writeConfig(t, dir, "icehockey_ahl.yml", "sport_key: icehockey_ahl\ndisplay_name: AHL Hockey\nsharp_books: [{key: pinnacle}]\n")
+	writeConfig(t, dir, "README.md", "not a sport config")
+
+	reg := registry.NewNormalizerRegistry()
+	loaded, err := reg.LoadDirectory(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Loaded in file name order, JSON and YAML alike; other files ignored
+	want := []string{"baseball_mlb", "basketball_ncaab", "icehockey_ahl", "icehockey_nhl"}
+	if len(loaded) != len(want) {
+		t.Fatalf("loaded = %v, want %v", loaded, want)
+	}
+	for i := range want {
+		if loaded[i] != want[i] {
+			t.Errorf("loaded = %v, want %v", loaded, want)
+			break
+		}
+	}
+
+	if _, ok := reg.Get("icehockey_nhl"); !ok {
+		t.Error("icehockey_nhl should be registered")
+	}
+}
+
+func TestLoadDirectory_InvalidFileFails(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, dir, "bad.json", `{"display_name": "No Sport Key"}`)
+
+	reg := registry.NewNormalizerRegistry()
+	if _, err := reg.LoadDirectory(dir); err == nil {
+		t.Error("expected error for invalid config")
+	}
+}
+
+func TestLoadDirectory_InvalidYAMLFails(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, dir, "bad.yaml", "sport_key: [unclosed\n")
+
+	reg := registry.NewNormalizerRegistry()
+	if _, err := reg.LoadDirectory(dir); err == nil {
+		t.Error("expected error for invalid YAML config")
+	}
+}
+
+func TestLoadDirectory_DuplicateSportFails(t *testing.T) {
+	dir := t.TempDir()
+	config := `{"sport_key": "icehockey_nhl", "display_name": "NHL Hockey", "sharp_books": [{"key": "pinnacle"}]}`
+	writeConfig(t, dir, "a.json", config)
+	writeConfig(t, dir, "b.json", config)
+
+	reg := registry.NewNormalizerRegistry()
+	if _, err := reg.LoadDirectory(dir); err == nil {
+		t.Error("expected error for duplicate sport key")
+	}
+}
+
+func TestLoadDirectory_ShippedConfigs(t *testing.T) {
+	// Every config in the repo must load
+	reg := registry.NewNormalizerRegistry()
+	loaded, err := reg.LoadDirectory("../../../config/sports")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(loaded) == 0 {
+		t.Error("expected shipped sport configs to load")
+	}
+}
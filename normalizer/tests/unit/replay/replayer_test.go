@@ -0,0 +1,126 @@
+package replay_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/marketstate"
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/processor"
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/publisher"
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/registry"
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/replay"
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/normalizer/sports/basketball_nba"
+	"github.com/XavierBriggs/fortuna/services/normalizer/tests/testutil"
+)
+
+// setupReplay builds a replayer over odds that writes JSON lines to a buffer
+func setupReplay(t *testing.T, odds []models.RawOdds, config marketstate.Config) (*replay.Replayer, *publisher.FilePublisher, *bytes.Buffer) {
+	t.Helper()
+
+	reg := registry.NewNormalizerRegistry()
+	if err := reg.Register(basketball_nba.NewNormalizer()); err != nil {
+		t.Fatalf("failed to register normalizer: %v", err)
+	}
+
+	var buf bytes.Buffer
+	pub := publisher.NewFilePublisher(&buf)
+	clock := replay.NewClock()
+	store := marketstate.NewStoreWithClock(config, clock.Now)
+	proc := processor.NewProcessor(nil, pub, reg, store, processor.Options{})
+
+	return replay.NewReplayer(replay.NewSliceSource(odds), proc, clock), pub, &buf
+}
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []models.NormalizedOdds {
+	t.Helper()
+
+	var out []models.NormalizedOdds
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var normalized models.NormalizedOdds
+		if err := json.Unmarshal([]byte(line), &normalized); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", line, err)
+		}
+		out = append(out, normalized)
+	}
+	return out
+}
+
+func TestReplayer_VendorTimestampOrder(t *testing.T) {
+	base := time.Date(2025, 1, 10, 19, 0, 0, 0, time.UTC)
+
+	pinnacle := testutil.SpreadOdds("pinnacle", "Los Angeles Lakers", -110, -7.5)
+	pinnacle.VendorLastUpdate = base
+	pinnacleOpp := testutil.SpreadOdds("pinnacle", "Boston Celtics", -110, 7.5)
+	pinnacleOpp.VendorLastUpdate = base.Add(time.Second)
+	soft := testutil.SpreadOdds("fanduel", "Los Angeles Lakers", 100, -7.5)
+	soft.VendorLastUpdate = base.Add(2 * time.Second)
+
+	// Stored out of order; replay must still see Pinnacle first
+	replayer, pub, buf := setupReplay(t, []models.RawOdds{soft, pinnacleOpp, pinnacle}, marketstate.DefaultConfig())
+
+	result, err := replayer.Run(context.Background(), replay.Filter{EventID: "test-event-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := pub.Flush(); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+
+	if result.Rows != 3 || result.Published != 3 || result.Errors != 0 {
+		t.Errorf("result = %+v, want 3 rows, 3 published, 0 errors", result)
+	}
+	if !result.From.Equal(base) || !result.To.Equal(soft.VendorLastUpdate) {
+		t.Errorf("range = %v → %v, want %v → %v", result.From, result.To, base, soft.VendorLastUpdate)
+	}
+
+	lines := decodeLines(t, buf)
+	last := lines[len(lines)-1]
+	if last.BookKey != "fanduel" {
+		t.Fatalf("last published book = %s, want fanduel", last.BookKey)
+	}
+	if last.SharpConsensus == nil {
+		t.Error("soft book should see both Pinnacle sides replayed before it")
+	}
+}
+
+func TestReplayer_MarketStateFollowsVendorTime(t *testing.T) {
+	base := time.Date(2025, 1, 10, 19, 0, 0, 0, time.UTC)
+
+	pinnacle := testutil.SpreadOdds("pinnacle", "Los Angeles Lakers", -110, -7.5)
+	pinnacle.VendorLastUpdate = base
+	pinnacleOpp := testutil.SpreadOdds("pinnacle", "Boston Celtics", -110, 7.5)
+	pinnacleOpp.VendorLastUpdate = base
+
+	// Two hours later in vendor time: the sharp market has expired
+	soft := testutil.SpreadOdds("fanduel", "Los Angeles Lakers", 100, -7.5)
+	soft.VendorLastUpdate = base.Add(2 * time.Hour)
+
+	config := marketstate.DefaultConfig()
+	config.TTL = 30 * time.Minute
+	replayer, pub, buf := setupReplay(t, []models.RawOdds{pinnacle, pinnacleOpp, soft}, config)
+
+	if _, err := replayer.Run(context.Background(), replay.Filter{EventID: "test-event-1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := pub.Flush(); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+
+	lines := decodeLines(t, buf)
+	if lines[len(lines)-1].SharpConsensus != nil {
+		t.Error("sharp quotes older than the TTL in vendor time should not price the soft book")
+	}
+}
+
+func TestReplayer_FilterRequired(t *testing.T) {
+	replayer, _, _ := setupReplay(t, nil, marketstate.DefaultConfig())
+
+	if _, err := replayer.Run(context.Background(), replay.Filter{SportKey: "basketball_nba"}); err == nil {
+		t.Error("expected error without an event or time range")
+	}
+}
@@ -0,0 +1,154 @@
+package sports_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/normalizer/sports/generic"
+	"github.com/XavierBriggs/fortuna/services/normalizer/tests/testutil"
+)
+
+const nhlConfig = `{
+  "sport_key": "icehockey_nhl",
+  "display_name": "NHL Hockey",
+  "sharp_books": [{"key": "pinnacle", "weight": 1.0}, {"key": "circa", "weight": 0.8}],
+  "consensus_half_life": "30s",
+  "vig_methods": {"three_way": "shin"},
+  "markets": {
+    "h2h": {"type": "three_way"},
+    "spreads": {"type": "two_way"},
+    "totals": {"type": "two_way", "vig_method": "power"}
+  },
+  "min_edge_for_alert": 0.015
+}`
+
+func TestGenericConfig_Parse(t *testing.T) {
+	config, err := generic.ParseConfig([]byte(nhlConfig))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	normalizer := generic.NewNormalizer(config)
+
+	if normalizer.GetSportKey() != "icehockey_nhl" || normalizer.GetDisplayName() != "NHL Hockey" {
+		t.Errorf("got %s / %s", normalizer.GetSportKey(), normalizer.GetDisplayName())
+	}
+
+	tests := []struct {
+		marketKey  string
+		wantType   models.MarketType
+		wantMethod models.VigMethod
+	}{
+		{"h2h", models.MarketTypeThreeWay, models.VigMethodShin},
+		{"spreads", models.MarketTypeTwoWay, models.VigMethodMultiplicative}, // default
+		{"totals", models.MarketTypeTwoWay, models.VigMethodPower},           // per-market override
+		{"player_goals", models.MarketTypeProps, models.VigMethodMultiplicative},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.marketKey, func(t *testing.T) {
+			if got := normalizer.GetMarketType(tt.marketKey); got != tt.wantType {
+				t.Errorf("GetMarketType(%s) = %v, want %v", tt.marketKey, got, tt.wantType)
+			}
+			if got := config.GetMarketVigMethod(tt.marketKey); got != tt.wantMethod {
+				t.Errorf("GetMarketVigMethod(%s) = %v, want %v", tt.marketKey, got, tt.wantMethod)
+			}
+		})
+	}
+
+	if config.GetConsensusHalfLife() != 30*time.Second {
+		t.Errorf("ConsensusHalfLife = %v, want 30s", config.GetConsensusHalfLife())
+	}
+	if config.GetMaxSharpQuoteAge() != 10*time.Minute {
+		t.Errorf("MaxSharpQuoteAge = %v, want default 10m", config.GetMaxSharpQuoteAge())
+	}
+	if config.GetSharpBookWeight("circa") != 0.8 {
+		t.Errorf("circa weight = %f, want 0.8", config.GetSharpBookWeight("circa"))
+	}
+}
+
+func TestGenericConfig_ParseYAML(t *testing.T) {
+	config, err := generic.ParseYAMLConfig([]byte(`
+sport_key: icehockey_nhl
+display_name: NHL Hockey
+sharp_books:
+  - key: pinnacle
+    weight: 1.0
+  - key: circa
+    weight: 0.8
+consensus_half_life: 30s
+vig_methods:
+  three_way: shin
+markets:
+  h2h: {type: three_way}
+  totals: {type: two_way, vig_method: power}
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if config.SportKey != "icehockey_nhl" || config.GetConsensusHalfLife() != 30*time.Second {
+		t.Errorf("got %s with half-life %v", config.SportKey, config.GetConsensusHalfLife())
+	}
+	if got := config.GetMarketVigMethod("totals"); got != models.VigMethodPower {
+		t.Errorf("GetMarketVigMethod(totals) = %v, want power", got)
+	}
+	if config.GetSharpBookWeight("circa") != 0.8 {
+		t.Errorf("circa weight = %f, want 0.8", config.GetSharpBookWeight("circa"))
+	}
+
+	// Same strictness as JSON
+	if _, err := generic.ParseYAMLConfig([]byte("sport_key: x\ndisplay_name: X\nsharp_books: [{key: pinnacle}]\nsharp_book: circa\n")); err == nil {
+		t.Error("expected error for unknown field")
+	}
+}
+
+func TestGenericConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+	}{
+		{"Missing sport key", `{"display_name": "NHL", "sharp_books": [{"key": "pinnacle"}]}`},
+		{"No sharp books", `{"sport_key": "icehockey_nhl", "display_name": "NHL"}`},
+		{"Unknown market type", `{"sport_key": "x", "display_name": "X", "sharp_books": [{"key": "pinnacle"}], "markets": {"h2h": {"type": "four_way"}}}`},
+		{"Unknown vig method", `{"sport_key": "x", "display_name": "X", "sharp_books": [{"key": "pinnacle"}], "vig_methods": {"two_way": "magic"}}`},
+		{"Unknown field", `{"sport_key": "x", "display_name": "X", "sharp_books": [{"key": "pinnacle"}], "sharp_book": "circa"}`},
+		{"Bad duration", `{"sport_key": "x", "display_name": "X", "sharp_books": [{"key": "pinnacle"}], "consensus_half_life": "soon"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := generic.ParseConfig([]byte(tt.config)); err == nil {
+				t.Error("expected error but got none")
+			}
+		})
+	}
+}
+
+func TestGenericNormalizer_Normalize(t *testing.T) {
+	config, err := generic.ParseConfig([]byte(nhlConfig))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	normalizer := generic.NewNormalizer(config)
+
+	soft := testutil.SpreadOdds("fanduel", "Boston Bruins", 150, -1.5)
+	soft.SportKey = "icehockey_nhl"
+	pinnacle := testutil.SpreadOdds("pinnacle", "Boston Bruins", 140, -1.5)
+	pinnacleOpp := testutil.SpreadOdds("pinnacle", "Toronto Maple Leafs", -160, 1.5)
+
+	normalized, err := normalizer.Normalize(context.Background(), soft, []models.RawOdds{soft, pinnacle, pinnacleOpp})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if normalized.SharpConsensus == nil {
+		t.Fatal("SharpConsensus should not be nil")
+	}
+
+	if normalized.Edge == nil || *normalized.Edge <= 0 {
+		t.Errorf("Edge = %v, want positive for +150 vs sharp +140", normalized.Edge)
+	}
+}
@@ -3,7 +3,9 @@ package sports_test
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/XavierBriggs/fortuna/services/normalizer/internal/marketstate"
 	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
 	"github.com/XavierBriggs/fortuna/services/normalizer/sports/basketball_nba"
 	"github.com/XavierBriggs/fortuna/services/normalizer/tests/testutil"
@@ -89,8 +91,8 @@ func TestNBANormalizer_GetVigMethod(t *testing.T) {
 		want       models.VigMethod
 	}{
 		{models.MarketTypeTwoWay, models.VigMethodMultiplicative},
-		{models.MarketTypeThreeWay, models.VigMethodAdditive},
-		{models.MarketTypeProps, models.VigMethodNone},
+		{models.MarketTypeThreeWay, models.VigMethodShin},
+		{models.MarketTypeProps, models.VigMethodMultiplicative},
 	}
 
 	for _, tt := range tests {
@@ -227,9 +229,9 @@ func TestNBANormalizer_NormalizeMoneyline(t *testing.T) {
 		t.Errorf("MarketType = %s, want three_way", normalized.MarketType)
 	}
 
-	// Verify vig method
-	if normalized.VigMethod != "additive" {
-		t.Errorf("VigMethod = %s, want additive", normalized.VigMethod)
+	// Verify vig method (h2h defaults to shin)
+	if normalized.VigMethod != "shin" {
+		t.Errorf("VigMethod = %s, want shin", normalized.VigMethod)
 	}
 }
 
@@ -257,8 +259,16 @@ func TestNBANormalizer_NormalizePlayerProp(t *testing.T) {
 	}
 
 	// Verify vig method
-	if normalized.VigMethod != "none" {
-		t.Errorf("VigMethod = %s, want none", normalized.VigMethod)
+	if normalized.VigMethod != "multiplicative" {
+		t.Errorf("VigMethod = %s, want multiplicative", normalized.VigMethod)
+	}
+
+	// Over/Under pair is devigged like a total
+	if normalized.NoVigProbability == nil {
+		t.Fatal("NoVigProbability should not be nil for a two-sided prop")
+	}
+	if *normalized.NoVigProbability < 0.49 || *normalized.NoVigProbability > 0.51 {
+		t.Errorf("NoVigProbability = %f, want ~0.50", *normalized.NoVigProbability)
 	}
 }
 
@@ -282,3 +292,180 @@ func TestNBANormalizer_MissingOppositeSide(t *testing.T) {
 	}
 }
 
+
+func TestNBANormalizer_MarketVigMethodOverride(t *testing.T) {
+	config := basketball_nba.DefaultConfig()
+	config.SetMarketVigMethods(map[string]models.VigMethod{"h2h": models.VigMethodPower})
+	normalizer := basketball_nba.NewNormalizerWithConfig(config)
+	ctx := context.Background()
+
+	lakersML := testutil.MoneylineOdds("fanduel", "Los Angeles Lakers", -150)
+	celticsML := testutil.MoneylineOdds("fanduel", "Boston Celtics", 130)
+
+	normalized, err := normalizer.Normalize(ctx, lakersML, []models.RawOdds{lakersML, celticsML})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if normalized.VigMethod != "power" {
+		t.Errorf("VigMethod = %s, want power", normalized.VigMethod)
+	}
+
+	if normalized.NoVigProbability == nil {
+		t.Fatal("NoVigProbability should not be nil when both sides are priced")
+	}
+
+	// Favorite's fair probability sits between 50% and its implied 60%
+	if *normalized.NoVigProbability < 0.5 || *normalized.NoVigProbability > normalized.ImpliedProbability {
+		t.Errorf("NoVigProbability = %f, want between 0.5 and %f", *normalized.NoVigProbability, normalized.ImpliedProbability)
+	}
+}
+
+func TestNBANormalizer_SharpConsensusDevigsAndDecays(t *testing.T) {
+	normalizer := basketball_nba.NewNormalizer()
+	ctx := context.Background()
+	now := time.Now()
+
+	soft := testutil.SpreadOdds("fanduel", "Los Angeles Lakers", 100, -7.5)
+	soft.VendorLastUpdate = now
+
+	// Pinnacle: fresh, balanced -110/-110 → 50% fair
+	pinnacle := testutil.SpreadOdds("pinnacle", "Los Angeles Lakers", -110, -7.5)
+	pinnacleOpp := testutil.SpreadOdds("pinnacle", "Boston Celtics", -110, 7.5)
+	pinnacle.VendorLastUpdate = now
+	pinnacleOpp.VendorLastUpdate = now
+
+	// Circa: 4 minutes stale at -150/+130 → ~58% fair, should barely count
+	circa := testutil.SpreadOdds("circa", "Los Angeles Lakers", -150, -7.5)
+	circaOpp := testutil.SpreadOdds("circa", "Boston Celtics", 130, 7.5)
+	circa.VendorLastUpdate = now.Add(-4 * time.Minute)
+	circaOpp.VendorLastUpdate = now.Add(-4 * time.Minute)
+
+	// Bookmaker: one-sided, still carries vig → excluded
+	bookmaker := testutil.SpreadOdds("bookmaker", "Los Angeles Lakers", -200, -7.5)
+
+	marketOdds := []models.RawOdds{soft, pinnacle, pinnacleOpp, circa, circaOpp, bookmaker}
+
+	normalized, err := normalizer.Normalize(ctx, soft, marketOdds)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if normalized.SharpConsensus == nil {
+		t.Fatal("SharpConsensus should not be nil")
+	}
+
+	if normalized.SharpBookCount != 2 {
+		t.Errorf("SharpBookCount = %d, want 2 (one-sided bookmaker excluded)", normalized.SharpBookCount)
+	}
+
+	// Stale Circa is decayed to ~5% of Pinnacle's weight
+	if *normalized.SharpConsensus < 0.50 || *normalized.SharpConsensus > 0.51 {
+		t.Errorf("SharpConsensus = %f, want ~0.50 (dominated by fresh Pinnacle)", *normalized.SharpConsensus)
+	}
+
+	if normalized.SharpDispersion == nil || *normalized.SharpDispersion <= 0 {
+		t.Error("SharpDispersion should be positive when sharp books disagree")
+	}
+}
+
+func TestNBANormalizer_PropsGroupedByPlayerAndPoint(t *testing.T) {
+	normalizer := basketball_nba.NewNormalizer()
+	ctx := context.Background()
+
+	lebronOver := testutil.PlayerPropOdds("fanduel", "LeBron James", "Over", -130, 25.5)
+	lebronUnder := testutil.PlayerPropOdds("fanduel", "LeBron James", "Under", 110, 25.5)
+	lebronAltOver := testutil.PlayerPropOdds("fanduel", "LeBron James", "Over", 150, 22.5)
+	lebronAltUnder := testutil.PlayerPropOdds("fanduel", "LeBron James", "Under", -200, 22.5)
+	curryUnder := testutil.PlayerPropOdds("fanduel", "Stephen Curry", "Under", -300, 25.5)
+
+	// Market state is built the way the processor builds it, so every line the
+	// book posts for the player has to survive alongside the others
+	store := marketstate.NewStore(marketstate.DefaultConfig())
+	for _, odds := range []models.RawOdds{lebronOver, curryUnder, lebronAltUnder, lebronUnder, lebronAltOver} {
+		store.Upsert(odds)
+	}
+
+	if got := len(store.Get(lebronOver)); got != 5 {
+		t.Fatalf("market state holds %d quotes, want 5", got)
+	}
+
+	// Only LeBron's Under at the same line may pair with LeBron's Over
+	normalized, err := normalizer.Normalize(ctx, lebronOver, store.Get(lebronOver))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if normalized.NoVigProbability == nil {
+		t.Fatal("NoVigProbability should not be nil")
+	}
+
+	// -130/+110 → 56.5% / 47.6% → ~54.3% fair on the Over
+	if *normalized.NoVigProbability < 0.54 || *normalized.NoVigProbability > 0.55 {
+		t.Errorf("NoVigProbability = %f, want ~0.543 (paired with wrong side?)", *normalized.NoVigProbability)
+	}
+
+	// The alternate line pairs with its own Over
+	normalized, err = normalizer.Normalize(ctx, lebronAltUnder, store.Get(lebronAltUnder))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// -200/+150 → 66.7% / 40.0% → ~62.5% fair on the Under
+	if normalized.NoVigProbability == nil || *normalized.NoVigProbability < 0.62 || *normalized.NoVigProbability > 0.63 {
+		t.Errorf("alt NoVigProbability = %v, want ~0.625", normalized.NoVigProbability)
+	}
+}
+
+func TestNBANormalizer_PropsSharpFallback(t *testing.T) {
+	normalizer := basketball_nba.NewNormalizer()
+	ctx := context.Background()
+
+	softOver := testutil.PlayerPropOdds("fanduel", "LeBron James", "Over", -110, 25.5)
+	softUnder := testutil.PlayerPropOdds("fanduel", "LeBron James", "Under", -110, 25.5)
+
+	// Pinnacle only hangs the Over → can't be devigged, so no consensus
+	pinnacleOver := testutil.PlayerPropOdds("pinnacle", "LeBron James", "Over", -150, 25.5)
+
+	normalized, err := normalizer.Normalize(ctx, softOver, []models.RawOdds{softOver, softUnder, pinnacleOver})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if normalized.SharpConsensus != nil {
+		t.Errorf("SharpConsensus = %f, want nil for one-sided sharp prop", *normalized.SharpConsensus)
+	}
+
+	// Falls back to FanDuel's own two-sided price
+	if normalized.NoVigProbability == nil || *normalized.NoVigProbability < 0.49 || *normalized.NoVigProbability > 0.51 {
+		t.Errorf("NoVigProbability = %v, want ~0.50 from book's own pair", normalized.NoVigProbability)
+	}
+
+	// Once Pinnacle hangs both sides, its devigged price becomes the consensus
+	pinnacleUnder := testutil.PlayerPropOdds("pinnacle", "LeBron James", "Under", 130, 25.5)
+	normalized, err = normalizer.Normalize(ctx, softOver, []models.RawOdds{softOver, softUnder, pinnacleOver, pinnacleUnder})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if normalized.SharpConsensus == nil || *normalized.SharpConsensus < 0.57 || *normalized.SharpConsensus > 0.59 {
+		t.Errorf("SharpConsensus = %v, want ~0.58", normalized.SharpConsensus)
+	}
+}
+
+func TestNBANormalizer_TotalsPairOverUnder(t *testing.T) {
+	normalizer := basketball_nba.NewNormalizer()
+	ctx := context.Background()
+
+	over := testutil.TotalOdds("fanduel", "Over", -110, 220.5)
+	under := testutil.TotalOdds("fanduel", "Under", -110, 220.5)
+
+	normalized, err := normalizer.Normalize(ctx, over, []models.RawOdds{over, under})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if normalized.NoVigProbability == nil {
+		t.Error("NoVigProbability should not be nil for Over/Under at the same total")
+	}
+}
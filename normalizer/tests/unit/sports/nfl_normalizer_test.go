@@ -0,0 +1,142 @@
+package sports_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/normalizer/sports/americanfootball_nfl"
+	"github.com/XavierBriggs/fortuna/services/normalizer/tests/testutil"
+)
+
+func nflSpread(bookKey string, outcome string, price int, point float64) models.RawOdds {
+	odds := testutil.SpreadOdds(bookKey, outcome, price, point)
+	odds.SportKey = "americanfootball_nfl"
+	return odds
+}
+
+func TestNFLNormalizer_GetSportKey(t *testing.T) {
+	normalizer := americanfootball_nfl.NewNormalizer()
+
+	if got := normalizer.GetSportKey(); got != "americanfootball_nfl" {
+		t.Errorf("GetSportKey() = %s, want americanfootball_nfl", got)
+	}
+
+	if got := normalizer.GetDisplayName(); got != "NFL Football" {
+		t.Errorf("GetDisplayName() = %s, want NFL Football", got)
+	}
+}
+
+func TestNFLNormalizer_GetMarketType(t *testing.T) {
+	normalizer := americanfootball_nfl.NewNormalizer()
+
+	tests := []struct {
+		marketKey string
+		want      models.MarketType
+	}{
+		{"spreads", models.MarketTypeTwoWay},
+		{"alternate_spreads", models.MarketTypeTwoWay},
+		{"totals", models.MarketTypeTwoWay},
+		{"h2h", models.MarketTypeThreeWay},
+		{"player_pass_yds", models.MarketTypeProps},
+		{"player_anytime_td", models.MarketTypeProps},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.marketKey, func(t *testing.T) {
+			if got := normalizer.GetMarketType(tt.marketKey); got != tt.want {
+				t.Errorf("GetMarketType(%s) = %v, want %v", tt.marketKey, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNFLNormalizer_MoneylineUsesShin(t *testing.T) {
+	normalizer := americanfootball_nfl.NewNormalizer()
+
+	chiefs := testutil.MoneylineOdds("fanduel", "Kansas City Chiefs", -250)
+	chiefs.SportKey = "americanfootball_nfl"
+	raiders := testutil.MoneylineOdds("fanduel", "Las Vegas Raiders", 205)
+	raiders.SportKey = "americanfootball_nfl"
+
+	normalized, err := normalizer.Normalize(context.Background(), chiefs, []models.RawOdds{chiefs, raiders})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if normalized.VigMethod != "shin" {
+		t.Errorf("VigMethod = %s, want shin", normalized.VigMethod)
+	}
+	if normalized.NoVigProbability == nil {
+		t.Fatal("NoVigProbability should not be nil when both sides are priced")
+	}
+}
+
+func TestNFLNormalizer_SameKeyNumberSpread(t *testing.T) {
+	normalizer := americanfootball_nfl.NewNormalizer()
+	ctx := context.Background()
+
+	soft := nflSpread("draftkings", "Kansas City Chiefs", -105, -3)
+	pinnacle := nflSpread("pinnacle", "Kansas City Chiefs", -110, -3)
+	pinnacleOpp := nflSpread("pinnacle", "Buffalo Bills", -110, 3)
+
+	normalized, err := normalizer.Normalize(ctx, soft, []models.RawOdds{soft, pinnacle, pinnacleOpp})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if normalized.SharpConsensus == nil {
+		t.Fatal("SharpConsensus should not be nil")
+	}
+
+	if math.Abs(*normalized.SharpConsensus-0.50) > 0.0001 {
+		t.Errorf("SharpConsensus = %f, want 0.50", *normalized.SharpConsensus)
+	}
+}
+
+func TestNFLNormalizer_SpreadAcrossKeyNumber(t *testing.T) {
+	normalizer := americanfootball_nfl.NewNormalizer()
+	ctx := context.Background()
+
+	// Soft book hangs -2.5 at -120 while Pinnacle is -3 -110/-110
+	// Getting off the 3 is worth ~9.5%, so -2.5 at -120 is still +EV
+	soft := nflSpread("draftkings", "Kansas City Chiefs", -120, -2.5)
+	pinnacle := nflSpread("pinnacle", "Kansas City Chiefs", -110, -3)
+	pinnacleOpp := nflSpread("pinnacle", "Buffalo Bills", -110, 3)
+
+	normalized, err := normalizer.Normalize(ctx, soft, []models.RawOdds{soft, pinnacle, pinnacleOpp})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if normalized.SharpConsensus == nil {
+		t.Fatal("SharpConsensus should translate Pinnacle -3 to -2.5")
+	}
+
+	if *normalized.SharpConsensus < 0.54 || *normalized.SharpConsensus > 0.55 {
+		t.Errorf("SharpConsensus = %f, want ~0.5475", *normalized.SharpConsensus)
+	}
+
+	if normalized.Edge == nil || *normalized.Edge <= 0 {
+		t.Errorf("Edge = %v, want positive for -2.5 at -120 vs sharp -3", normalized.Edge)
+	}
+}
+
+func TestNFLNormalizer_TotalsNotTranslated(t *testing.T) {
+	normalizer := americanfootball_nfl.NewNormalizer()
+	ctx := context.Background()
+
+	soft := testutil.TotalOdds("draftkings", "Over", -110, 44.5)
+	pinnacle := testutil.TotalOdds("pinnacle", "Over", -110, 45.5)
+	pinnacleOpp := testutil.TotalOdds("pinnacle", "Under", -110, 45.5)
+
+	normalized, err := normalizer.Normalize(ctx, soft, []models.RawOdds{soft, pinnacle, pinnacleOpp})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if normalized.SharpConsensus != nil {
+		t.Errorf("SharpConsensus = %f, want nil (totals need the same line)", *normalized.SharpConsensus)
+	}
+}
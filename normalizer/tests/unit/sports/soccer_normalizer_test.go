@@ -0,0 +1,171 @@
+package sports_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/XavierBriggs/fortuna/services/normalizer/pkg/models"
+	"github.com/XavierBriggs/fortuna/services/normalizer/sports/soccer"
+	"github.com/XavierBriggs/fortuna/services/normalizer/tests/testutil"
+)
+
+func soccerOdds(marketKey string, bookKey string, outcome string, price int) models.RawOdds {
+	return testutil.RawOddsFixture(func(o *models.RawOdds) {
+		o.SportKey = "soccer_epl"
+		o.MarketKey = marketKey
+		o.BookKey = bookKey
+		o.OutcomeName = outcome
+		o.Price = price
+		o.Point = nil
+	})
+}
+
+func soccerHandicap(bookKey string, outcome string, price int, point float64) models.RawOdds {
+	odds := soccerOdds("spreads", bookKey, outcome, price)
+	odds.Point = &point
+	return odds
+}
+
+func TestSoccerNormalizer_Leagues(t *testing.T) {
+	tests := []struct {
+		normalizer *soccer.Normalizer
+		sportKey   string
+	}{
+		{soccer.NewEPLNormalizer(), "soccer_epl"},
+		{soccer.NewMLSNormalizer(), "soccer_usa_mls"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.normalizer.GetSportKey(); got != tt.sportKey {
+			t.Errorf("GetSportKey() = %s, want %s", got, tt.sportKey)
+		}
+		if got := tt.normalizer.GetMarketType("double_chance"); got != models.MarketTypeDoubleChance {
+			t.Errorf("GetMarketType(double_chance) = %v, want double_chance", got)
+		}
+		if got := tt.normalizer.GetMarketType("draw_no_bet"); got != models.MarketTypeTwoWay {
+			t.Errorf("GetMarketType(draw_no_bet) = %v, want two_way", got)
+		}
+	}
+}
+
+func TestSoccerNormalizer_ThreeWayH2H(t *testing.T) {
+	normalizer := soccer.NewEPLNormalizer()
+	ctx := context.Background()
+
+	home := soccerOdds("h2h", "bet365", "Arsenal", 110)
+	draw := soccerOdds("h2h", "bet365", "Draw", 240)
+	away := soccerOdds("h2h", "bet365", "Chelsea", 250)
+	marketOdds := []models.RawOdds{home, draw, away}
+
+	total := 0.0
+	for _, odds := range marketOdds {
+		normalized, err := normalizer.Normalize(ctx, odds, marketOdds)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if normalized.NoVigProbability == nil {
+			t.Fatalf("%s: NoVigProbability should be set when all three outcomes are priced", odds.OutcomeName)
+		}
+		total += *normalized.NoVigProbability
+	}
+
+	// Shin devig on each outcome independently still sums to one
+	if math.Abs(total-1.0) > 0.0001 {
+		t.Errorf("fair Home/Draw/Away sum to %f, want 1.0", total)
+	}
+}
+
+func TestSoccerNormalizer_ThreeWayRequiresDraw(t *testing.T) {
+	normalizer := soccer.NewEPLNormalizer()
+	ctx := context.Background()
+
+	// Draw missing: devigging two outcomes would overstate both teams
+	home := soccerOdds("h2h", "bet365", "Arsenal", 110)
+	away := soccerOdds("h2h", "bet365", "Chelsea", 250)
+
+	normalized, err := normalizer.Normalize(ctx, home, []models.RawOdds{home, away})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if normalized.NoVigProbability != nil {
+		t.Errorf("NoVigProbability = %f, want nil without a draw price", *normalized.NoVigProbability)
+	}
+}
+
+func TestSoccerNormalizer_DrawNoBet(t *testing.T) {
+	normalizer := soccer.NewEPLNormalizer()
+	ctx := context.Background()
+
+	home := soccerOdds("draw_no_bet", "bet365", "Arsenal", -150)
+	away := soccerOdds("draw_no_bet", "bet365", "Chelsea", 120)
+
+	normalized, err := normalizer.Normalize(ctx, home, []models.RawOdds{home, away})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if normalized.NoVigProbability == nil {
+		t.Fatal("NoVigProbability should be set for draw no bet")
+	}
+
+	// 0.60 / (0.60 + 0.4545)
+	if math.Abs(*normalized.NoVigProbability-0.569) > 0.001 {
+		t.Errorf("NoVigProbability = %f, want ~0.569", *normalized.NoVigProbability)
+	}
+}
+
+func TestSoccerNormalizer_DoubleChance(t *testing.T) {
+	normalizer := soccer.NewEPLNormalizer()
+	ctx := context.Background()
+
+	homeOrDraw := soccerOdds("double_chance", "bet365", "Arsenal/Draw", -333)
+	homeOrAway := soccerOdds("double_chance", "bet365", "Arsenal/Chelsea", -400)
+	drawOrAway := soccerOdds("double_chance", "bet365", "Draw/Chelsea", -125)
+	marketOdds := []models.RawOdds{homeOrDraw, homeOrAway, drawOrAway}
+
+	normalized, err := normalizer.Normalize(ctx, homeOrDraw, marketOdds)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if normalized.NoVigProbability == nil {
+		t.Fatal("NoVigProbability should be set for double chance")
+	}
+
+	if *normalized.NoVigProbability >= normalized.ImpliedProbability {
+		t.Errorf("NoVigProbability = %f, want below implied %f", *normalized.NoVigProbability, normalized.ImpliedProbability)
+	}
+}
+
+func TestSoccerNormalizer_QuarterLineVsSharpHalfLine(t *testing.T) {
+	normalizer := soccer.NewEPLNormalizer()
+	ctx := context.Background()
+
+	// Pinnacle Arsenal -0.5 -105/-105 → 50% fair
+	pinnacle := soccerHandicap("pinnacle", "Arsenal", -105, -0.5)
+	pinnacleOpp := soccerHandicap("pinnacle", "Chelsea", -105, 0.5)
+
+	// Soft book hangs the quarter line -0.25 at the same price
+	soft := soccerHandicap("bet365", "Arsenal", -105, -0.25)
+
+	normalized, err := normalizer.Normalize(ctx, soft, []models.RawOdds{soft, pinnacle, pinnacleOpp})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if normalized.SharpConsensus == nil {
+		t.Fatal("SharpConsensus should translate -0.5 to -0.25")
+	}
+
+	// Half the stake is refunded on a draw, so -0.25 is worth more than -0.5
+	if *normalized.SharpConsensus <= 0.50 {
+		t.Errorf("SharpConsensus = %f, want > 0.50", *normalized.SharpConsensus)
+	}
+
+	if normalized.Edge == nil || *normalized.Edge <= 0 {
+		t.Errorf("Edge = %v, want positive for -0.25 at the -0.5 price", normalized.Edge)
+	}
+}
@@ -2,7 +2,9 @@ module github.com/XavierBriggs/fortuna/services/settlement-service
 
 go 1.23
 
-require github.com/lib/pq v1.10.9
-
-
+require (
+	github.com/XavierBriggs/fortuna/services/shared v0.0.0
+	github.com/lib/pq v1.10.9
+)
 
+replace github.com/XavierBriggs/fortuna/services/shared => ../shared
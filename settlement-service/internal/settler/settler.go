@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"net/http"
 	"time"
+
+	"github.com/XavierBriggs/fortuna/services/shared/odds"
 )
 
 // Settler handles bet settlement
@@ -274,7 +276,7 @@ func (s *Settler) settleMoneyline(bet Bet, scores *EventScore, homeScore, awaySc
 	}
 
 	if bet.OutcomeName == winner {
-		payout := bet.StakeAmount * americanToDecimal(bet.BetPrice)
+		payout := bet.StakeAmount * odds.AmericanToDecimal(bet.BetPrice)
 		return "win", payout
 	}
 
@@ -293,7 +295,7 @@ func (s *Settler) settleSpread(bet Bet, scores *EventScore, homeScore, awayScore
 	if bet.OutcomeName == scores.HomeTeam {
 		adjustedScore = float64(homeScore) + spread
 		if adjustedScore > float64(awayScore) {
-			return "win", bet.StakeAmount * americanToDecimal(bet.BetPrice)
+			return "win", bet.StakeAmount * odds.AmericanToDecimal(bet.BetPrice)
 		} else if adjustedScore == float64(awayScore) {
 			return "push", bet.StakeAmount
 		}
@@ -301,7 +303,7 @@ func (s *Settler) settleSpread(bet Bet, scores *EventScore, homeScore, awayScore
 	} else {
 		adjustedScore = float64(awayScore) + spread
 		if adjustedScore > float64(homeScore) {
-			return "win", bet.StakeAmount * americanToDecimal(bet.BetPrice)
+			return "win", bet.StakeAmount * odds.AmericanToDecimal(bet.BetPrice)
 		} else if adjustedScore == float64(homeScore) {
 			return "push", bet.StakeAmount
 		}
@@ -319,14 +321,14 @@ func (s *Settler) settleTotal(bet Bet, homeScore, awayScore int) (string, float6
 
 	if bet.OutcomeName == "Over" {
 		if totalPoints > line {
-			return "win", bet.StakeAmount * americanToDecimal(bet.BetPrice)
+			return "win", bet.StakeAmount * odds.AmericanToDecimal(bet.BetPrice)
 		} else if totalPoints == line {
 			return "push", bet.StakeAmount
 		}
 		return "loss", 0.0
 	} else { // Under
 		if totalPoints < line {
-			return "win", bet.StakeAmount * americanToDecimal(bet.BetPrice)
+			return "win", bet.StakeAmount * odds.AmericanToDecimal(bet.BetPrice)
 		} else if totalPoints == line {
 			return "push", bet.StakeAmount
 		}
@@ -344,11 +346,3 @@ func (s *Settler) updateBetResult(ctx context.Context, betID int64, result strin
 	_, err := s.holocronDB.ExecContext(ctx, query, result, payout, betID)
 	return err
 }
-
-func americanToDecimal(american int) float64 {
-	if american > 0 {
-		return (float64(american) / 100.0) + 1.0
-	}
-	return (100.0 / float64(-american)) + 1.0
-}
-
@@ -0,0 +1,55 @@
+package odds
+
+import "math"
+
+// AmericanToDecimal converts American odds to decimal odds
+// American +150 → Decimal 2.50
+// American -150 → Decimal 1.67
+// The price must be >= +100 or <= -100; validate untrusted prices with FromAmerican.
+func AmericanToDecimal(american int) float64 {
+	if american > 0 {
+		// Positive odds: (american / 100) + 1
+		return (float64(american) / 100.0) + 1.0
+	}
+
+	// Negative odds: (100 / abs(american)) + 1
+	return (100.0 / float64(-american)) + 1.0
+}
+
+// DecimalToAmerican converts decimal odds to American odds, rounded to the nearest whole number
+// Decimal 2.50 → American +150
+// Decimal 1.50 → American -200
+// The price must be > 1.0; validate untrusted prices with Parse.
+func DecimalToAmerican(decimal float64) int {
+	if decimal >= 2.0 {
+		// Positive odds: (decimal - 1) * 100
+		return int(math.Round((decimal - 1.0) * 100.0))
+	}
+
+	// Negative odds: -100 / (decimal - 1)
+	return int(math.Round(-100.0 / (decimal - 1.0)))
+}
+
+// AmericanToImpliedProbability converts American odds to implied probability (1 / decimal)
+// American -110 → 0.5238
+func AmericanToImpliedProbability(american int) float64 {
+	return 1.0 / AmericanToDecimal(american)
+}
+
+// PriceMoveCents returns how many cents a price moved, positive when it shortened
+// Cents are measured across the even-money gap, so -105 → +105 is 10 cents:
+// -110 → -120 = +10 (shortened), +150 → +170 = -20 (lengthened)
+func PriceMoveCents(from, to int) int {
+	return centsValue(from) - centsValue(to)
+}
+
+// centsValue places American odds on a continuous cents scale (-110 → -10, +105 → +5)
+func centsValue(american int) int {
+	if american >= 100 {
+		return american - 100
+	}
+	if american <= -100 {
+		return american + 100
+	}
+	return 0
+}
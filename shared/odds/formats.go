@@ -0,0 +1,341 @@
+package odds
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Format is the odds format a price is quoted or displayed in
+type Format string
+
+const (
+	American   Format = "american"   // +150, -110
+	Decimal    Format = "decimal"    // 2.50, 1.91
+	Fractional Format = "fractional" // 3/2, 10/11
+	HongKong   Format = "hongkong"   // 1.50, 0.91 (profit per unit staked)
+	Malay      Format = "malay"      // 0.91, -0.67 (negative for underdogs)
+	Indonesian Format = "indonesian" // 1.50, -1.10 (negative for favorites)
+)
+
+// displayPlaces is the decimal places shown for formats that don't terminate (10/11 → 0.91)
+const displayPlaces = 2
+
+var ratOne = big.NewRat(1, 1)
+
+// ParseFormat validates a user-supplied format name (case-insensitive, empty = American)
+func ParseFormat(value string) (Format, error) {
+	switch format := Format(strings.ToLower(strings.TrimSpace(value))); format {
+	case "":
+		return American, nil
+	case American, Decimal, Fractional, HongKong, Malay, Indonesian:
+		return format, nil
+	case "hong_kong", "hk":
+		return HongKong, nil
+	default:
+		return "", fmt.Errorf("unsupported odds format %q (american, decimal, fractional, hongkong, malay, indonesian)", value)
+	}
+}
+
+// Odds is an exact price, held as the profit per unit staked (decimal odds - 1)
+// Every supported format is a rational function of that ratio, so converting
+// between formats never loses precision:
+//
+// American -110 → 10/11 → Decimal 21/11, Fractional 10/11, Malay 10/11, Indonesian -11/10
+type Odds struct {
+	ratio *big.Rat
+}
+
+// FromAmerican converts American odds to an exact price
+// +150 → 3/2, -110 → 10/11
+func FromAmerican(american int) (Odds, error) {
+	switch {
+	case american >= 100:
+		return Odds{ratio: big.NewRat(int64(american), 100)}, nil
+	case american <= -100:
+		return Odds{ratio: big.NewRat(100, int64(-american))}, nil
+	default:
+		return Odds{}, fmt.Errorf("invalid American odds %d: must be >= +100 or <= -100", american)
+	}
+}
+
+// Parse parses a price quoted in the given format (empty format = American)
+//
+// Examples (all the same price):
+// American "-125", Decimal "1.80", Fractional "4/5", Hong Kong "0.80",
+// Malay "0.80", Indonesian "-1.25"
+func Parse(value string, format Format) (Odds, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return Odds{}, fmt.Errorf("invalid %s odds: empty price", formatName(format))
+	}
+
+	switch format {
+	case "", American:
+		american, err := strconv.Atoi(strings.TrimPrefix(value, "+"))
+		if err != nil {
+			return Odds{}, fmt.Errorf("invalid American odds %q: %w", value, err)
+		}
+		return FromAmerican(american)
+
+	case Fractional:
+		return parseFractional(value)
+	}
+
+	x, ok := new(big.Rat).SetString(strings.TrimPrefix(value, "+"))
+	if !ok {
+		return Odds{}, fmt.Errorf("invalid %s odds %q: not a number", formatName(format), value)
+	}
+
+	switch format {
+	case Decimal:
+		// Decimal odds include the stake: 2.50 → 3/2 profit
+		if x.Cmp(ratOne) <= 0 {
+			return Odds{}, fmt.Errorf("invalid decimal odds %q: must be > 1", value)
+		}
+		return Odds{ratio: x.Sub(x, ratOne)}, nil
+
+	case HongKong:
+		if x.Sign() <= 0 {
+			return Odds{}, fmt.Errorf("invalid Hong Kong odds %q: must be > 0", value)
+		}
+		return Odds{ratio: x}, nil
+
+	case Malay:
+		// Favorites quote the profit (0 < m <= 1), underdogs -1/profit (-1 <= m < 0)
+		abs := new(big.Rat).Abs(x)
+		if x.Sign() == 0 || abs.Cmp(ratOne) > 0 {
+			return Odds{}, fmt.Errorf("invalid Malay odds %q: must be between -1 and 1 and non-zero", value)
+		}
+		if x.Sign() > 0 {
+			return Odds{ratio: x}, nil
+		}
+		return Odds{ratio: abs.Inv(abs)}, nil
+
+	case Indonesian:
+		// Underdogs quote the profit (i >= 1), favorites -1/profit (i <= -1)
+		abs := new(big.Rat).Abs(x)
+		if abs.Cmp(ratOne) < 0 {
+			return Odds{}, fmt.Errorf("invalid Indonesian odds %q: must be >= 1 or <= -1", value)
+		}
+		if x.Sign() > 0 {
+			return Odds{ratio: x}, nil
+		}
+		return Odds{ratio: abs.Inv(abs)}, nil
+
+	default:
+		return Odds{}, fmt.Errorf("unsupported odds format: %s", format)
+	}
+}
+
+// parseFractional parses "n/d" (or "evs"/"evens" for 1/1)
+func parseFractional(value string) (Odds, error) {
+	switch strings.ToLower(value) {
+	case "evs", "evens":
+		return Odds{ratio: big.NewRat(1, 1)}, nil
+	}
+
+	numerator, denominator, found := strings.Cut(value, "/")
+	if !found {
+		return Odds{}, fmt.Errorf("invalid fractional odds %q: expected n/d", value)
+	}
+
+	n, err := strconv.ParseInt(strings.TrimSpace(numerator), 10, 64)
+	if err != nil {
+		return Odds{}, fmt.Errorf("invalid fractional odds %q: %w", value, err)
+	}
+	d, err := strconv.ParseInt(strings.TrimSpace(denominator), 10, 64)
+	if err != nil {
+		return Odds{}, fmt.Errorf("invalid fractional odds %q: %w", value, err)
+	}
+	if n <= 0 || d <= 0 {
+		return Odds{}, fmt.Errorf("invalid fractional odds %q: must be positive", value)
+	}
+
+	return Odds{ratio: big.NewRat(n, d)}, nil
+}
+
+// IsValid reports whether the price was successfully created
+func (o Odds) IsValid() bool {
+	return o.ratio != nil && o.ratio.Sign() > 0
+}
+
+// Ratio returns a copy of the exact profit per unit staked
+func (o Odds) Ratio() *big.Rat {
+	if o.ratio == nil {
+		return new(big.Rat)
+	}
+	return new(big.Rat).Set(o.ratio)
+}
+
+// Equal reports whether two prices are exactly the same
+func (o Odds) Equal(other Odds) bool {
+	return o.Ratio().Cmp(other.Ratio()) == 0
+}
+
+// Decimal returns decimal odds (profit + stake)
+func (o Odds) Decimal() float64 {
+	decimal, _ := new(big.Rat).Add(o.Ratio(), ratOne).Float64()
+	return decimal
+}
+
+// ImpliedProbability returns 1 / decimal, computed exactly before rounding to float64
+func (o Odds) ImpliedProbability() float64 {
+	if !o.IsValid() {
+		return 0
+	}
+	decimal := new(big.Rat).Add(o.ratio, ratOne)
+	probability, _ := decimal.Inv(decimal).Float64()
+	return probability
+}
+
+// American returns the price as American odds, rounded to the nearest whole number
+// Exact for every price that came from American odds.
+func (o Odds) American() int {
+	if !o.IsValid() {
+		return 0
+	}
+	if o.ratio.Cmp(ratOne) >= 0 {
+		return roundRat(new(big.Rat).Mul(o.ratio, big.NewRat(100, 1)))
+	}
+	return -roundRat(new(big.Rat).Quo(big.NewRat(100, 1), o.ratio))
+}
+
+// Format renders the price in the given format (empty format = American)
+// Fractional is always exact; decimal formats print every digit when the value
+// terminates and round to two places when it doesn't (10/11 → "0.91").
+func (o Odds) Format(format Format) (string, error) {
+	if !o.IsValid() {
+		return "", fmt.Errorf("invalid odds: no price")
+	}
+
+	switch format {
+	case "", American:
+		return fmt.Sprintf("%+d", o.American()), nil
+
+	case Decimal:
+		return decimalString(new(big.Rat).Add(o.ratio, ratOne)), nil
+
+	case Fractional:
+		return o.ratio.Num().String() + "/" + o.ratio.Denom().String(), nil
+
+	case HongKong:
+		return decimalString(o.ratio), nil
+
+	case Malay:
+		if o.ratio.Cmp(ratOne) <= 0 {
+			return decimalString(o.ratio), nil
+		}
+		return decimalString(negInv(o.ratio)), nil
+
+	case Indonesian:
+		if o.ratio.Cmp(ratOne) >= 0 {
+			return decimalString(o.ratio), nil
+		}
+		return decimalString(negInv(o.ratio)), nil
+
+	default:
+		return "", fmt.Errorf("unsupported odds format: %s", format)
+	}
+}
+
+// FormatAmerican renders American odds in another format
+// Convenience function that combines FromAmerican + Format
+func FormatAmerican(american int, format Format) (string, error) {
+	price, err := FromAmerican(american)
+	if err != nil {
+		return "", err
+	}
+	return price.Format(format)
+}
+
+// IsSupportedFormat reports whether the format can be parsed and formatted
+func IsSupportedFormat(format Format) bool {
+	switch format {
+	case American, Decimal, Fractional,
+		HongKong, Malay, Indonesian:
+		return true
+	}
+	return false
+}
+
+// Quoted returns the exact price a quote was made at
+// value (in format) wins over the American price when set.
+func Quoted(american int, value string, format Format) (Odds, error) {
+	if value != "" {
+		return Parse(value, format)
+	}
+	return FromAmerican(american)
+}
+
+// decimalString prints x with every digit when it terminates, otherwise rounded
+// Always at least two places (2 → "2.00", 1.905 → "1.905", 21/11 → "1.91").
+func decimalString(x *big.Rat) string {
+	places, terminates := terminatingPlaces(x.Denom())
+	if !terminates {
+		return x.FloatString(displayPlaces)
+	}
+	if places < displayPlaces {
+		places = displayPlaces
+	}
+	return x.FloatString(places)
+}
+
+// terminatingPlaces returns the decimal places needed to print 1/denominator exactly
+// A fraction terminates only when its denominator has no prime factors besides 2 and 5.
+func terminatingPlaces(denominator *big.Int) (int, bool) {
+	rest, twos := removeFactor(denominator, 2)
+	rest, fives := removeFactor(rest, 5)
+	if rest.Cmp(big.NewInt(1)) != 0 {
+		return 0, false
+	}
+	if twos > fives {
+		return twos, true
+	}
+	return fives, true
+}
+
+// removeFactor divides n by prime as many times as it can, returning what's left and the count
+func removeFactor(n *big.Int, prime int64) (*big.Int, int) {
+	rest := new(big.Int).Set(n)
+	p := big.NewInt(prime)
+	count := 0
+	for {
+		quo, rem := new(big.Int).QuoRem(rest, p, new(big.Int))
+		if rem.Sign() != 0 {
+			return rest, count
+		}
+		rest = quo
+		count++
+	}
+}
+
+// negInv returns -1/x
+func negInv(x *big.Rat) *big.Rat {
+	inv := new(big.Rat).Inv(x)
+	return inv.Neg(inv)
+}
+
+// roundRat rounds a positive rational to the nearest integer (halves round up)
+func roundRat(x *big.Rat) int {
+	doubled := new(big.Int).Mul(x.Num(), big.NewInt(2))
+	doubled.Add(doubled, x.Denom())
+	quo := new(big.Int).Quo(doubled, new(big.Int).Mul(x.Denom(), big.NewInt(2)))
+	return int(quo.Int64())
+}
+
+// formatName returns a readable format name for errors
+func formatName(format Format) string {
+	switch format {
+	case "", American:
+		return "American"
+	case HongKong:
+		return "Hong Kong"
+	case Malay:
+		return "Malay"
+	case Indonesian:
+		return "Indonesian"
+	}
+	return string(format)
+}
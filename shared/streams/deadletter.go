@@ -0,0 +1,48 @@
+package streams
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DeadLetterFunc moves a poison entry from streamKey to a dead-letter stream
+// The entry is acked on streamKey once it returns nil.
+type DeadLetterFunc func(ctx context.Context, streamKey string, message redis.XMessage, deliveries int64) error
+
+// DeadLetterStream returns the stream poison messages from streamKey are moved to
+// Stream key format: odds.deadletter.{sport_key} for odds.raw.{sport_key},
+// deadletter.{stream_key} for every other stream
+func DeadLetterStream(streamKey string) string {
+	if sportKey, ok := strings.CutPrefix(streamKey, "odds.raw."); ok {
+		return fmt.Sprintf("odds.deadletter.%s", sportKey)
+	}
+	return fmt.Sprintf("deadletter.%s", streamKey)
+}
+
+// DeadLetterValues returns the fields a poison entry is stored with
+// The original fields are kept so the entry can be re-driven as-is.
+func DeadLetterValues(streamKey string, message redis.XMessage, deliveries int64, failedAt time.Time) map[string]interface{} {
+	values := make(map[string]interface{}, len(message.Values)+4)
+	for key, value := range message.Values {
+		values[key] = value
+	}
+	values["source_stream"] = streamKey
+	values["source_id"] = message.ID
+	values["deliveries"] = deliveries
+	values["failed_at"] = failedAt.UTC().Format(time.RFC3339Nano)
+	return values
+}
+
+// CopyToDeadLetter returns a DeadLetterFunc that copies entries to DeadLetterStream(streamKey)
+func CopyToDeadLetter(client *redis.Client) DeadLetterFunc {
+	return func(ctx context.Context, streamKey string, message redis.XMessage, deliveries int64) error {
+		return client.XAdd(ctx, &redis.XAddArgs{
+			Stream: DeadLetterStream(streamKey),
+			Values: DeadLetterValues(streamKey, message, deliveries, time.Now()),
+		}).Err()
+	}
+}
@@ -0,0 +1,215 @@
+package streams
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RecoveryConfig controls how idle pending entries are reclaimed
+type RecoveryConfig struct {
+	ClaimInterval time.Duration // How often to scan for idle pending entries
+	MinIdle       time.Duration // Entries idle at least this long are claimed
+	MaxDeliveries int64         // Deliveries allowed before an entry is dead-lettered
+	BatchSize     int64         // Entries claimed per scan
+}
+
+// DefaultRecoveryConfig returns the default recovery settings
+func DefaultRecoveryConfig() RecoveryConfig {
+	return RecoveryConfig{
+		ClaimInterval: 30 * time.Second,
+		MinIdle:       1 * time.Minute,
+		MaxDeliveries: 5,
+		BatchSize:     100,
+	}
+}
+
+// PendingStats describes a stream's backlog for the consumer group
+type PendingStats struct {
+	Stream       string `json:"stream"`
+	Pending      int64  `json:"pending"`       // Delivered to the group but not acked
+	Lag          int64  `json:"lag"`           // Entries not yet delivered to the group (-1 if unknown)
+	Claimed      int64  `json:"claimed"`       // Idle entries reclaimed by this consumer
+	DeadLettered int64  `json:"dead_lettered"` // Entries dead-lettered after MaxDeliveries
+}
+
+// PendingRecovery reclaims entries left pending by crashed consumers
+type PendingRecovery struct {
+	client     *redis.Client
+	groupName  string
+	consumerID string
+	config     RecoveryConfig
+	deadLetter DeadLetterFunc
+
+	mu           sync.Mutex
+	lastClaim    map[string]time.Time
+	claimed      map[string]int64
+	deadLettered map[string]int64
+}
+
+// NewPendingRecovery creates a recovery helper for one consumer
+// Poison entries are passed to deadLetter, or copied to DeadLetterStream(streamKey) if nil.
+func NewPendingRecovery(client *redis.Client, groupName, consumerID string, config RecoveryConfig, deadLetter DeadLetterFunc) *PendingRecovery {
+	if deadLetter == nil {
+		deadLetter = CopyToDeadLetter(client)
+	}
+	return &PendingRecovery{
+		client:       client,
+		groupName:    groupName,
+		consumerID:   consumerID,
+		config:       config,
+		deadLetter:   deadLetter,
+		lastClaim:    make(map[string]time.Time),
+		claimed:      make(map[string]int64),
+		deadLettered: make(map[string]int64),
+	}
+}
+
+// Due reports whether a stream should be scanned, and marks the scan
+// The first call per stream is always due so a restart picks up its backlog.
+func (r *PendingRecovery) Due(streamKey string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.lastClaim[streamKey]) < r.config.ClaimInterval {
+		return false
+	}
+	r.lastClaim[streamKey] = time.Now()
+	return true
+}
+
+// Claim takes over entries idle longer than MinIdle and returns the ones to retry
+// Entries delivered more than MaxDeliveries times are dead-lettered and acked.
+func (r *PendingRecovery) Claim(ctx context.Context, streamKey string) ([]redis.XMessage, error) {
+	messages, _, err := r.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   streamKey,
+		Group:    r.groupName,
+		Consumer: r.consumerID,
+		MinIdle:  r.config.MinIdle,
+		Start:    "0-0",
+		Count:    r.config.BatchSize,
+	}).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("xautoclaim %s: %w", streamKey, err)
+	}
+	if len(messages) == 0 {
+		return nil, nil
+	}
+
+	deliveries, err := r.deliveryCounts(ctx, streamKey, messages)
+	if err != nil {
+		return nil, err
+	}
+
+	retry := make([]redis.XMessage, 0, len(messages))
+	for _, message := range messages {
+		// Entry was trimmed from the stream while pending
+		if message.Values == nil {
+			r.client.XAck(ctx, streamKey, r.groupName, message.ID)
+			continue
+		}
+
+		count := deliveries[message.ID]
+		if r.config.MaxDeliveries > 0 && count > r.config.MaxDeliveries {
+			if err := r.moveToDeadLetter(ctx, streamKey, message, count); err != nil {
+				return retry, err
+			}
+			continue
+		}
+		retry = append(retry, message)
+	}
+
+	r.mu.Lock()
+	r.claimed[streamKey] += int64(len(retry))
+	r.mu.Unlock()
+
+	if len(retry) > 0 {
+		fmt.Printf("♻️  Reclaimed %d pending entries from %s\n", len(retry), streamKey)
+	}
+	return retry, nil
+}
+
+// deliveryCounts looks up how many times each claimed entry has been delivered
+func (r *PendingRecovery) deliveryCounts(ctx context.Context, streamKey string, messages []redis.XMessage) (map[string]int64, error) {
+	pipe := r.client.Pipeline()
+	cmds := make([]*redis.XPendingExtCmd, len(messages))
+	for i, message := range messages {
+		cmds[i] = pipe.XPendingExt(ctx, &redis.XPendingExtArgs{
+			Stream:   streamKey,
+			Group:    r.groupName,
+			Start:    message.ID,
+			End:      message.ID,
+			Count:    1,
+			Consumer: r.consumerID,
+		})
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("xpending %s: %w", streamKey, err)
+	}
+
+	counts := make(map[string]int64, len(messages))
+	for _, cmd := range cmds {
+		for _, entry := range cmd.Val() {
+			counts[entry.ID] = entry.RetryCount
+		}
+	}
+	return counts, nil
+}
+
+// moveToDeadLetter dead-letters a poison entry and acks it
+func (r *PendingRecovery) moveToDeadLetter(ctx context.Context, streamKey string, message redis.XMessage, deliveries int64) error {
+	if err := r.deadLetter(ctx, streamKey, message, deliveries); err != nil {
+		return fmt.Errorf("error dead-lettering %s: %w", message.ID, err)
+	}
+
+	if err := r.client.XAck(ctx, streamKey, r.groupName, message.ID).Err(); err != nil {
+		return fmt.Errorf("error acking dead-lettered %s: %w", message.ID, err)
+	}
+
+	r.mu.Lock()
+	r.deadLettered[streamKey]++
+	r.mu.Unlock()
+
+	fmt.Printf("⚠️  Dead-lettered %s from %s after %d deliveries\n", message.ID, streamKey, deliveries)
+	return nil
+}
+
+// Stats returns pending and lag counts for the group plus this consumer's recovery counts
+func (r *PendingRecovery) Stats(ctx context.Context, streamKey string) (PendingStats, error) {
+	r.mu.Lock()
+	stats := PendingStats{
+		Stream:       streamKey,
+		Lag:          -1,
+		Claimed:      r.claimed[streamKey],
+		DeadLettered: r.deadLettered[streamKey],
+	}
+	r.mu.Unlock()
+
+	pending, err := r.client.XPending(ctx, streamKey, r.groupName).Result()
+	if err != nil && err != redis.Nil {
+		return stats, fmt.Errorf("xpending %s: %w", streamKey, err)
+	}
+	if pending != nil {
+		stats.Pending = pending.Count
+	}
+
+	// Lag is only reported by Redis 7+
+	groups, err := r.client.XInfoGroups(ctx, streamKey).Result()
+	if err != nil {
+		return stats, fmt.Errorf("xinfo groups %s: %w", streamKey, err)
+	}
+	for _, group := range groups {
+		if group.Name == r.groupName {
+			stats.Lag = group.Lag
+			break
+		}
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,81 @@
+package odds_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/XavierBriggs/fortuna/services/shared/odds"
+)
+
+func TestAmericanToDecimal(t *testing.T) {
+	tests := []struct {
+		american int
+		want     float64
+	}{
+		{100, 2.0},
+		{150, 2.5},
+		{-150, 1.0 + 100.0/150.0},
+		{-110, 1.0 + 100.0/110.0},
+		{-100, 2.0},
+		{1000, 11.0},
+	}
+
+	for _, tt := range tests {
+		if got := odds.AmericanToDecimal(tt.american); math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("AmericanToDecimal(%d) = %v, want %v", tt.american, got, tt.want)
+		}
+	}
+}
+
+func TestDecimalToAmerican(t *testing.T) {
+	tests := []struct {
+		decimal float64
+		want    int
+	}{
+		{2.0, 100},
+		{2.5, 150},
+		{11.0, 1000},
+		{1.0 + 100.0/110.0, -110},
+		{1.5, -200},
+		{1.667, -150}, // Rounded, not truncated to -149
+		{2.349, 135},  // Rounded, not truncated to +134
+	}
+
+	for _, tt := range tests {
+		if got := odds.DecimalToAmerican(tt.decimal); got != tt.want {
+			t.Errorf("DecimalToAmerican(%v) = %d, want %d", tt.decimal, got, tt.want)
+		}
+	}
+}
+
+func TestAmericanToImpliedProbability(t *testing.T) {
+	if got := odds.AmericanToImpliedProbability(-110); math.Abs(got-110.0/210.0) > 1e-12 {
+		t.Errorf("AmericanToImpliedProbability(-110) = %v, want %v", got, 110.0/210.0)
+	}
+	if got := odds.AmericanToImpliedProbability(300); math.Abs(got-0.25) > 1e-12 {
+		t.Errorf("AmericanToImpliedProbability(300) = %v, want 0.25", got)
+	}
+}
+
+func TestPriceMoveCents(t *testing.T) {
+	tests := []struct {
+		name string
+		from int
+		to   int
+		want int
+	}{
+		{"Favorite shortens", -110, -120, 10},
+		{"Favorite lengthens", -120, -110, -10},
+		{"Crosses even", -105, 105, -10},
+		{"Underdog shortens", 150, 130, 20},
+		{"Unchanged", -110, -110, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := odds.PriceMoveCents(tt.from, tt.to); got != tt.want {
+				t.Errorf("PriceMoveCents(%d, %d) = %d, want %d", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package odds_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/XavierBriggs/fortuna/services/shared/odds"
+)
+
+var allFormats = []odds.Format{
+	odds.American,
+	odds.Decimal,
+	odds.Fractional,
+	odds.HongKong,
+	odds.Malay,
+	odds.Indonesian,
+}
+
+func TestFormatAmerican(t *testing.T) {
+	tests := []struct {
+		american int
+		format   odds.Format
+		want     string
+	}{
+		{-110, odds.American, "-110"},
+		{150, odds.American, "+150"},
+		{150, odds.Decimal, "2.50"},
+		{-125, odds.Decimal, "1.80"},
+		{-110, odds.Decimal, "1.91"},
+		{-110, odds.Fractional, "10/11"},
+		{150, odds.Fractional, "3/2"},
+		{100, odds.Fractional, "1/1"},
+		{150, odds.HongKong, "1.50"},
+		{-125, odds.HongKong, "0.80"},
+		{-125, odds.Malay, "0.80"},
+		{150, odds.Malay, "-0.67"},
+		{200, odds.Malay, "-0.50"},
+		{150, odds.Indonesian, "1.50"},
+		{-125, odds.Indonesian, "-1.25"},
+		{-110, odds.Indonesian, "-1.10"},
+		{-105, odds.Indonesian, "-1.05"},
+		{-105, odds.Decimal, "1.95"},
+		{-400, odds.Decimal, "1.25"},
+		{-160, odds.Decimal, "1.625"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			got, err := odds.FormatAmerican(tt.american, tt.format)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("FormatAmerican(%d, %s) = %q, want %q", tt.american, tt.format, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value    string
+		format   odds.Format
+		american int
+	}{
+		{"-110", odds.American, -110},
+		{"+150", "", 150},
+		{"2.50", odds.Decimal, 150},
+		{"1.80", odds.Decimal, -125},
+		{"10/11", odds.Fractional, -110},
+		{"evs", odds.Fractional, 100},
+		{"0.80", odds.HongKong, -125},
+		{"0.80", odds.Malay, -125},
+		{"-0.50", odds.Malay, 200},
+		{"-1.25", odds.Indonesian, -125},
+		{"2.00", odds.Indonesian, 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format)+" "+tt.value, func(t *testing.T) {
+			price, err := odds.Parse(tt.value, tt.format)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := price.American(); got != tt.american {
+				t.Errorf("Parse(%q, %s).American() = %d, want %d", tt.value, tt.format, got, tt.american)
+			}
+		})
+	}
+}
+
+func TestParseOdds_Invalid(t *testing.T) {
+	tests := []struct {
+		value  string
+		format odds.Format
+	}{
+		{"", odds.Decimal},
+		{"-99", odds.American},
+		{"1.00", odds.Decimal},
+		{"abc", odds.Decimal},
+		{"3-2", odds.Fractional},
+		{"0/1", odds.Fractional},
+		{"0", odds.HongKong},
+		{"1.20", odds.Malay},
+		{"0", odds.Malay},
+		{"0.50", odds.Indonesian},
+		{"2.00", "moneyline"},
+	}
+
+	for _, tt := range tests {
+		if _, err := odds.Parse(tt.value, tt.format); err == nil {
+			t.Errorf("Parse(%q, %s) expected error", tt.value, tt.format)
+		}
+	}
+}
+
+// Every American price survives a trip through every format exactly when the format
+// can represent it (fractional always; decimal formats when the value terminates)
+func TestOdds_RoundTripFromAmerican(t *testing.T) {
+	for american := -1000; american <= 1000; american++ {
+		if american > -100 && american < 100 {
+			continue
+		}
+		price, err := odds.FromAmerican(american)
+		if err != nil {
+			t.Fatalf("FromAmerican(%d): %v", american, err)
+		}
+
+		for _, format := range allFormats {
+			formatted, err := price.Format(format)
+			if err != nil {
+				t.Fatalf("Format(%d, %s): %v", american, format, err)
+			}
+			parsed, err := odds.Parse(formatted, format)
+			if err != nil {
+				t.Fatalf("Parse(%q, %s): %v", formatted, format, err)
+			}
+
+			// Format → Parse → Format is stable for every price
+			again, _ := parsed.Format(format)
+			if again != formatted {
+				t.Errorf("%d %s: %q re-formatted as %q", american, format, formatted, again)
+			}
+
+			if format == odds.Fractional || format == odds.American {
+				if !parsed.Equal(price) {
+					t.Errorf("%d %s: %q did not round-trip exactly", american, format, formatted)
+				}
+			}
+		}
+	}
+}
+
+// Quoted prices keep their exact value across formats, so re-quoting in the
+// original format returns the original string
+func TestOdds_RoundTripQuoted(t *testing.T) {
+	tests := []struct {
+		value  string
+		format odds.Format
+	}{
+		{"1.905", odds.Decimal},
+		{"2.375", odds.Decimal},
+		{"13/8", odds.Fractional},
+		{"100/30", odds.Fractional},
+		{"0.93", odds.HongKong},
+		{"0.87", odds.Malay},
+		{"-0.92", odds.Malay},
+		{"-1.08", odds.Indonesian},
+		{"1.15", odds.Indonesian},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format)+" "+tt.value, func(t *testing.T) {
+			price, err := odds.Parse(tt.value, tt.format)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// Through fractional (always exact) and back
+			fraction, _ := price.Format(odds.Fractional)
+			viaFraction, err := odds.Parse(fraction, odds.Fractional)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", fraction, err)
+			}
+			if !viaFraction.Equal(price) {
+				t.Errorf("%q via %q lost precision", tt.value, fraction)
+			}
+
+			want, _ := price.Format(tt.format)
+			got, _ := viaFraction.Format(tt.format)
+			if got != want {
+				t.Errorf("%q re-quoted as %q, want %q", tt.value, got, want)
+			}
+		})
+	}
+}
+
+func TestOdds_ImpliedProbability(t *testing.T) {
+	price, err := odds.Parse("10/11", odds.Fractional)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := odds.AmericanToImpliedProbability(-110)
+	if got := price.ImpliedProbability(); math.Abs(got-want) > 1e-12 {
+		t.Errorf("ImpliedProbability() = %v, want %v", got, want)
+	}
+	if got := price.Decimal(); math.Abs(got-21.0/11.0) > 1e-12 {
+		t.Errorf("Decimal() = %v, want %v", got, 21.0/11.0)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    odds.Format
+		wantErr bool
+	}{
+		{"", odds.American, false},
+		{"Decimal", odds.Decimal, false},
+		{"fractional", odds.Fractional, false},
+		{"hk", odds.HongKong, false},
+		{"malay", odds.Malay, false},
+		{"indonesian", odds.Indonesian, false},
+		{"moneyline", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := odds.ParseFormat(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseFormat(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseFormat(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFormatAmerican_Invalid(t *testing.T) {
+	if _, err := odds.FormatAmerican(50, odds.Decimal); err == nil {
+		t.Error("FormatAmerican(50) expected error")
+	}
+}
+
+func TestQuoted(t *testing.T) {
+	// The quoted value wins over the rounded American price
+	price, err := odds.Quoted(-108, "1.93", odds.Decimal)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(price.Decimal()-1.93) > 1e-12 {
+		t.Errorf("Decimal() = %v, want 1.93", price.Decimal())
+	}
+
+	price, err = odds.Quoted(-110, "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if price.American() != -110 {
+		t.Errorf("American() = %d, want -110", price.American())
+	}
+
+	if _, err := odds.Quoted(0, "", ""); err == nil {
+		t.Error("Quoted(0) expected error")
+	}
+}
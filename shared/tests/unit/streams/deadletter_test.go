@@ -0,0 +1,57 @@
+package streams_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/XavierBriggs/fortuna/services/shared/streams"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestDeadLetterStream(t *testing.T) {
+	tests := []struct {
+		streamKey string
+		want      string
+	}{
+		{"odds.raw.basketball_nba", "odds.deadletter.basketball_nba"},
+		{"odds.normalized.soccer_epl", "deadletter.odds.normalized.soccer_epl"},
+		{"opportunities.detected", "deadletter.opportunities.detected"},
+	}
+
+	for _, tt := range tests {
+		if got := streams.DeadLetterStream(tt.streamKey); got != tt.want {
+			t.Errorf("DeadLetterStream(%s) = %s, want %s", tt.streamKey, got, tt.want)
+		}
+	}
+}
+
+func TestDeadLetterValues_KeepsOriginalFields(t *testing.T) {
+	message := redis.XMessage{
+		ID:     "1700000000000-0",
+		Values: map[string]interface{}{"data": `{"event_id":"evt1"}`, "attempts": "2"},
+	}
+	failedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	values := streams.DeadLetterValues("odds.normalized.basketball_nba", message, 6, failedAt)
+
+	if values["data"] != `{"event_id":"evt1"}` || values["attempts"] != "2" {
+		t.Errorf("expected original fields to be kept, got %v", values)
+	}
+	if values["source_stream"] != "odds.normalized.basketball_nba" {
+		t.Errorf("expected source_stream odds.normalized.basketball_nba, got %v", values["source_stream"])
+	}
+	if values["source_id"] != message.ID {
+		t.Errorf("expected source_id %s, got %v", message.ID, values["source_id"])
+	}
+	if values["deliveries"] != int64(6) {
+		t.Errorf("expected deliveries 6, got %v", values["deliveries"])
+	}
+	if values["failed_at"] != "2025-01-02T03:04:05Z" {
+		t.Errorf("expected failed_at 2025-01-02T03:04:05Z, got %v", values["failed_at"])
+	}
+
+	// The message itself is untouched
+	if _, ok := message.Values["source_id"]; ok {
+		t.Errorf("expected message values not to be modified")
+	}
+}
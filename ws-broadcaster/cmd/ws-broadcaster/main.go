@@ -48,7 +48,7 @@ func main() {
 	go streamConsumer.Start(ctx)
 
 	// Create HTTP handler (pass context for WebSocket lifecycle)
-	handler := handlers.NewHandler(h, ctx)
+	handler := handlers.NewHandlerWithConsumer(h, ctx, streamConsumer)
 
 	// Set up HTTP routes
 	mux := http.NewServeMux()
@@ -3,6 +3,7 @@ module github.com/XavierBriggs/fortuna/services/ws-broadcaster
 go 1.21
 
 require (
+	github.com/XavierBriggs/fortuna/services/shared v0.0.0
 	github.com/google/uuid v1.6.0
 	github.com/gorilla/websocket v1.5.1
 	github.com/redis/go-redis/v9 v9.0.5
@@ -14,6 +15,4 @@ require (
 	golang.org/x/net v0.17.0 // indirect
 )
 
-
-
-
+replace github.com/XavierBriggs/fortuna/services/shared => ../shared
@@ -3,7 +3,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // StreamConfig defines which Redis streams to consume from
@@ -26,6 +28,11 @@ type StreamConfig struct {
 	// Consumer group and ID
 	ConsumerGroup string
 	ConsumerID    string
+
+	// Pending-entry recovery
+	ClaimInterval time.Duration // How often to reclaim idle pending entries
+	ClaimMinIdle  time.Duration // Idle time before a pending entry is reclaimed
+	MaxDeliveries int64         // Deliveries before an entry is dead-lettered
 }
 
 // ServerConfig holds server configuration
@@ -93,6 +100,9 @@ func loadStreamConfig() StreamConfig {
 		OpportunitiesStream:   "opportunities.detected",
 		ConsumerGroup:         getEnv("CONSUMER_GROUP", "ws-broadcaster"),
 		ConsumerID:            getEnv("CONSUMER_ID", "broadcaster-1"),
+		ClaimInterval:         getEnvDuration("STREAM_CLAIM_INTERVAL", 30*time.Second),
+		ClaimMinIdle:          getEnvDuration("STREAM_CLAIM_MIN_IDLE", 1*time.Minute),
+		MaxDeliveries:         int64(getEnvInt("STREAM_MAX_DELIVERIES", 5)),
 	}
 }
 
@@ -104,6 +114,26 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvInt gets an integer environment variable or returns a default value
+func getEnvInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
+// getEnvDuration gets a duration environment variable (e.g. "30s") or returns a default value
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
+
 // GetAllStreams returns all streams to consume from
 func (sc *StreamConfig) GetAllStreams() []string {
 	streams := make([]string, 0, len(sc.NormalizedOddsStreams)+len(sc.GameUpdatesStreams)+len(sc.LiveGameStreams)+len(sc.GameStatsStreams)+1)
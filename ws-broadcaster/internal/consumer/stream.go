@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/XavierBriggs/fortuna/services/shared/streams"
 	"github.com/XavierBriggs/fortuna/services/ws-broadcaster/internal/config"
 	"github.com/XavierBriggs/fortuna/services/ws-broadcaster/internal/hub"
 	"github.com/XavierBriggs/fortuna/services/ws-broadcaster/pkg/models"
@@ -26,6 +27,7 @@ type StreamConsumer struct {
 	redis        *redis.Client
 	hub          *hub.Hub
 	streamConfig config.StreamConfig
+	recovery     *streams.PendingRecovery
 }
 
 // NewStreamConsumer creates a new stream consumer
@@ -34,9 +36,25 @@ func NewStreamConsumer(redisClient *redis.Client, h *hub.Hub, streamConfig confi
 		redis:        redisClient,
 		hub:          h,
 		streamConfig: streamConfig,
+		recovery:     streams.NewPendingRecovery(redisClient, streamConfig.ConsumerGroup, streamConfig.ConsumerID, recoveryConfig(streamConfig), nil),
 	}
 }
 
+// recoveryConfig builds recovery settings from the stream config (zero values use defaults)
+func recoveryConfig(streamConfig config.StreamConfig) RecoveryConfig {
+	recovery := DefaultRecoveryConfig()
+	if streamConfig.ClaimInterval > 0 {
+		recovery.ClaimInterval = streamConfig.ClaimInterval
+	}
+	if streamConfig.ClaimMinIdle > 0 {
+		recovery.MinIdle = streamConfig.ClaimMinIdle
+	}
+	if streamConfig.MaxDeliveries > 0 {
+		recovery.MaxDeliveries = streamConfig.MaxDeliveries
+	}
+	return recovery
+}
+
 // Start begins consuming from Redis Streams
 func (sc *StreamConsumer) Start(ctx context.Context) error {
 	fmt.Println("✓ Stream consumer started")
@@ -79,6 +97,17 @@ func (sc *StreamConsumer) consumeStream(ctx context.Context, stream string) {
 		case <-ctx.Done():
 			return
 		default:
+			// Retry entries left pending by a crashed broadcaster (or by us before a restart)
+			if sc.recovery.Due(stream) {
+				claimed, err := sc.recovery.Claim(ctx, stream)
+				if err != nil {
+					fmt.Printf("⚠️  Claim pending error (%s): %v\n", stream, err)
+				}
+				for _, message := range claimed {
+					sc.processMessage(ctx, stream, message)
+				}
+			}
+
 			// Read messages from stream
 			streams, err := sc.redis.XReadGroup(ctx, &redis.XReadGroupArgs{
 				Group:    sc.streamConfig.ConsumerGroup,
@@ -233,6 +262,20 @@ func (sc *StreamConsumer) processMinervaGameStats(ctx context.Context, stream st
 	sc.ackMessage(ctx, stream, messageID)
 }
 
+// PendingStats returns the backlog and recovery counts for every consumed stream
+func (sc *StreamConsumer) PendingStats(ctx context.Context) []PendingStats {
+	streams := sc.streamConfig.GetAllStreams()
+	stats := make([]PendingStats, 0, len(streams))
+	for _, stream := range streams {
+		streamStats, err := sc.recovery.Stats(ctx, stream)
+		if err != nil {
+			fmt.Printf("⚠️  Failed to read pending stats (%s): %v\n", stream, err)
+		}
+		stats = append(stats, streamStats)
+	}
+	return stats
+}
+
 // ackMessage acknowledges a message in the stream
 func (sc *StreamConsumer) ackMessage(ctx context.Context, stream string, messageID string) {
 	err := sc.redis.XAck(ctx, stream, sc.streamConfig.ConsumerGroup, messageID).Err()
@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/XavierBriggs/fortuna/services/ws-broadcaster/internal/client"
+	"github.com/XavierBriggs/fortuna/services/ws-broadcaster/internal/consumer"
 	"github.com/XavierBriggs/fortuna/services/ws-broadcaster/internal/hub"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
@@ -24,8 +25,9 @@ var upgrader = websocket.Upgrader{
 
 // Handler manages HTTP endpoints
 type Handler struct {
-	hub *hub.Hub
-	ctx context.Context
+	hub     *hub.Hub
+	ctx     context.Context
+	streams *consumer.StreamConsumer
 }
 
 // NewHandler creates a new handler instance
@@ -36,6 +38,13 @@ func NewHandler(h *hub.Hub, ctx context.Context) *Handler {
 	}
 }
 
+// NewHandlerWithConsumer creates a handler that also reports stream backlog in /metrics
+func NewHandlerWithConsumer(h *hub.Hub, ctx context.Context, streams *consumer.StreamConsumer) *Handler {
+	handler := NewHandler(h, ctx)
+	handler.streams = streams
+	return handler
+}
+
 // HandleWebSocket upgrades HTTP connections to WebSocket
 func (h *Handler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 	// Upgrade connection
@@ -74,6 +83,9 @@ func (h *Handler) HandleHealth(w http.ResponseWriter, r *http.Request) {
 // HandleMetrics returns hub metrics
 func (h *Handler) HandleMetrics(w http.ResponseWriter, r *http.Request) {
 	metrics := h.hub.GetMetrics()
+	if h.streams != nil {
+		metrics["streams"] = h.streams.PendingStats(r.Context())
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(metrics)
@@ -3,6 +3,7 @@ package config_test
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/XavierBriggs/fortuna/services/ws-broadcaster/internal/config"
 )
@@ -86,6 +87,39 @@ func TestLoadConfig_CustomValues(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_Recovery(t *testing.T) {
+	os.Clearenv()
+
+	cfg := config.LoadConfig()
+
+	if cfg.Stream.ClaimInterval != 30*time.Second {
+		t.Errorf("Expected default claim interval 30s, got %s", cfg.Stream.ClaimInterval)
+	}
+	if cfg.Stream.ClaimMinIdle != time.Minute {
+		t.Errorf("Expected default claim min idle 1m, got %s", cfg.Stream.ClaimMinIdle)
+	}
+	if cfg.Stream.MaxDeliveries != 5 {
+		t.Errorf("Expected default max deliveries 5, got %d", cfg.Stream.MaxDeliveries)
+	}
+
+	os.Setenv("STREAM_CLAIM_INTERVAL", "10s")
+	os.Setenv("STREAM_CLAIM_MIN_IDLE", "2m")
+	os.Setenv("STREAM_MAX_DELIVERIES", "3")
+	defer os.Clearenv()
+
+	cfg = config.LoadConfig()
+
+	if cfg.Stream.ClaimInterval != 10*time.Second {
+		t.Errorf("Expected claim interval 10s, got %s", cfg.Stream.ClaimInterval)
+	}
+	if cfg.Stream.ClaimMinIdle != 2*time.Minute {
+		t.Errorf("Expected claim min idle 2m, got %s", cfg.Stream.ClaimMinIdle)
+	}
+	if cfg.Stream.MaxDeliveries != 3 {
+		t.Errorf("Expected max deliveries 3, got %d", cfg.Stream.MaxDeliveries)
+	}
+}
+
 func TestStreamConfig_MultipleSports(t *testing.T) {
 	os.Setenv("SPORTS", "basketball_nba,americanfootball_nfl,baseball_mlb")
 	defer os.Clearenv()